@@ -0,0 +1,57 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerOption returns the option a gRPC server the store is registered with has to be made with.
+// The api_v2 messages are generated by gogo/protobuf, and those holding a custom type, such as the
+// model.TraceID of a GetTraceRequest, cannot be encoded by the codec gRPC uses by default, which
+// reads a message by reflection; the option has the server encode them with their own methods.
+func ServerOption() grpc.ServerOption {
+	return grpc.ForceServerCodec(codec{})
+}
+
+// DialOption returns the option a client connection to a server made with ServerOption has to be
+// made with, for the same reason.
+func DialOption() grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.ForceCodec(codec{}))
+}
+
+// codec encodes a gogo/protobuf message with gogo/protobuf, and any other with protobuf. It keeps
+// the name of the default codec, since the wire format is the same.
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	switch message := v.(type) {
+	case proto.Message:
+		return proto.Marshal(message)
+	case gogoproto.Message:
+		return gogoproto.Marshal(message)
+	}
+	return nil, fmt.Errorf("cannot encode %T, which is not a protobuf message", v)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	switch message := v.(type) {
+	case proto.Message:
+		return proto.Unmarshal(data, message)
+	case gogoproto.Message:
+		return gogoproto.Unmarshal(data, message)
+	}
+	return fmt.Errorf("cannot decode into %T, which is not a protobuf message", v)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
+
+var _ encoding.Codec = codec{}
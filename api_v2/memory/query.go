@@ -0,0 +1,274 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/query/chunk"
+	"github.com/jaegertracing/jaeger-idl/storage/v2/dependencies"
+)
+
+// GetTrace streams the spans of the trace the request names, in chunks each under the size gRPC
+// accepts, in the order they were written. A trace the store does not hold is looked for among
+// those ArchiveTrace copied, and one found in neither is answered with NotFound.
+//
+// A time range narrows where the trace is looked for, as it would in a backend partitioned by time:
+// a trace none of whose spans started within it is not found. Once found, a trace is returned whole.
+func (s *Store) GetTrace(req *api_v2.GetTraceRequest, stream api_v2.QueryService_GetTraceServer) error {
+	t, err := s.find(req.TraceID, req.StartTime, req.EndTime)
+	if err != nil {
+		return err
+	}
+	return s.send(t, stream.Send)
+}
+
+// ArchiveTrace copies the trace the request names to the archive, where GetTrace finds it once it
+// is no longer stored. A trace the store does not hold is answered with NotFound.
+func (s *Store) ArchiveTrace(_ context.Context, req *api_v2.ArchiveTraceRequest) (*api_v2.ArchiveTraceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.traces[req.TraceID]
+	if !ok || !slices.ContainsFunc(t.spans, startedWithin(req.StartTime, req.EndTime)) {
+		return nil, status.Errorf(codes.NotFound, "trace %s not found", req.TraceID)
+	}
+	s.archive[t.id] = &trace{id: t.id, spans: slices.Clone(t.spans)}
+	return &api_v2.ArchiveTraceResponse{}, nil
+}
+
+// FindTraces streams the traces that hold a span matching every parameter of the query, each whole
+// and in chunks, the trace that started last first. A span matches when:
+//
+//   - its service is ServiceName, and its operation is OperationName, where either is given;
+//   - for every tag of Tags, it has a tag, a tag of its process or a field of one of its logs with
+//     that key, whose value reads as the tag's value;
+//   - it started within StartTimeMin and StartTimeMax, inclusive, where either is given;
+//   - it lasted at least DurationMin and at most DurationMax, where either is given.
+//
+// A SearchDepth above zero is the most traces returned; otherwise every trace that matches is. A
+// request without a query, or whose minimum exceeds its maximum, is refused with InvalidArgument.
+func (s *Store) FindTraces(req *api_v2.FindTracesRequest, stream api_v2.QueryService_FindTracesServer) error {
+	query := req.Query
+	switch {
+	case query == nil:
+		return status.Error(codes.InvalidArgument, "query is required")
+	case query.DurationMax != 0 && query.DurationMin > query.DurationMax:
+		return status.Error(codes.InvalidArgument, "duration_min exceeds duration_max")
+	case !query.StartTimeMin.IsZero() && !query.StartTimeMax.IsZero() && query.StartTimeMin.After(query.StartTimeMax):
+		return status.Error(codes.InvalidArgument, "start_time_min is after start_time_max")
+	}
+	type found struct {
+		trace *trace
+		start time.Time
+	}
+	var traces []found
+	s.mu.RLock()
+	for _, t := range s.traces {
+		if slices.ContainsFunc(t.spans, func(span *model.Span) bool { return matches(query, span) }) {
+			traces = append(traces, found{trace: t, start: t.start()})
+		}
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(traces, func(a, b found) int {
+		if order := b.start.Compare(a.start); order != 0 {
+			return order
+		}
+		return strings.Compare(a.trace.id.String(), b.trace.id.String())
+	})
+	if query.SearchDepth > 0 && len(traces) > int(query.SearchDepth) {
+		traces = traces[:query.SearchDepth]
+	}
+	for _, f := range traces {
+		if err := s.send(f.trace, stream.Send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetServices returns the name of every service a stored span was written by, sorted.
+func (s *Store) GetServices(context.Context, *api_v2.GetServicesRequest) (*api_v2.GetServicesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var services []string
+	for _, t := range s.traces {
+		for _, span := range t.spans {
+			if !slices.Contains(services, span.Process.ServiceName) {
+				services = append(services, span.Process.ServiceName)
+			}
+		}
+	}
+	slices.Sort(services)
+	return &api_v2.GetServicesResponse{Services: services}, nil
+}
+
+// GetOperations returns the operations a service has written, each with the span.kind of its
+// spans, sorted by name and then by kind; given a kind, only operations of that kind are returned.
+// A span without a span.kind has an empty one. OperationNames, which older clients read, holds the
+// names alone, once each.
+func (s *Store) GetOperations(_ context.Context, req *api_v2.GetOperationsRequest) (*api_v2.GetOperationsResponse, error) {
+	if req.Service == "" {
+		return nil, status.Error(codes.InvalidArgument, "service is required")
+	}
+	if _, err := model.SpanKindFromString(req.SpanKind); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	type operation struct{ name, kind string }
+	seen := map[operation]bool{}
+	s.mu.RLock()
+	for _, t := range s.traces {
+		for _, span := range t.spans {
+			if span.Process.ServiceName != req.Service {
+				continue
+			}
+			kind, _ := span.GetSpanKind()
+			if req.SpanKind == "" || string(kind) == req.SpanKind {
+				seen[operation{name: span.OperationName, kind: string(kind)}] = true
+			}
+		}
+	}
+	s.mu.RUnlock()
+	response := &api_v2.GetOperationsResponse{}
+	for op := range seen {
+		response.Operations = append(response.Operations, &api_v2.Operation{Name: op.name, SpanKind: op.kind})
+		if !slices.Contains(response.OperationNames, op.name) {
+			response.OperationNames = append(response.OperationNames, op.name)
+		}
+	}
+	slices.SortFunc(response.Operations, func(a, b *api_v2.Operation) int {
+		if order := strings.Compare(a.Name, b.Name); order != 0 {
+			return order
+		}
+		return strings.Compare(a.SpanKind, b.SpanKind)
+	})
+	slices.Sort(response.OperationNames)
+	return response, nil
+}
+
+// GetDependencies returns the links between services that the traces holding a span started within
+// the range show, derived as package dependencies derives them. A zero end of the range leaves that
+// end open.
+func (s *Store) GetDependencies(_ context.Context, req *api_v2.GetDependenciesRequest) (*api_v2.GetDependenciesResponse, error) {
+	aggregator := dependencies.NewAggregator(0)
+	within := startedWithin(req.StartTime, req.EndTime)
+	s.mu.RLock()
+	for _, t := range s.traces {
+		if slices.ContainsFunc(t.spans, within) {
+			aggregator.AddModelTrace(t.model())
+		}
+	}
+	s.mu.RUnlock()
+	response := &api_v2.GetDependenciesResponse{}
+	for _, window := range aggregator.Windows() {
+		for _, link := range window.Dependencies {
+			response.Dependencies = append(response.Dependencies, model.DependencyLink{
+				Parent:    link.GetParent(),
+				Child:     link.GetChild(),
+				CallCount: link.GetCallCount(),
+				Source:    link.GetSource(),
+			})
+		}
+	}
+	return response, nil
+}
+
+// find returns the stored or archived trace with an ID, which has a span started within the range.
+func (s *Store) find(id model.TraceID, start, end time.Time) (*trace, error) {
+	within := startedWithin(start, end)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, traces := range []map[model.TraceID]*trace{s.traces, s.archive} {
+		if t, ok := traces[id]; ok && slices.ContainsFunc(t.spans, within) {
+			return &trace{id: t.id, spans: slices.Clone(t.spans)}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "trace %s not found", id)
+}
+
+// send sends the spans of a trace in chunks of at most chunkSize bytes.
+func (s *Store) send(t *trace, send func(*api_v2.SpansResponseChunk) error) error {
+	spans := make([]model.Span, len(t.spans))
+	for i, span := range t.spans {
+		spans[i] = *span
+	}
+	for message, err := range chunk.SplitSpans(spans, s.chunkSize) {
+		if err != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if err := send(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start returns when the earliest span of the trace started.
+func (t *trace) start() time.Time {
+	var start time.Time
+	for _, span := range t.spans {
+		if start.IsZero() || span.StartTime.Before(start) {
+			start = span.StartTime
+		}
+	}
+	return start
+}
+
+// model returns the trace as a model.Trace, whose spans carry their processes.
+func (t *trace) model() *model.Trace {
+	return &model.Trace{Spans: t.spans}
+}
+
+// startedWithin returns whether a span started within a range, inclusive, whose zero ends are open.
+func startedWithin(start, end time.Time) func(*model.Span) bool {
+	return func(span *model.Span) bool {
+		return (start.IsZero() || !span.StartTime.Before(start)) && (end.IsZero() || !span.StartTime.After(end))
+	}
+}
+
+// matches returns whether a span matches every parameter of a query, as FindTraces documents.
+func matches(query *api_v2.TraceQueryParameters, span *model.Span) bool {
+	switch {
+	case query.ServiceName != "" && span.Process.ServiceName != query.ServiceName:
+		return false
+	case query.OperationName != "" && span.OperationName != query.OperationName:
+		return false
+	case !startedWithin(query.StartTimeMin, query.StartTimeMax)(span):
+		return false
+	case query.DurationMin != 0 && span.Duration < query.DurationMin:
+		return false
+	case query.DurationMax != 0 && span.Duration > query.DurationMax:
+		return false
+	}
+	for key, value := range query.Tags {
+		if !hasTag(span, key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasTag returns whether a span, its process or one of its logs has a tag with a key whose value
+// reads as value.
+func hasTag(span *model.Span, key, value string) bool {
+	lists := []model.KeyValues{span.Tags, span.Process.Tags}
+	for _, log := range span.Logs {
+		lists = append(lists, log.Fields)
+	}
+	for _, tags := range lists {
+		for _, tag := range tags {
+			if tag.Key == key && tag.AsString() == value {
+				return true
+			}
+		}
+	}
+	return false
+}
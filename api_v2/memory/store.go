@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements the api_v2 QueryService and CollectorService (jaeger.api_v2) over
+// spans held in memory, as a stand-in for a Jaeger query service and collector wherever a test
+// wants one: a client of the API is tested against a server that answers every call the way Jaeger
+// does, without running Jaeger. It is a reference rather than a server to run in production.
+// Nothing is evicted, and every search walks every span.
+//
+// Spans are written to a Store through PostSpans, in the model.Batch a collector takes them in, and
+// read back through the QueryService. Dependencies are not written: GetDependencies derives them
+// from the stored spans, as package dependencies derives them. Listen serves a Store over an
+// in-memory connection, so that a test needs no network; a Store served otherwise needs the codec
+// ServerOption and DialOption set, as the api_v2 messages are generated by gogo/protobuf.
+package memory
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/query/chunk"
+)
+
+// listenerBufferSize is the size of the buffer of the in-memory connection Listen serves on.
+const listenerBufferSize = 1 << 20
+
+// Store holds spans in memory and serves them over the api_v2 QueryService, and takes them over
+// the CollectorService. The zero value is not ready for use; a Store is made with NewStore.
+//
+// A Store is safe for concurrent use: a write is seen in full by every call that starts after it
+// returns, and by no call that started before.
+type Store struct {
+	api_v2.UnimplementedQueryServiceServer
+	api_v2.UnimplementedCollectorServiceServer
+
+	mu     sync.RWMutex
+	traces map[model.TraceID]*trace
+	// archive holds the traces ArchiveTrace copied, which GetTrace falls back to.
+	archive map[model.TraceID]*trace
+	// chunkSize is the most bytes a SpansResponseChunk takes.
+	chunkSize int
+}
+
+// trace is every span written for one trace ID, in the order they were written, each carrying its
+// process.
+type trace struct {
+	id    model.TraceID
+	spans []*model.Span
+}
+
+// NewStore returns an empty store.
+func NewStore() *Store {
+	return &Store{
+		traces:    map[model.TraceID]*trace{},
+		archive:   map[model.TraceID]*trace{},
+		chunkSize: chunk.MaxMessageSize,
+	}
+}
+
+// Register registers the store as the QueryService and CollectorService of a gRPC server, which
+// has to be made with ServerOption.
+func (s *Store) Register(server *grpc.Server) {
+	api_v2.RegisterQueryServiceServer(server, s)
+	api_v2.RegisterCollectorServiceServer(server, s)
+}
+
+// Listen serves the store on an in-memory connection, and returns a client connection to it and a
+// function that closes the connection and stops the server, which the caller calls when done.
+func (s *Store) Listen() (*grpc.ClientConn, func(), error) {
+	listener := bufconn.Listen(listenerBufferSize)
+	server := grpc.NewServer(ServerOption())
+	s.Register(server)
+	go server.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		DialOption())
+	if err != nil {
+		server.Stop()
+		return nil, nil, err
+	}
+	return conn, func() {
+		conn.Close()
+		server.Stop()
+	}, nil
+}
+
+// PostSpans stores the spans of a batch, each under the trace its trace ID names. A span without a
+// process of its own is stored with the batch's, as a collector stores it. A trace may arrive over
+// several batches, and its spans are kept in the order they arrived. The store keeps a copy, so the
+// caller may go on using what it posted.
+//
+// Every span has to carry a trace ID and a process with a service name, since it is stored and
+// searched by them; a batch holding a span that does not is refused, and stores nothing.
+func (s *Store) PostSpans(_ context.Context, req *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	spans := make([]*model.Span, 0, len(req.Batch.Spans))
+	for _, span := range req.Batch.Spans {
+		if span == nil {
+			return nil, status.Error(codes.InvalidArgument, "batch holds a nil span")
+		}
+		withProcess := *span
+		if withProcess.Process == nil {
+			withProcess.Process = req.Batch.Process
+		}
+		stored, err := clone(&withProcess)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "span %q cannot be copied: %v", span.OperationName, err)
+		}
+		switch {
+		case stored.TraceID == model.TraceID{}:
+			return nil, status.Errorf(codes.InvalidArgument, "span %q has no trace ID", span.OperationName)
+		case stored.Process == nil || stored.Process.ServiceName == "":
+			return nil, status.Errorf(codes.InvalidArgument, "span %q has no service name", span.OperationName)
+		}
+		stored.ProcessID = ""
+		spans = append(spans, stored)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, span := range spans {
+		t, ok := s.traces[span.TraceID]
+		if !ok {
+			t = &trace{id: span.TraceID}
+			s.traces[span.TraceID] = t
+		}
+		t.spans = append(t.spans, span)
+	}
+	return &api_v2.PostSpansResponse{}, nil
+}
+
+// clone returns a deep copy of a span, by way of its wire form.
+func clone(span *model.Span) (*model.Span, error) {
+	data, err := span.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	copied := &model.Span{}
+	if err := copied.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var (
+	traceDispatch = model.NewTraceID(0, 1)
+	traceConfig   = model.NewTraceID(0, 2)
+	traceLarge    = model.NewTraceID(0, 3)
+)
+
+type clients struct {
+	query     api_v2.QueryServiceClient
+	collector api_v2.CollectorServiceClient
+}
+
+// serve serves a store holding the fixture over an in-memory connection.
+func serve(t *testing.T) (*Store, clients) {
+	store := NewStore()
+	conn, stop, err := store.Listen()
+	require.NoError(t, err)
+	t.Cleanup(stop)
+	c := clients{query: api_v2.NewQueryServiceClient(conn), collector: api_v2.NewCollectorServiceClient(conn)}
+	for _, batch := range fixture() {
+		_, err := c.collector.PostSpans(context.Background(), &api_v2.PostSpansRequest{Batch: batch})
+		require.NoError(t, err)
+	}
+	return store, c
+}
+
+// fixture is three traces:
+//
+//   - dispatch, by frontend calling driver, at base;
+//   - config, by frontend alone, 10 minutes later, with a log field;
+//   - large, by batch, 20 minutes later, with 25 spans, more than a chunk holds.
+func fixture() []model.Batch {
+	frontend := model.NewProcess("frontend", []model.KeyValue{model.String("host.name", "web-1")})
+	batches := []model.Batch{
+		{
+			Process: frontend,
+			Spans: []*model.Span{
+				{
+					TraceID: traceDispatch, SpanID: 1, OperationName: "GET /dispatch", StartTime: base, Duration: 500 * time.Millisecond,
+					Tags: []model.KeyValue{model.SpanKindTag(model.SpanKindServer), model.String("http.method", "GET")},
+				},
+				{
+					TraceID: traceDispatch, SpanID: 2, OperationName: "find driver", StartTime: base.Add(time.Millisecond), Duration: 300 * time.Millisecond,
+					Tags:       []model.KeyValue{model.SpanKindTag(model.SpanKindClient)},
+					References: []model.SpanRef{model.NewChildOfRef(traceDispatch, 1)},
+				},
+				{
+					TraceID: traceConfig, SpanID: 1, OperationName: "GET /config", StartTime: base.Add(10 * time.Minute), Duration: 2 * time.Millisecond,
+					Tags: []model.KeyValue{model.SpanKindTag(model.SpanKindServer), model.Int64("http.status_code", 404)},
+					Logs: []model.Log{{Timestamp: base.Add(10 * time.Minute), Fields: []model.KeyValue{model.String("event", "cache miss")}}},
+				},
+			},
+		},
+		{
+			Spans: []*model.Span{{
+				TraceID: traceDispatch, SpanID: 3, OperationName: "FindNearest", StartTime: base.Add(2 * time.Millisecond), Duration: 200 * time.Millisecond,
+				Tags:       []model.KeyValue{model.SpanKindTag(model.SpanKindServer)},
+				References: []model.SpanRef{model.NewChildOfRef(traceDispatch, 2)},
+				Process:    model.NewProcess("driver", nil),
+			}},
+		},
+	}
+	large := model.Batch{Process: model.NewProcess("batch", nil)}
+	for i := range 25 {
+		large.Spans = append(large.Spans, &model.Span{
+			TraceID: traceLarge, SpanID: model.SpanID(i + 1), OperationName: fmt.Sprintf("step %d", i),
+			StartTime: base.Add(20*time.Minute + time.Duration(i)*time.Millisecond), Duration: time.Millisecond,
+		})
+	}
+	return append(batches, large)
+}
+
+// spans reads every chunk of a stream, and returns the spans and the chunks.
+func spans(t *testing.T, recv func() (*api_v2.SpansResponseChunk, error)) ([]model.Span, []*api_v2.SpansResponseChunk) {
+	t.Helper()
+	var all []model.Span
+	var chunks []*api_v2.SpansResponseChunk
+	for {
+		chunk, err := recv()
+		if errors.Is(err, io.EOF) {
+			return all, chunks
+		}
+		require.NoError(t, err)
+		all = append(all, chunk.Spans...)
+		chunks = append(chunks, chunk)
+	}
+}
+
+// traceIDs returns the trace of each span, once each, in the order they arrive.
+func traceIDs(spans []model.Span) []model.TraceID {
+	var ids []model.TraceID
+	for _, span := range spans {
+		if len(ids) == 0 || ids[len(ids)-1] != span.TraceID {
+			ids = append(ids, span.TraceID)
+		}
+	}
+	return ids
+}
+
+func TestGetTrace(t *testing.T) {
+	store, c := serve(t)
+	ctx := context.Background()
+
+	stream, err := c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceDispatch})
+	require.NoError(t, err)
+	got, chunks := spans(t, stream.Recv)
+	require.Len(t, got, 3)
+	assert.Len(t, chunks, 1)
+	assert.Equal(t, "FindNearest", got[2].OperationName)
+	assert.Equal(t, "driver", got[2].Process.ServiceName, "a span keeps its own process")
+	assert.Equal(t, "frontend", got[0].Process.ServiceName, "a span takes the batch's process")
+
+	store.chunkSize = 500
+	stream, err = c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceLarge})
+	require.NoError(t, err)
+	got, chunks = spans(t, stream.Recv)
+	assert.Len(t, got, 25)
+	assert.Greater(t, len(chunks), 1)
+	for _, message := range chunks {
+		assert.LessOrEqual(t, message.Size(), store.chunkSize)
+	}
+
+	store.chunkSize = 10
+	stream, err = c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceLarge})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "a span larger than a chunk")
+
+	stream, err = c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceDispatch, StartTime: base.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err), "outside the time range")
+
+	stream, err = c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: model.NewTraceID(0, 99)})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestArchiveTrace(t *testing.T) {
+	store, c := serve(t)
+	ctx := context.Background()
+
+	_, err := c.query.ArchiveTrace(ctx, &api_v2.ArchiveTraceRequest{TraceID: model.NewTraceID(0, 99)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = c.query.ArchiveTrace(ctx, &api_v2.ArchiveTraceRequest{TraceID: traceConfig})
+	require.NoError(t, err)
+	store.mu.Lock()
+	delete(store.traces, traceConfig)
+	store.mu.Unlock()
+
+	stream, err := c.query.GetTrace(ctx, &api_v2.GetTraceRequest{TraceID: traceConfig})
+	require.NoError(t, err)
+	got, _ := spans(t, stream.Recv)
+	require.Len(t, got, 1)
+	assert.Equal(t, "GET /config", got[0].OperationName, "found in the archive")
+}
+
+func TestFindTraces(t *testing.T) {
+	_, c := serve(t)
+	tests := []struct {
+		name     string
+		query    *api_v2.TraceQueryParameters
+		expected []model.TraceID
+	}{
+		{
+			name:     "everything, the latest first",
+			query:    &api_v2.TraceQueryParameters{},
+			expected: []model.TraceID{traceLarge, traceConfig, traceDispatch},
+		},
+		{
+			name:     "service",
+			query:    &api_v2.TraceQueryParameters{ServiceName: "driver"},
+			expected: []model.TraceID{traceDispatch},
+		},
+		{
+			name:     "service and operation in one span",
+			query:    &api_v2.TraceQueryParameters{ServiceName: "driver", OperationName: "GET /dispatch"},
+			expected: nil,
+		},
+		{
+			name:     "span tag",
+			query:    &api_v2.TraceQueryParameters{Tags: map[string]string{"http.status_code": "404"}},
+			expected: []model.TraceID{traceConfig},
+		},
+		{
+			name:     "process tag and log field",
+			query:    &api_v2.TraceQueryParameters{Tags: map[string]string{"host.name": "web-1", "event": "cache miss"}},
+			expected: []model.TraceID{traceConfig},
+		},
+		{
+			name:     "duration",
+			query:    &api_v2.TraceQueryParameters{DurationMin: 250 * time.Millisecond, DurationMax: 400 * time.Millisecond},
+			expected: []model.TraceID{traceDispatch},
+		},
+		{
+			name:     "start time",
+			query:    &api_v2.TraceQueryParameters{StartTimeMin: base.Add(5 * time.Minute), StartTimeMax: base.Add(10 * time.Minute)},
+			expected: []model.TraceID{traceConfig},
+		},
+		{
+			name:     "search depth",
+			query:    &api_v2.TraceQueryParameters{SearchDepth: 2},
+			expected: []model.TraceID{traceLarge, traceConfig},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := c.query.FindTraces(context.Background(), &api_v2.FindTracesRequest{Query: test.query})
+			require.NoError(t, err)
+			got, _ := spans(t, stream.Recv)
+			assert.Equal(t, test.expected, traceIDs(got))
+		})
+	}
+}
+
+func TestFindTraces_InvalidQuery(t *testing.T) {
+	_, c := serve(t)
+	for _, query := range []*api_v2.TraceQueryParameters{
+		nil,
+		{DurationMin: time.Second, DurationMax: time.Millisecond},
+		{StartTimeMin: base.Add(time.Hour), StartTimeMax: base},
+	} {
+		stream, err := c.query.FindTraces(context.Background(), &api_v2.FindTracesRequest{Query: query})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "query %v", query)
+	}
+}
+
+func TestGetServicesAndOperations(t *testing.T) {
+	_, c := serve(t)
+	ctx := context.Background()
+
+	services, err := c.query.GetServices(ctx, &api_v2.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"batch", "driver", "frontend"}, services.Services)
+
+	operations, err := c.query.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: "frontend"})
+	require.NoError(t, err)
+	assert.Equal(t, []*api_v2.Operation{
+		{Name: "GET /config", SpanKind: "server"},
+		{Name: "GET /dispatch", SpanKind: "server"},
+		{Name: "find driver", SpanKind: "client"},
+	}, operations.Operations)
+	assert.Equal(t, []string{"GET /config", "GET /dispatch", "find driver"}, operations.OperationNames)
+
+	operations, err = c.query.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: "frontend", SpanKind: "client"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"find driver"}, operations.OperationNames)
+
+	operations, err = c.query.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: "batch"})
+	require.NoError(t, err)
+	assert.Equal(t, "", operations.Operations[0].SpanKind, "a span without span.kind")
+
+	_, err = c.query.GetOperations(ctx, &api_v2.GetOperationsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.query.GetOperations(ctx, &api_v2.GetOperationsRequest{Service: "frontend", SpanKind: "sideways"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetDependencies(t *testing.T) {
+	_, c := serve(t)
+	ctx := context.Background()
+
+	response, err := c.query.GetDependencies(ctx, &api_v2.GetDependenciesRequest{StartTime: base, EndTime: base.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, []model.DependencyLink{{Parent: "frontend", Child: "driver", CallCount: 1, Source: "jaeger"}}, response.Dependencies)
+
+	response, err = c.query.GetDependencies(ctx, &api_v2.GetDependenciesRequest{StartTime: base.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Empty(t, response.Dependencies, "the dispatch trace started before the range")
+}
+
+func TestPostSpans(t *testing.T) {
+	_, c := serve(t)
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		batch model.Batch
+		err   string
+	}{
+		{name: "no trace ID", batch: model.Batch{Process: model.NewProcess("a", nil), Spans: []*model.Span{{OperationName: "x"}}}, err: `span "x" has no trace ID`},
+		{name: "no service", batch: model.Batch{Spans: []*model.Span{{TraceID: model.NewTraceID(0, 9), OperationName: "x"}}}, err: `span "x" has no service name`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.collector.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: test.batch})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+
+	store := NewStore()
+	_, err := store.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: model.Batch{Spans: []*model.Span{nil}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a nil span, which only a caller in-process can post")
+
+	posted := &model.Span{TraceID: model.NewTraceID(0, 9), SpanID: 1, OperationName: "kept", Process: model.NewProcess("a", nil), StartTime: base}
+	_, err = store.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: model.Batch{Spans: []*model.Span{posted}}})
+	require.NoError(t, err)
+	posted.OperationName = "changed"
+	assert.Equal(t, "kept", store.traces[posted.TraceID].spans[0].OperationName, "the store keeps a copy")
+}
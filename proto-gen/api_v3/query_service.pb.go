@@ -0,0 +1,1379 @@
+// Copyright (c) 2021 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api_v3/query_service.proto
+
+package api_v3
+
+import (
+	_ "github.com/google/gnostic-models/openapiv3"
+	v1 "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	v11 "go.opentelemetry.io/proto/otlp/trace/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request object to get a trace.
+type GetTraceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex encoded 64 or 128 bit trace ID.
+	TraceId string `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Optional. The start time to search trace ID.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Optional. The end time to search trace ID.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Optional. If set to true, the response will not include any
+	// enrichments to the trace, such as clock skew adjustment.
+	// Instead, the trace will be returned exactly as stored.
+	RawTraces     bool `protobuf:"varint,4,opt,name=raw_traces,json=rawTraces,proto3" json:"raw_traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTraceRequest) Reset() {
+	*x = GetTraceRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTraceRequest) ProtoMessage() {}
+
+func (x *GetTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetTraceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTraceRequest) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *GetTraceRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetTraceRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetTraceRequest) GetRawTraces() bool {
+	if x != nil {
+		return x.RawTraces
+	}
+	return false
+}
+
+// Query parameters to find traces.
+//
+// All fields form a conjunction (e.g., "service_name='X' AND operation_name='Y' AND ..."),
+// except for `search_depth` and `raw_traces`.
+//
+// Fields are matched against individual spans, not the trace level. The results include
+// traces with at least one matching span.
+//
+// The results have no guaranteed ordering.
+type TraceQueryParameters struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service_name filters spans generated by a specific service.
+	ServiceName string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// operation_name filters spans by a specific operation / span name.
+	OperationName string `protobuf:"bytes,2,opt,name=operation_name,json=operationName,proto3" json:"operation_name,omitempty"`
+	// attributes contains key-value pairs where the key is the attribute name
+	// and the value is its string representation. Attributes are matched against
+	// span and resource attributes. At least one span must match all specified attributes.
+	//
+	// The HTTP API expects this as a URL-encoded JSON string map.
+	// Example: {"http.status_code":"200","error":"true"}
+	Attributes map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// start_time_min is the start of the time interval (inclusive) for the query.
+	// Only traces with spans that started on or after this time will be returned.
+	//
+	// The HTTP API uses RFC-3339ns format.
+	//
+	// This field is required.
+	StartTimeMin *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time_min,json=startTimeMin,proto3" json:"start_time_min,omitempty"`
+	// start_time_max is the end of the time interval (exclusive) for the query.
+	// Only traces with spans that started before this time will be returned.
+	//
+	// The HTTP API uses RFC-3339ns format.
+	//
+	// This field is required.
+	StartTimeMax *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time_max,json=startTimeMax,proto3" json:"start_time_max,omitempty"`
+	// duration_min is the minimum duration of a span in the trace.
+	// Only traces with spans that lasted at least this long will be returned.
+	//
+	// The HTTP API uses Golang's time format (e.g., "10s").
+	DurationMin *durationpb.Duration `protobuf:"bytes,6,opt,name=duration_min,json=durationMin,proto3" json:"duration_min,omitempty"`
+	// duration_max is the maximum duration of a span in the trace.
+	// Only traces with spans that lasted at most this long will be returned.
+	//
+	// The HTTP API uses Golang's time format (e.g., "10s").
+	DurationMax *durationpb.Duration `protobuf:"bytes,7,opt,name=duration_max,json=durationMax,proto3" json:"duration_max,omitempty"`
+	// search_depth defines the maximum search depth. Depending on the backend storage implementation,
+	// this may behave like an SQL `LIMIT` clause. However, some implementations might not support
+	// precise limits, and a larger value generally results in more traces being returned.
+	SearchDepth int32 `protobuf:"varint,8,opt,name=search_depth,json=searchDepth,proto3" json:"search_depth,omitempty"`
+	// If set to true, the response will exclude any enrichments to the trace, such as clock skew adjustments.
+	// The trace will be returned exactly as stored.
+	//
+	// This field is optional.
+	RawTraces bool `protobuf:"varint,9,opt,name=raw_traces,json=rawTraces,proto3" json:"raw_traces,omitempty"`
+	// filter is the structured query filter: a single boolean-valued Call,
+	// mutually exclusive with the legacy predicate fields (service_name,
+	// operation_name, duration_min/max, attributes).
+	//
+	// Experimental. In Jaeger, the query service admits this field only when the
+	// `jaeger.query.structuredFilters` feature gate is enabled (Alpha, off by default);
+	// with the gate off a request carrying it is refused. The field is never ignored,
+	// because dropping a predicate would answer with more traces than were asked for.
+	//
+	// Over the HTTP GET binding it is a URL-encoded JSON object; in a request body
+	// it is the structured Call. For example, the filter http.status_code == 500 is
+	// {"op":"eq","args":[{"attr":{"key":"http.status_code"}},{"scalar":{"value":"500"}}]}
+	Filter        *v1.Call `protobuf:"bytes,10,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceQueryParameters) Reset() {
+	*x = TraceQueryParameters{}
+	mi := &file_api_v3_query_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceQueryParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceQueryParameters) ProtoMessage() {}
+
+func (x *TraceQueryParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceQueryParameters.ProtoReflect.Descriptor instead.
+func (*TraceQueryParameters) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TraceQueryParameters) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceQueryParameters) GetOperationName() string {
+	if x != nil {
+		return x.OperationName
+	}
+	return ""
+}
+
+func (x *TraceQueryParameters) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *TraceQueryParameters) GetStartTimeMin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTimeMin
+	}
+	return nil
+}
+
+func (x *TraceQueryParameters) GetStartTimeMax() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTimeMax
+	}
+	return nil
+}
+
+func (x *TraceQueryParameters) GetDurationMin() *durationpb.Duration {
+	if x != nil {
+		return x.DurationMin
+	}
+	return nil
+}
+
+func (x *TraceQueryParameters) GetDurationMax() *durationpb.Duration {
+	if x != nil {
+		return x.DurationMax
+	}
+	return nil
+}
+
+func (x *TraceQueryParameters) GetSearchDepth() int32 {
+	if x != nil {
+		return x.SearchDepth
+	}
+	return 0
+}
+
+func (x *TraceQueryParameters) GetRawTraces() bool {
+	if x != nil {
+		return x.RawTraces
+	}
+	return false
+}
+
+func (x *TraceQueryParameters) GetFilter() *v1.Call {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Request object to search traces.
+type FindTracesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *TraceQueryParameters  `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindTracesRequest) Reset() {
+	*x = FindTracesRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindTracesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindTracesRequest) ProtoMessage() {}
+
+func (x *FindTracesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindTracesRequest.ProtoReflect.Descriptor instead.
+func (*FindTracesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindTracesRequest) GetQuery() *TraceQueryParameters {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// Request object to get service names.
+type GetServicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServicesRequest) Reset() {
+	*x = GetServicesRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServicesRequest) ProtoMessage() {}
+
+func (x *GetServicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServicesRequest.ProtoReflect.Descriptor instead.
+func (*GetServicesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{3}
+}
+
+// Response object to get service names.
+type GetServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []string               `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServicesResponse) Reset() {
+	*x = GetServicesResponse{}
+	mi := &file_api_v3_query_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServicesResponse) ProtoMessage() {}
+
+func (x *GetServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServicesResponse.ProtoReflect.Descriptor instead.
+func (*GetServicesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetServicesResponse) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// Request object to get operation names.
+type GetOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required service name.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Optional span kind.
+	SpanKind      string `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsRequest) Reset() {
+	*x = GetOperationsRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsRequest) ProtoMessage() {}
+
+func (x *GetOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOperationsRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GetOperationsRequest) GetSpanKind() string {
+	if x != nil {
+		return x.SpanKind
+	}
+	return ""
+}
+
+// Operation encapsulates information about operation.
+type Operation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SpanKind      string                 `protobuf:"bytes,2,opt,name=span_kind,json=spanKind,proto3" json:"span_kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_api_v3_query_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Operation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Operation) GetSpanKind() string {
+	if x != nil {
+		return x.SpanKind
+	}
+	return ""
+}
+
+// Response object to get operation names.
+type GetOperationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOperationsResponse) Reset() {
+	*x = GetOperationsResponse{}
+	mi := &file_api_v3_query_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOperationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationsResponse) ProtoMessage() {}
+
+func (x *GetOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationsResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOperationsResponse) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type GetDependenciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The start time for the time range to search dependencies.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Required. The end time for the time range to search dependencies.
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesRequest) Reset() {
+	*x = GetDependenciesRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesRequest) ProtoMessage() {}
+
+func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDependenciesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetDependenciesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type DependenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependencies  []*Dependency          `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependenciesResponse) Reset() {
+	*x = DependenciesResponse{}
+	mi := &file_api_v3_query_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependenciesResponse) ProtoMessage() {}
+
+func (x *DependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependenciesResponse.ProtoReflect.Descriptor instead.
+func (*DependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DependenciesResponse) GetDependencies() []*Dependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+type Dependency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parent        string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Child         string                 `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	CallCount     uint64                 `protobuf:"varint,3,opt,name=call_count,json=callCount,proto3" json:"call_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dependency) Reset() {
+	*x = Dependency{}
+	mi := &file_api_v3_query_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dependency) ProtoMessage() {}
+
+func (x *Dependency) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
+func (*Dependency) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Dependency) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *Dependency) GetChild() string {
+	if x != nil {
+		return x.Child
+	}
+	return ""
+}
+
+func (x *Dependency) GetCallCount() uint64 {
+	if x != nil {
+		return x.CallCount
+	}
+	return 0
+}
+
+// ServiceSummary contains per-service statistics for a trace, matching
+// what the UI renders as a coloured tag in the search results row.
+type ServiceSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the service.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of spans attributed to this service in the trace.
+	SpanCount int32 `protobuf:"varint,2,opt,name=span_count,json=spanCount,proto3" json:"span_count,omitempty"`
+	// Number of spans from this service that carry OTEL StatusCode = ERROR.
+	// The UI renders an error icon when this value is > 0.
+	// Only spans explicitly owned by this service are counted; there is no
+	// error propagation from child spans of other services.
+	ErrorSpanCount int32 `protobuf:"varint,3,opt,name=error_span_count,json=errorSpanCount,proto3" json:"error_span_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ServiceSummary) Reset() {
+	*x = ServiceSummary{}
+	mi := &file_api_v3_query_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSummary) ProtoMessage() {}
+
+func (x *ServiceSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSummary.ProtoReflect.Descriptor instead.
+func (*ServiceSummary) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ServiceSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetSpanCount() int32 {
+	if x != nil {
+		return x.SpanCount
+	}
+	return 0
+}
+
+func (x *ServiceSummary) GetErrorSpanCount() int32 {
+	if x != nil {
+		return x.ErrorSpanCount
+	}
+	return 0
+}
+
+// TraceSummary contains lightweight summary information about a trace,
+// suitable for display in search result lists.
+type TraceSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hex-encoded 128-bit trace ID.
+	TraceId string `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Name of the service that owns the root span.
+	RootServiceName string `protobuf:"bytes,2,opt,name=root_service_name,json=rootServiceName,proto3" json:"root_service_name,omitempty"`
+	// Operation name of the root span.
+	RootOperationName string `protobuf:"bytes,3,opt,name=root_operation_name,json=rootOperationName,proto3" json:"root_operation_name,omitempty"`
+	// Start timestamp of the earliest span in the trace (Unix nanoseconds).
+	// Named to match the OTLP convention (e.g. startTimeUnixNano in OTLP span JSON).
+	// proto3 JSON encoding: fixed64/uint64/int64 fields are serialised as decimal
+	// strings to avoid float64 precision loss in JavaScript for values above 2^53.
+	MinStartTimeUnixNano uint64 `protobuf:"fixed64,4,opt,name=min_start_time_unix_nano,json=minStartTimeUnixNano,proto3" json:"min_start_time_unix_nano,omitempty"`
+	// End timestamp of the latest span in the trace (Unix nanoseconds).
+	// The UI may compute duration as BigInt(maxEndTimeUnixNano) - BigInt(minStartTimeUnixNano).
+	MaxEndTimeUnixNano uint64 `protobuf:"fixed64,5,opt,name=max_end_time_unix_nano,json=maxEndTimeUnixNano,proto3" json:"max_end_time_unix_nano,omitempty"`
+	// Total number of spans in the trace.
+	SpanCount int32 `protobuf:"varint,6,opt,name=span_count,json=spanCount,proto3" json:"span_count,omitempty"`
+	// Number of spans that carry an error indicator (OTEL StatusCode = ERROR).
+	ErrorSpanCount int32 `protobuf:"varint,7,opt,name=error_span_count,json=errorSpanCount,proto3" json:"error_span_count,omitempty"`
+	// Number of spans whose parent span ID is not present in this trace.
+	// A non-zero value indicates an incomplete or partial trace.
+	OrphanSpanCount int32 `protobuf:"varint,8,opt,name=orphan_span_count,json=orphanSpanCount,proto3" json:"orphan_span_count,omitempty"`
+	// Per-service breakdown, one entry per distinct service name observed
+	// across all spans, sorted by name.
+	Services      []*ServiceSummary `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceSummary) Reset() {
+	*x = TraceSummary{}
+	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSummary) ProtoMessage() {}
+
+func (x *TraceSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSummary.ProtoReflect.Descriptor instead.
+func (*TraceSummary) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TraceSummary) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *TraceSummary) GetRootServiceName() string {
+	if x != nil {
+		return x.RootServiceName
+	}
+	return ""
+}
+
+func (x *TraceSummary) GetRootOperationName() string {
+	if x != nil {
+		return x.RootOperationName
+	}
+	return ""
+}
+
+func (x *TraceSummary) GetMinStartTimeUnixNano() uint64 {
+	if x != nil {
+		return x.MinStartTimeUnixNano
+	}
+	return 0
+}
+
+func (x *TraceSummary) GetMaxEndTimeUnixNano() uint64 {
+	if x != nil {
+		return x.MaxEndTimeUnixNano
+	}
+	return 0
+}
+
+func (x *TraceSummary) GetSpanCount() int32 {
+	if x != nil {
+		return x.SpanCount
+	}
+	return 0
+}
+
+func (x *TraceSummary) GetErrorSpanCount() int32 {
+	if x != nil {
+		return x.ErrorSpanCount
+	}
+	return 0
+}
+
+func (x *TraceSummary) GetOrphanSpanCount() int32 {
+	if x != nil {
+		return x.OrphanSpanCount
+	}
+	return 0
+}
+
+func (x *TraceSummary) GetServices() []*ServiceSummary {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+// Request object for FindTraceSummaries.
+type FindTraceSummariesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *TraceQueryParameters  `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindTraceSummariesRequest) Reset() {
+	*x = FindTraceSummariesRequest{}
+	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindTraceSummariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindTraceSummariesRequest) ProtoMessage() {}
+
+func (x *FindTraceSummariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindTraceSummariesRequest.ProtoReflect.Descriptor instead.
+func (*FindTraceSummariesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FindTraceSummariesRequest) GetQuery() *TraceQueryParameters {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+// Response chunk for FindTraceSummaries. A single RPC call may yield multiple
+// chunks, each carrying one or more summaries, mirroring the chunked streaming
+// used by FindTraces / GetTrace.
+type FindTraceSummariesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summaries     []*TraceSummary        `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindTraceSummariesResponse) Reset() {
+	*x = FindTraceSummariesResponse{}
+	mi := &file_api_v3_query_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindTraceSummariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindTraceSummariesResponse) ProtoMessage() {}
+
+func (x *FindTraceSummariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindTraceSummariesResponse.ProtoReflect.Descriptor instead.
+func (*FindTraceSummariesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FindTraceSummariesResponse) GetSummaries() []*TraceSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+// GRPCGatewayError is the type returned when GRPC server returns an error.
+// Example: {"error":{"grpcCode":2,"httpCode":500,"message":"...","httpStatus":"text..."}}.
+type GRPCGatewayError struct {
+	state         protoimpl.MessageState                    `protogen:"open.v1"`
+	Error         *GRPCGatewayError_GRPCGatewayErrorDetails `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCGatewayError) Reset() {
+	*x = GRPCGatewayError{}
+	mi := &file_api_v3_query_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCGatewayError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCGatewayError) ProtoMessage() {}
+
+func (x *GRPCGatewayError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCGatewayError.ProtoReflect.Descriptor instead.
+func (*GRPCGatewayError) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GRPCGatewayError) GetError() *GRPCGatewayError_GRPCGatewayErrorDetails {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GRPCGatewayWrapper wraps streaming responses from GetTrace/FindTraces for HTTP.
+// Today there is always only one response because internally the HTTP server gets
+// data from QueryService that does not support multiple responses. But in the
+// future the server may return multiple responeses using Transfer-Encoding: chunked.
+// In case of errors, GRPCGatewayError above is used.
+//
+// Example:
+//
+//	{"result": {"resourceSpans": ...}}
+//
+// See https://github.com/grpc-ecosystem/grpc-gateway/issues/2189
+type GRPCGatewayWrapper struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *v11.TracesData        `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCGatewayWrapper) Reset() {
+	*x = GRPCGatewayWrapper{}
+	mi := &file_api_v3_query_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCGatewayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCGatewayWrapper) ProtoMessage() {}
+
+func (x *GRPCGatewayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCGatewayWrapper.ProtoReflect.Descriptor instead.
+func (*GRPCGatewayWrapper) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GRPCGatewayWrapper) GetResult() *v11.TracesData {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GRPCGatewayError_GRPCGatewayErrorDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GrpcCode      int32                  `protobuf:"varint,1,opt,name=grpcCode,proto3" json:"grpcCode,omitempty"`
+	HttpCode      int32                  `protobuf:"varint,2,opt,name=httpCode,proto3" json:"httpCode,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	HttpStatus    string                 `protobuf:"bytes,4,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) Reset() {
+	*x = GRPCGatewayError_GRPCGatewayErrorDetails{}
+	mi := &file_api_v3_query_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCGatewayError_GRPCGatewayErrorDetails) ProtoMessage() {}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_query_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCGatewayError_GRPCGatewayErrorDetails.ProtoReflect.Descriptor instead.
+func (*GRPCGatewayError_GRPCGatewayErrorDetails) Descriptor() ([]byte, []int) {
+	return file_api_v3_query_service_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) GetGrpcCode() int32 {
+	if x != nil {
+		return x.GrpcCode
+	}
+	return 0
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) GetHttpCode() int32 {
+	if x != nil {
+		return x.HttpCode
+	}
+	return 0
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GRPCGatewayError_GRPCGatewayErrorDetails) GetHttpStatus() string {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return ""
+}
+
+var File_api_v3_query_service_proto protoreflect.FileDescriptor
+
+const file_api_v3_query_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi_v3/query_service.proto\x12\rjaeger.api_v3\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a(opentelemetry/proto/trace/v1/trace.proto\x1a#gnostic/openapiv3/annotations.proto\x1a\x1eexpression/v1/expression.proto\"\xbd\x01\n" +
+	"\x0fGetTraceRequest\x12\x19\n" +
+	"\btrace_id\x18\x01 \x01(\tR\atraceId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1d\n" +
+	"\n" +
+	"raw_traces\x18\x04 \x01(\bR\trawTraces\"\x99\x05\n" +
+	"\x14TraceQueryParameters\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12%\n" +
+	"\x0eoperation_name\x18\x02 \x01(\tR\roperationName\x12\x81\x01\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v23.jaeger.api_v3.TraceQueryParameters.AttributesEntryB,\xbaG):\x1e\x12\x1c'{\"http.status_code\":\"200\"}'\xca\x01\x06stringR\n" +
+	"attributes\x12@\n" +
+	"\x0estart_time_min\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fstartTimeMin\x12@\n" +
+	"\x0estart_time_max\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fstartTimeMax\x12<\n" +
+	"\fduration_min\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\vdurationMin\x12<\n" +
+	"\fduration_max\x18\a \x01(\v2\x19.google.protobuf.DurationR\vdurationMax\x12!\n" +
+	"\fsearch_depth\x18\b \x01(\x05R\vsearchDepth\x12\x1d\n" +
+	"\n" +
+	"raw_traces\x18\t \x01(\bR\trawTraces\x122\n" +
+	"\x06filter\x18\n" +
+	" \x01(\v2\x1a.jaeger.expression.v1.CallR\x06filter\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"N\n" +
+	"\x11FindTracesRequest\x129\n" +
+	"\x05query\x18\x01 \x01(\v2#.jaeger.api_v3.TraceQueryParametersR\x05query\"\x14\n" +
+	"\x12GetServicesRequest\"6\n" +
+	"\x13GetServicesResponse\x12\x1f\n" +
+	"\bservices\x18\x01 \x03(\tB\x03\xe0A\x02R\bservices\"W\n" +
+	"\x14GetOperationsRequest\x12\x1d\n" +
+	"\aservice\x18\x01 \x01(\tB\x03\xe0A\x02R\aservice\x12 \n" +
+	"\tspan_kind\x18\x02 \x01(\tB\x03\xe0A\x01R\bspanKind\"F\n" +
+	"\tOperation\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\x02R\x04name\x12 \n" +
+	"\tspan_kind\x18\x02 \x01(\tB\x03\xe0A\x02R\bspanKind\"V\n" +
+	"\x15GetOperationsResponse\x12=\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x18.jaeger.api_v3.OperationB\x03\xe0A\x02R\n" +
+	"operations\"\x8a\x01\n" +
+	"\x16GetDependenciesRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"Z\n" +
+	"\x14DependenciesResponse\x12B\n" +
+	"\fdependencies\x18\x01 \x03(\v2\x19.jaeger.api_v3.DependencyB\x03\xe0A\x02R\fdependencies\"h\n" +
+	"\n" +
+	"Dependency\x12\x1b\n" +
+	"\x06parent\x18\x01 \x01(\tB\x03\xe0A\x02R\x06parent\x12\x19\n" +
+	"\x05child\x18\x02 \x01(\tB\x03\xe0A\x02R\x05child\x12\"\n" +
+	"\n" +
+	"call_count\x18\x03 \x01(\x04B\x03\xe0A\x02R\tcallCount\"r\n" +
+	"\x0eServiceSummary\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\x02R\x04name\x12\x1d\n" +
+	"\n" +
+	"span_count\x18\x02 \x01(\x05R\tspanCount\x12(\n" +
+	"\x10error_span_count\x18\x03 \x01(\x05R\x0eerrorSpanCount\"\xa6\x03\n" +
+	"\fTraceSummary\x12\x1e\n" +
+	"\btrace_id\x18\x01 \x01(\tB\x03\xe0A\x02R\atraceId\x12*\n" +
+	"\x11root_service_name\x18\x02 \x01(\tR\x0frootServiceName\x12.\n" +
+	"\x13root_operation_name\x18\x03 \x01(\tR\x11rootOperationName\x126\n" +
+	"\x18min_start_time_unix_nano\x18\x04 \x01(\x06R\x14minStartTimeUnixNano\x122\n" +
+	"\x16max_end_time_unix_nano\x18\x05 \x01(\x06R\x12maxEndTimeUnixNano\x12\x1d\n" +
+	"\n" +
+	"span_count\x18\x06 \x01(\x05R\tspanCount\x12(\n" +
+	"\x10error_span_count\x18\a \x01(\x05R\x0eerrorSpanCount\x12*\n" +
+	"\x11orphan_span_count\x18\b \x01(\x05R\x0forphanSpanCount\x129\n" +
+	"\bservices\x18\t \x03(\v2\x1d.jaeger.api_v3.ServiceSummaryR\bservices\"V\n" +
+	"\x19FindTraceSummariesRequest\x129\n" +
+	"\x05query\x18\x01 \x01(\v2#.jaeger.api_v3.TraceQueryParametersR\x05query\"W\n" +
+	"\x1aFindTraceSummariesResponse\x129\n" +
+	"\tsummaries\x18\x01 \x03(\v2\x1b.jaeger.api_v3.TraceSummaryR\tsummaries\"\xef\x01\n" +
+	"\x10GRPCGatewayError\x12M\n" +
+	"\x05error\x18\x01 \x01(\v27.jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetailsR\x05error\x1a\x8b\x01\n" +
+	"\x17GRPCGatewayErrorDetails\x12\x1a\n" +
+	"\bgrpcCode\x18\x01 \x01(\x05R\bgrpcCode\x12\x1a\n" +
+	"\bhttpCode\x18\x02 \x01(\x05R\bhttpCode\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1e\n" +
+	"\n" +
+	"httpStatus\x18\x04 \x01(\tR\n" +
+	"httpStatus\"V\n" +
+	"\x12GRPCGatewayWrapper\x12@\n" +
+	"\x06result\x18\x01 \x01(\v2(.opentelemetry.proto.trace.v1.TracesDataR\x06result2\xa5\x06\n" +
+	"\fQueryService\x12y\n" +
+	"\bGetTrace\x12\x1e.jaeger.api_v3.GetTraceRequest\x1a(.opentelemetry.proto.trace.v1.TracesData\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v3/traces/{trace_id}0\x01\x12\x87\x01\n" +
+	"\n" +
+	"FindTraces\x12 .jaeger.api_v3.FindTracesRequest\x1a(.opentelemetry.proto.trace.v1.TracesData\"+\x82\xd3\xe4\x93\x02%Z\x13:\x01*\"\x0e/api/v3/traces\x12\x0e/api/v3/traces0\x01\x12n\n" +
+	"\vGetServices\x12!.jaeger.api_v3.GetServicesRequest\x1a\".jaeger.api_v3.GetServicesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v3/services\x12v\n" +
+	"\rGetOperations\x12#.jaeger.api_v3.GetOperationsRequest\x1a$.jaeger.api_v3.GetOperationsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v3/operations\x12{\n" +
+	"\x0fGetDependencies\x12%.jaeger.api_v3.GetDependenciesRequest\x1a#.jaeger.api_v3.DependenciesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v3/dependencies\x12\xaa\x01\n" +
+	"\x12FindTraceSummaries\x12(.jaeger.api_v3.FindTraceSummariesRequest\x1a).jaeger.api_v3.FindTraceSummariesResponse\"=\x82\xd3\xe4\x93\x027Z\x1c:\x01*\"\x17/api/v3/trace-summaries\x12\x17/api/v3/trace-summaries0\x01B!\n" +
+	"\x17io.jaegertracing.api_v3Z\x06api_v3b\x06proto3"
+
+var (
+	file_api_v3_query_service_proto_rawDescOnce sync.Once
+	file_api_v3_query_service_proto_rawDescData []byte
+)
+
+func file_api_v3_query_service_proto_rawDescGZIP() []byte {
+	file_api_v3_query_service_proto_rawDescOnce.Do(func() {
+		file_api_v3_query_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v3_query_service_proto_rawDesc), len(file_api_v3_query_service_proto_rawDesc)))
+	})
+	return file_api_v3_query_service_proto_rawDescData
+}
+
+var file_api_v3_query_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_api_v3_query_service_proto_goTypes = []any{
+	(*GetTraceRequest)(nil),                          // 0: jaeger.api_v3.GetTraceRequest
+	(*TraceQueryParameters)(nil),                     // 1: jaeger.api_v3.TraceQueryParameters
+	(*FindTracesRequest)(nil),                        // 2: jaeger.api_v3.FindTracesRequest
+	(*GetServicesRequest)(nil),                       // 3: jaeger.api_v3.GetServicesRequest
+	(*GetServicesResponse)(nil),                      // 4: jaeger.api_v3.GetServicesResponse
+	(*GetOperationsRequest)(nil),                     // 5: jaeger.api_v3.GetOperationsRequest
+	(*Operation)(nil),                                // 6: jaeger.api_v3.Operation
+	(*GetOperationsResponse)(nil),                    // 7: jaeger.api_v3.GetOperationsResponse
+	(*GetDependenciesRequest)(nil),                   // 8: jaeger.api_v3.GetDependenciesRequest
+	(*DependenciesResponse)(nil),                     // 9: jaeger.api_v3.DependenciesResponse
+	(*Dependency)(nil),                               // 10: jaeger.api_v3.Dependency
+	(*ServiceSummary)(nil),                           // 11: jaeger.api_v3.ServiceSummary
+	(*TraceSummary)(nil),                             // 12: jaeger.api_v3.TraceSummary
+	(*FindTraceSummariesRequest)(nil),                // 13: jaeger.api_v3.FindTraceSummariesRequest
+	(*FindTraceSummariesResponse)(nil),               // 14: jaeger.api_v3.FindTraceSummariesResponse
+	(*GRPCGatewayError)(nil),                         // 15: jaeger.api_v3.GRPCGatewayError
+	(*GRPCGatewayWrapper)(nil),                       // 16: jaeger.api_v3.GRPCGatewayWrapper
+	nil,                                              // 17: jaeger.api_v3.TraceQueryParameters.AttributesEntry
+	(*GRPCGatewayError_GRPCGatewayErrorDetails)(nil), // 18: jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
+	(*timestamppb.Timestamp)(nil),                    // 19: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                      // 20: google.protobuf.Duration
+	(*v1.Call)(nil),                                  // 21: jaeger.expression.v1.Call
+	(*v11.TracesData)(nil),                           // 22: opentelemetry.proto.trace.v1.TracesData
+}
+var file_api_v3_query_service_proto_depIdxs = []int32{
+	19, // 0: jaeger.api_v3.GetTraceRequest.start_time:type_name -> google.protobuf.Timestamp
+	19, // 1: jaeger.api_v3.GetTraceRequest.end_time:type_name -> google.protobuf.Timestamp
+	17, // 2: jaeger.api_v3.TraceQueryParameters.attributes:type_name -> jaeger.api_v3.TraceQueryParameters.AttributesEntry
+	19, // 3: jaeger.api_v3.TraceQueryParameters.start_time_min:type_name -> google.protobuf.Timestamp
+	19, // 4: jaeger.api_v3.TraceQueryParameters.start_time_max:type_name -> google.protobuf.Timestamp
+	20, // 5: jaeger.api_v3.TraceQueryParameters.duration_min:type_name -> google.protobuf.Duration
+	20, // 6: jaeger.api_v3.TraceQueryParameters.duration_max:type_name -> google.protobuf.Duration
+	21, // 7: jaeger.api_v3.TraceQueryParameters.filter:type_name -> jaeger.expression.v1.Call
+	1,  // 8: jaeger.api_v3.FindTracesRequest.query:type_name -> jaeger.api_v3.TraceQueryParameters
+	6,  // 9: jaeger.api_v3.GetOperationsResponse.operations:type_name -> jaeger.api_v3.Operation
+	19, // 10: jaeger.api_v3.GetDependenciesRequest.start_time:type_name -> google.protobuf.Timestamp
+	19, // 11: jaeger.api_v3.GetDependenciesRequest.end_time:type_name -> google.protobuf.Timestamp
+	10, // 12: jaeger.api_v3.DependenciesResponse.dependencies:type_name -> jaeger.api_v3.Dependency
+	11, // 13: jaeger.api_v3.TraceSummary.services:type_name -> jaeger.api_v3.ServiceSummary
+	1,  // 14: jaeger.api_v3.FindTraceSummariesRequest.query:type_name -> jaeger.api_v3.TraceQueryParameters
+	12, // 15: jaeger.api_v3.FindTraceSummariesResponse.summaries:type_name -> jaeger.api_v3.TraceSummary
+	18, // 16: jaeger.api_v3.GRPCGatewayError.error:type_name -> jaeger.api_v3.GRPCGatewayError.GRPCGatewayErrorDetails
+	22, // 17: jaeger.api_v3.GRPCGatewayWrapper.result:type_name -> opentelemetry.proto.trace.v1.TracesData
+	0,  // 18: jaeger.api_v3.QueryService.GetTrace:input_type -> jaeger.api_v3.GetTraceRequest
+	2,  // 19: jaeger.api_v3.QueryService.FindTraces:input_type -> jaeger.api_v3.FindTracesRequest
+	3,  // 20: jaeger.api_v3.QueryService.GetServices:input_type -> jaeger.api_v3.GetServicesRequest
+	5,  // 21: jaeger.api_v3.QueryService.GetOperations:input_type -> jaeger.api_v3.GetOperationsRequest
+	8,  // 22: jaeger.api_v3.QueryService.GetDependencies:input_type -> jaeger.api_v3.GetDependenciesRequest
+	13, // 23: jaeger.api_v3.QueryService.FindTraceSummaries:input_type -> jaeger.api_v3.FindTraceSummariesRequest
+	22, // 24: jaeger.api_v3.QueryService.GetTrace:output_type -> opentelemetry.proto.trace.v1.TracesData
+	22, // 25: jaeger.api_v3.QueryService.FindTraces:output_type -> opentelemetry.proto.trace.v1.TracesData
+	4,  // 26: jaeger.api_v3.QueryService.GetServices:output_type -> jaeger.api_v3.GetServicesResponse
+	7,  // 27: jaeger.api_v3.QueryService.GetOperations:output_type -> jaeger.api_v3.GetOperationsResponse
+	9,  // 28: jaeger.api_v3.QueryService.GetDependencies:output_type -> jaeger.api_v3.DependenciesResponse
+	14, // 29: jaeger.api_v3.QueryService.FindTraceSummaries:output_type -> jaeger.api_v3.FindTraceSummariesResponse
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_api_v3_query_service_proto_init() }
+func file_api_v3_query_service_proto_init() {
+	if File_api_v3_query_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v3_query_service_proto_rawDesc), len(file_api_v3_query_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v3_query_service_proto_goTypes,
+		DependencyIndexes: file_api_v3_query_service_proto_depIdxs,
+		MessageInfos:      file_api_v3_query_service_proto_msgTypes,
+	}.Build()
+	File_api_v3_query_service_proto = out.File
+	file_api_v3_query_service_proto_goTypes = nil
+	file_api_v3_query_service_proto_depIdxs = nil
+}
@@ -0,0 +1,362 @@
+// Copyright (c) 2021 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api_v3/query_service.proto
+
+package api_v3
+
+import (
+	context "context"
+	v1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	QueryService_GetTrace_FullMethodName           = "/jaeger.api_v3.QueryService/GetTrace"
+	QueryService_FindTraces_FullMethodName         = "/jaeger.api_v3.QueryService/FindTraces"
+	QueryService_GetServices_FullMethodName        = "/jaeger.api_v3.QueryService/GetServices"
+	QueryService_GetOperations_FullMethodName      = "/jaeger.api_v3.QueryService/GetOperations"
+	QueryService_GetDependencies_FullMethodName    = "/jaeger.api_v3.QueryService/GetDependencies"
+	QueryService_FindTraceSummaries_FullMethodName = "/jaeger.api_v3.QueryService/FindTraceSummaries"
+)
+
+// QueryServiceClient is the client API for QueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QueryServiceClient interface {
+	// GetTrace returns a single trace.
+	// Note that the JSON response over HTTP is wrapped into result envelope "{"result": ...}"
+	// It means that the JSON response cannot be directly unmarshalled using JSONPb.
+	// This can be fixed by first parsing into user-defined envelope with standard JSON library
+	// or string manipulation to remove the envelope. Alternatively generate objects using OpenAPI.
+	GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.TracesData], error)
+	// FindTraces searches for traces.
+	// See GetTrace for JSON unmarshalling.
+	FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.TracesData], error)
+	// GetServices returns service names.
+	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
+	// GetOperations returns operation names.
+	GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error)
+	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*DependenciesResponse, error)
+	// FindTraceSummaries searches for traces matching the given query and streams
+	// back lightweight summary information for each matching trace. Each response
+	// chunk may contain one or more summaries. Use this instead of FindTraces when
+	// full span data is not required (e.g. search results page).
+	FindTraceSummaries(ctx context.Context, in *FindTraceSummariesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FindTraceSummariesResponse], error)
+}
+
+type queryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQueryServiceClient(cc grpc.ClientConnInterface) QueryServiceClient {
+	return &queryServiceClient{cc}
+}
+
+func (c *queryServiceClient) GetTrace(ctx context.Context, in *GetTraceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.TracesData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QueryService_ServiceDesc.Streams[0], QueryService_GetTrace_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTraceRequest, v1.TracesData]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_GetTraceClient = grpc.ServerStreamingClient[v1.TracesData]
+
+func (c *queryServiceClient) FindTraces(ctx context.Context, in *FindTracesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.TracesData], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QueryService_ServiceDesc.Streams[1], QueryService_FindTraces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindTracesRequest, v1.TracesData]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_FindTracesClient = grpc.ServerStreamingClient[v1.TracesData]
+
+func (c *queryServiceClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServicesResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetOperations(ctx context.Context, in *GetOperationsRequest, opts ...grpc.CallOption) (*GetOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperationsResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*DependenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DependenciesResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) FindTraceSummaries(ctx context.Context, in *FindTraceSummariesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FindTraceSummariesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QueryService_ServiceDesc.Streams[2], QueryService_FindTraceSummaries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindTraceSummariesRequest, FindTraceSummariesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_FindTraceSummariesClient = grpc.ServerStreamingClient[FindTraceSummariesResponse]
+
+// QueryServiceServer is the server API for QueryService service.
+// All implementations must embed UnimplementedQueryServiceServer
+// for forward compatibility.
+type QueryServiceServer interface {
+	// GetTrace returns a single trace.
+	// Note that the JSON response over HTTP is wrapped into result envelope "{"result": ...}"
+	// It means that the JSON response cannot be directly unmarshalled using JSONPb.
+	// This can be fixed by first parsing into user-defined envelope with standard JSON library
+	// or string manipulation to remove the envelope. Alternatively generate objects using OpenAPI.
+	GetTrace(*GetTraceRequest, grpc.ServerStreamingServer[v1.TracesData]) error
+	// FindTraces searches for traces.
+	// See GetTrace for JSON unmarshalling.
+	FindTraces(*FindTracesRequest, grpc.ServerStreamingServer[v1.TracesData]) error
+	// GetServices returns service names.
+	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
+	// GetOperations returns operation names.
+	GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error)
+	GetDependencies(context.Context, *GetDependenciesRequest) (*DependenciesResponse, error)
+	// FindTraceSummaries searches for traces matching the given query and streams
+	// back lightweight summary information for each matching trace. Each response
+	// chunk may contain one or more summaries. Use this instead of FindTraces when
+	// full span data is not required (e.g. search results page).
+	FindTraceSummaries(*FindTraceSummariesRequest, grpc.ServerStreamingServer[FindTraceSummariesResponse]) error
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+// UnimplementedQueryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedQueryServiceServer struct{}
+
+func (UnimplementedQueryServiceServer) GetTrace(*GetTraceRequest, grpc.ServerStreamingServer[v1.TracesData]) error {
+	return status.Errorf(codes.Unimplemented, "method GetTrace not implemented")
+}
+func (UnimplementedQueryServiceServer) FindTraces(*FindTracesRequest, grpc.ServerStreamingServer[v1.TracesData]) error {
+	return status.Errorf(codes.Unimplemented, "method FindTraces not implemented")
+}
+func (UnimplementedQueryServiceServer) GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServices not implemented")
+}
+func (UnimplementedQueryServiceServer) GetOperations(context.Context, *GetOperationsRequest) (*GetOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperations not implemented")
+}
+func (UnimplementedQueryServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*DependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencies not implemented")
+}
+func (UnimplementedQueryServiceServer) FindTraceSummaries(*FindTraceSummariesRequest, grpc.ServerStreamingServer[FindTraceSummariesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FindTraceSummaries not implemented")
+}
+func (UnimplementedQueryServiceServer) mustEmbedUnimplementedQueryServiceServer() {}
+func (UnimplementedQueryServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeQueryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QueryServiceServer will
+// result in compilation errors.
+type UnsafeQueryServiceServer interface {
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+func RegisterQueryServiceServer(s grpc.ServiceRegistrar, srv QueryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedQueryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&QueryService_ServiceDesc, srv)
+}
+
+func _QueryService_GetTrace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTraceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).GetTrace(m, &grpc.GenericServerStream[GetTraceRequest, v1.TracesData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_GetTraceServer = grpc.ServerStreamingServer[v1.TracesData]
+
+func _QueryService_FindTraces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindTracesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).FindTraces(m, &grpc.GenericServerStream[FindTracesRequest, v1.TracesData]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_FindTracesServer = grpc.ServerStreamingServer[v1.TracesData]
+
+func _QueryService_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetServices(ctx, req.(*GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetOperations(ctx, req.(*GetOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_FindTraceSummaries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindTraceSummariesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).FindTraceSummaries(m, &grpc.GenericServerStream[FindTraceSummariesRequest, FindTraceSummariesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QueryService_FindTraceSummariesServer = grpc.ServerStreamingServer[FindTraceSummariesResponse]
+
+// QueryService_ServiceDesc is the grpc.ServiceDesc for QueryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QueryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.api_v3.QueryService",
+	HandlerType: (*QueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServices",
+			Handler:    _QueryService_GetServices_Handler,
+		},
+		{
+			MethodName: "GetOperations",
+			Handler:    _QueryService_GetOperations_Handler,
+		},
+		{
+			MethodName: "GetDependencies",
+			Handler:    _QueryService_GetDependencies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetTrace",
+			Handler:       _QueryService_GetTrace_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindTraces",
+			Handler:       _QueryService_FindTraces_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindTraceSummaries",
+			Handler:       _QueryService_FindTraceSummaries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api_v3/query_service.proto",
+}
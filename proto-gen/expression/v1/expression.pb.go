@@ -0,0 +1,632 @@
+// Copyright (c) 2021 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: expression/v1/expression.proto
+
+package expression
+
+import (
+	_ "github.com/google/gnostic-models/openapiv3"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Expression is a node in the filter AST: an atom (one of the three references,
+// or a Scalar or List constant) or a Call over argument Expressions.
+type Expression struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Term:
+	//
+	//	*Expression_Attr
+	//	*Expression_Field
+	//	*Expression_Nested
+	//	*Expression_Scalar
+	//	*Expression_List
+	//	*Expression_Call
+	Term          isExpression_Term `protobuf_oneof:"term"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	mi := &file_expression_v1_expression_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Expression) GetTerm() isExpression_Term {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+func (x *Expression) GetAttr() *AttributeReference {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_Attr); ok {
+			return x.Attr
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetField() *FieldReference {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_Field); ok {
+			return x.Field
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetNested() *NestedReference {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_Nested); ok {
+			return x.Nested
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetScalar() *Scalar {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_Scalar); ok {
+			return x.Scalar
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetList() *List {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_List); ok {
+			return x.List
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetCall() *Call {
+	if x != nil {
+		if x, ok := x.Term.(*Expression_Call); ok {
+			return x.Call
+		}
+	}
+	return nil
+}
+
+type isExpression_Term interface {
+	isExpression_Term()
+}
+
+type Expression_Attr struct {
+	Attr *AttributeReference `protobuf:"bytes,1,opt,name=attr,proto3,oneof"` // an entry in an attribute map, by key
+}
+
+type Expression_Field struct {
+	Field *FieldReference `protobuf:"bytes,2,opt,name=field,proto3,oneof"` // a built-in field of a level (§5.2)
+}
+
+type Expression_Nested struct {
+	Nested *NestedReference `protobuf:"bytes,3,opt,name=nested,proto3,oneof"` // the nested events or links, for `some` (§5.5)
+}
+
+type Expression_Scalar struct {
+	Scalar *Scalar `protobuf:"bytes,4,opt,name=scalar,proto3,oneof"` // a single constant
+}
+
+type Expression_List struct {
+	List *List `protobuf:"bytes,5,opt,name=list,proto3,oneof"` // a homogeneous list constant (right arg of in/not_in)
+}
+
+type Expression_Call struct {
+	Call *Call `protobuf:"bytes,6,opt,name=call,proto3,oneof"` // an operator/function over argument Expressions
+}
+
+func (*Expression_Attr) isExpression_Term() {}
+
+func (*Expression_Field) isExpression_Term() {}
+
+func (*Expression_Nested) isExpression_Term() {}
+
+func (*Expression_Scalar) isExpression_Term() {}
+
+func (*Expression_List) isExpression_Term() {}
+
+func (*Expression_Call) isExpression_Term() {}
+
+// AttributeReference names an entry in one of the span's attribute maps.
+type AttributeReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// level says which attribute map to read. Empty means the unqualified
+	// span-or-resource search (§5.1), so the empty value is one of the enum's own.
+	Level         string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeReference) Reset() {
+	*x = AttributeReference{}
+	mi := &file_expression_v1_expression_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeReference) ProtoMessage() {}
+
+func (x *AttributeReference) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeReference.ProtoReflect.Descriptor instead.
+func (*AttributeReference) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AttributeReference) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AttributeReference) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// FieldReference names a built-in field — a value the data model defines
+// directly rather than an attribute-map entry (§5.2).
+type FieldReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of a built-in field of `level`, not an arbitrary key (§5.2).
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Level         string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldReference) Reset() {
+	*x = FieldReference{}
+	mi := &file_expression_v1_expression_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldReference) ProtoMessage() {}
+
+func (x *FieldReference) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldReference.ProtoReflect.Descriptor instead.
+func (*FieldReference) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FieldReference) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// NestedReference names a span's events or links collection, which is what `some`
+// quantifies over (§5.5).
+type NestedReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NestedReference) Reset() {
+	*x = NestedReference{}
+	mi := &file_expression_v1_expression_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NestedReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NestedReference) ProtoMessage() {}
+
+func (x *NestedReference) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NestedReference.ProtoReflect.Descriptor instead.
+func (*NestedReference) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NestedReference) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// Scalar is a single constant value with an optional type hint.
+//
+// A duration ("2s") and a timestamp (RFC 3339) have no `type` of their
+// own: they travel as an unhinted constant, and the receiving side resolves them
+// from the built-in field they are compared against (§5.4).
+type Scalar struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// value is the constant as text, so a duration or a timestamp travels with the
+	// unit it is written in (§5.4).
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// type is OPTIONAL; empty means any type, a set type is authoritative (§5.4), so the empty
+	// value is one of the enum's own.
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scalar) Reset() {
+	*x = Scalar{}
+	mi := &file_expression_v1_expression_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scalar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scalar) ProtoMessage() {}
+
+func (x *Scalar) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scalar.ProtoReflect.Descriptor instead.
+func (*Scalar) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Scalar) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Scalar) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// List is a homogeneous list constant, the right arg of in/not_in. Every element is read as
+// one type, and that type is always known: either type declares it, or the built-in field the
+// list is compared against supplies it. A list compared against an attribute must declare it,
+// since an attribute declares nothing itself. A list is not a legacy predicate — the legacy
+// fields have no form for membership — so nothing has to accept an element type nobody stated.
+type List struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// values must have at least one element: membership in nothing matches nothing.
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// type is the type every element is read as, and a list matches only values of that type. It is
+	// OPTIONAL only where the field opposite it declares one (see Scalar.type) — a condition on the
+	// enclosing call that this schema cannot state, which is why the empty value is listed here and
+	// the validator checks the rest.
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *List) Reset() {
+	*x = List{}
+	mi := &file_expression_v1_expression_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*List) ProtoMessage() {}
+
+func (x *List) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use List.ProtoReflect.Descriptor instead.
+func (*List) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *List) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *List) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Call applies operator/function `op` to argument Expressions. Arity follows the
+// operator: unary for not/exists, binary for the comparisons and in/not_in,
+// n-ary for and/or. `some` is an event/link existential; its args are a
+// NestedReference and the Call evaluated against each element (§5.5).
+type Call struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Op    string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// args are the operands, and how many an operator takes is a property of op.
+	Args          []*Expression `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Call) Reset() {
+	*x = Call{}
+	mi := &file_expression_v1_expression_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Call) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Call) ProtoMessage() {}
+
+func (x *Call) ProtoReflect() protoreflect.Message {
+	mi := &file_expression_v1_expression_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Call.ProtoReflect.Descriptor instead.
+func (*Call) Descriptor() ([]byte, []int) {
+	return file_expression_v1_expression_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Call) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Call) GetArgs() []*Expression {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+var File_expression_v1_expression_proto protoreflect.FileDescriptor
+
+const file_expression_v1_expression_proto_rawDesc = "" +
+	"\n" +
+	"\x1eexpression/v1/expression.proto\x12\x14jaeger.expression.v1\x1a#gnostic/openapiv3/annotations.proto\"\xc1\x03\n" +
+	"\n" +
+	"Expression\x12>\n" +
+	"\x04attr\x18\x01 \x01(\v2(.jaeger.expression.v1.AttributeReferenceH\x00R\x04attr\x12<\n" +
+	"\x05field\x18\x02 \x01(\v2$.jaeger.expression.v1.FieldReferenceH\x00R\x05field\x12?\n" +
+	"\x06nested\x18\x03 \x01(\v2%.jaeger.expression.v1.NestedReferenceH\x00R\x06nested\x126\n" +
+	"\x06scalar\x18\x04 \x01(\v2\x1c.jaeger.expression.v1.ScalarH\x00R\x06scalar\x120\n" +
+	"\x04list\x18\x05 \x01(\v2\x1a.jaeger.expression.v1.ListH\x00R\x04list\x120\n" +
+	"\x04call\x18\x06 \x01(\v2\x1a.jaeger.expression.v1.CallH\x00R\x04call:P\xbaGM\xda\x01\t\n" +
+	"\a\xba\x01\x04attr\xda\x01\n" +
+	"\n" +
+	"\b\xba\x01\x05field\xda\x01\v\n" +
+	"\t\xba\x01\x06nested\xda\x01\v\n" +
+	"\t\xba\x01\x06scalar\xda\x01\t\n" +
+	"\a\xba\x01\x04list\xda\x01\t\n" +
+	"\a\xba\x01\x04callB\x06\n" +
+	"\x04term\"\x8f\x01\n" +
+	"\x12AttributeReference\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\\\n" +
+	"\x05level\x18\x02 \x01(\tBF\xbaGC\xc2\x01\x04\x12\x02''\xc2\x01\x06\x12\x04span\xc2\x01\n" +
+	"\x12\bresource\xc2\x01\a\x12\x05scope\xc2\x01\a\x12\x05event\xc2\x01\x06\x12\x04link\xca\x01\x06stringR\x05level:\t\xbaG\x06\xba\x01\x03key\"\x8f\x01\n" +
+	"\x0eFieldReference\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12U\n" +
+	"\x05level\x18\x02 \x01(\tB?\xbaG<\xc2\x01\x06\x12\x04span\xc2\x01\n" +
+	"\x12\bresource\xc2\x01\a\x12\x05scope\xc2\x01\a\x12\x05event\xc2\x01\x06\x12\x04link\xca\x01\x06stringR\x05level:\x12\xbaG\x0f\xba\x01\x04name\xba\x01\x05level\"U\n" +
+	"\x0fNestedReference\x125\n" +
+	"\x05level\x18\x01 \x01(\tB\x1f\xbaG\x1c\xc2\x01\a\x12\x05event\xc2\x01\x06\x12\x04link\xca\x01\x06stringR\x05level:\v\xbaG\b\xba\x01\x05level\"{\n" +
+	"\x06Scalar\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12N\n" +
+	"\x04type\x18\x02 \x01(\tB:\xbaG7\xc2\x01\x04\x12\x02''\xc2\x01\b\x12\x06string\xc2\x01\x05\x12\x03int\xc2\x01\b\x12\x06double\xc2\x01\x06\x12\x04bool\xca\x01\x06stringR\x04type:\v\xbaG\b\xba\x01\x05value\"\x84\x01\n" +
+	"\x04List\x12\x1e\n" +
+	"\x06values\x18\x01 \x03(\tB\x06\xbaG\x03\x98\x01\x01R\x06values\x12N\n" +
+	"\x04type\x18\x02 \x01(\tB:\xbaG7\xc2\x01\x04\x12\x02''\xc2\x01\b\x12\x06string\xc2\x01\x05\x12\x03int\xc2\x01\b\x12\x06double\xc2\x01\x06\x12\x04bool\xca\x01\x06stringR\x04type:\f\xbaG\t\xba\x01\x06values\"\xdf\x01\n" +
+	"\x04Call\x12\x8f\x01\n" +
+	"\x02op\x18\x01 \x01(\tB\x7f\xbaG|\xc2\x01\x05\x12\x03and\xc2\x01\x04\x12\x02or\xc2\x01\x05\x12\x03not\xc2\x01\x04\x12\x02eq\xc2\x01\x04\x12\x02ne\xc2\x01\x04\x12\x02gt\xc2\x01\x04\x12\x02lt\xc2\x01\x05\x12\x03gte\xc2\x01\x05\x12\x03lte\xc2\x01\a\x12\x05regex\xc2\x01\b\x12\x06exists\xc2\x01\x04\x12\x02in\xc2\x01\b\x12\x06not_in\xc2\x01\x06\x12\x04some\xca\x01\x06stringR\x02op\x124\n" +
+	"\x04args\x18\x02 \x03(\v2 .jaeger.expression.v1.ExpressionR\x04args:\x0f\xbaG\f\xba\x01\x02op\xba\x01\x04argsB\fZ\n" +
+	"expressionb\x06proto3"
+
+var (
+	file_expression_v1_expression_proto_rawDescOnce sync.Once
+	file_expression_v1_expression_proto_rawDescData []byte
+)
+
+func file_expression_v1_expression_proto_rawDescGZIP() []byte {
+	file_expression_v1_expression_proto_rawDescOnce.Do(func() {
+		file_expression_v1_expression_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_expression_v1_expression_proto_rawDesc), len(file_expression_v1_expression_proto_rawDesc)))
+	})
+	return file_expression_v1_expression_proto_rawDescData
+}
+
+var file_expression_v1_expression_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_expression_v1_expression_proto_goTypes = []any{
+	(*Expression)(nil),         // 0: jaeger.expression.v1.Expression
+	(*AttributeReference)(nil), // 1: jaeger.expression.v1.AttributeReference
+	(*FieldReference)(nil),     // 2: jaeger.expression.v1.FieldReference
+	(*NestedReference)(nil),    // 3: jaeger.expression.v1.NestedReference
+	(*Scalar)(nil),             // 4: jaeger.expression.v1.Scalar
+	(*List)(nil),               // 5: jaeger.expression.v1.List
+	(*Call)(nil),               // 6: jaeger.expression.v1.Call
+}
+var file_expression_v1_expression_proto_depIdxs = []int32{
+	1, // 0: jaeger.expression.v1.Expression.attr:type_name -> jaeger.expression.v1.AttributeReference
+	2, // 1: jaeger.expression.v1.Expression.field:type_name -> jaeger.expression.v1.FieldReference
+	3, // 2: jaeger.expression.v1.Expression.nested:type_name -> jaeger.expression.v1.NestedReference
+	4, // 3: jaeger.expression.v1.Expression.scalar:type_name -> jaeger.expression.v1.Scalar
+	5, // 4: jaeger.expression.v1.Expression.list:type_name -> jaeger.expression.v1.List
+	6, // 5: jaeger.expression.v1.Expression.call:type_name -> jaeger.expression.v1.Call
+	0, // 6: jaeger.expression.v1.Call.args:type_name -> jaeger.expression.v1.Expression
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_expression_v1_expression_proto_init() }
+func file_expression_v1_expression_proto_init() {
+	if File_expression_v1_expression_proto != nil {
+		return
+	}
+	file_expression_v1_expression_proto_msgTypes[0].OneofWrappers = []any{
+		(*Expression_Attr)(nil),
+		(*Expression_Field)(nil),
+		(*Expression_Nested)(nil),
+		(*Expression_Scalar)(nil),
+		(*Expression_List)(nil),
+		(*Expression_Call)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_expression_v1_expression_proto_rawDesc), len(file_expression_v1_expression_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_expression_v1_expression_proto_goTypes,
+		DependencyIndexes: file_expression_v1_expression_proto_depIdxs,
+		MessageInfos:      file_expression_v1_expression_proto_msgTypes,
+	}.Build()
+	File_expression_v1_expression_proto = out.File
+	file_expression_v1_expression_proto_goTypes = nil
+	file_expression_v1_expression_proto_depIdxs = nil
+}
@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: storage/v2/capabilities.proto
+
+package storage
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SearchCapabilities describes how faithfully a backend can serve the search RPCs of
+// TraceReader: which TraceQueryParameters fields it accepts as empty, and — as fields
+// are added here — which it honors exactly rather than approximating. Its zero value is
+// the least capable backend, so a field added later leaves existing implementations
+// reporting the new capability as unsupported.
+type SearchCapabilities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// without_service_name is true when FindTraces, FindTraceIDs and FindTraceSummaries
+	// accept a query whose service_name is empty and read it as "any service", rather
+	// than as an error or an empty result. Backends that index by service name, such as
+	// Cassandra, cannot do this.
+	WithoutServiceName bool `protobuf:"varint,1,opt,name=without_service_name,json=withoutServiceName,proto3" json:"without_service_name,omitempty"`
+	// same_span_conjunction is true when a multi-predicate conjunction — the legacy
+	// attributes map, or an `and` in the structured filter — is matched within a single
+	// span. false means the backend may satisfy different conjuncts from different spans
+	// of the same trace, as a flat inverted index intersecting at trace granularity does.
+	// It is reported, not enforced: the query service does not refuse a conjunction, it
+	// surfaces the looser scoping so a caller that needs strict single-span matching is
+	// not surprised by the result.
+	SameSpanConjunction bool `protobuf:"varint,2,opt,name=same_span_conjunction,json=sameSpanConjunction,proto3" json:"same_span_conjunction,omitempty"`
+	// filter describes structured-filter support (see FilterCapabilities below). An absent message and an
+	// empty one (both lists below empty) mean the same thing — no structured-filter
+	// support — so the query service down-converts any filter to the legacy service_name /
+	// attributes / duration fields, refusing what those cannot express. This follows the
+	// opt-in, zero-value-is-least-capable rule above: a backend advertises filter support
+	// only by populating the lists below, and there is no distinct "present but empty"
+	// state to get wrong.
+	Filter        *FilterCapabilities `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCapabilities) Reset() {
+	*x = SearchCapabilities{}
+	mi := &file_storage_v2_capabilities_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCapabilities) ProtoMessage() {}
+
+func (x *SearchCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_capabilities_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCapabilities.ProtoReflect.Descriptor instead.
+func (*SearchCapabilities) Descriptor() ([]byte, []int) {
+	return file_storage_v2_capabilities_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchCapabilities) GetWithoutServiceName() bool {
+	if x != nil {
+		return x.WithoutServiceName
+	}
+	return false
+}
+
+func (x *SearchCapabilities) GetSameSpanConjunction() bool {
+	if x != nil {
+		return x.SameSpanConjunction
+	}
+	return false
+}
+
+func (x *SearchCapabilities) GetFilter() *FilterCapabilities {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// FilterCapabilities declares how much of the structured trace-query filter a backend
+// can serve, by listing the levels and operators it evaluates. (The filter is Jaeger
+// RFC 0005: https://github.com/jaegertracing/jaeger/blob/main/docs/rfc/0005-structured-query-filters.md.)
+// A backend opts in only by naming what it serves, and what each state means is fixed:
+//
+//   - absent, or present with both lists empty: no structured-filter support. The query service
+//     converts a filter to the legacy service_name / attributes / duration fields and refuses
+//     what those cannot express.
+//   - operators non-empty, levels empty: the backend serves unqualified references only. An
+//     unqualified reference always reaches it; a level-qualified one is refused.
+//   - both non-empty: the backend serves the named levels, plus unqualified references, under the
+//     named operators.
+//   - operators empty, levels non-empty: nothing. A filter's root is always a call, so a backend
+//     that names no operator has named nothing it can evaluate, and every predicate is refused
+//     rather than converted to the legacy fields. Do not declare this state.
+//
+// Where the lists are non-empty they are refusal gates: the query service rejects a predicate that
+// names a level or an operator not listed here.
+//
+// These two lists are coarse admission gates rather than a guarantee of evaluation. They are
+// deliberately narrower than the AST: a backend that lists `span` and `eq` says nothing about
+// which built-in fields it holds, which type hints it honors, which list element types it can
+// match, or which regular expressions its engine can evaluate faithfully. So a reader may still
+// refuse a filter this gate admitted, and it owes a refusal rather than the results of the part
+// it could evaluate. Reliable preflight — a UI graying out what a backend cannot serve — needs a
+// capability surface of its own rather than more meaning read into these two lists.
+type FilterCapabilities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// levels lists the attribute levels the backend can filter on
+	// (span|resource|scope|event|link). Empty means it serves no level-qualified predicate, so only
+	// unqualified (empty-level) references reach it — which is support, not the absence of it, as
+	// long as operators names something (see the table above).
+	Levels []string `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty"`
+	// operators lists the op values the backend evaluates
+	// (and|or|not|eq|ne|gt|lt|gte|lte|regex|exists|in|not_in|some). A predicate whose op is
+	// not listed is refused. The boolean combinators are listed here like any other
+	// operator: a flat inverted index declares `and` and omits `or` and `not`, which is
+	// what confines it to the conjunctive subset. Nesting is not separately declared,
+	// because `and` is associative and a caller flattens it before asking.
+	Operators     []string `protobuf:"bytes,2,rep,name=operators,proto3" json:"operators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterCapabilities) Reset() {
+	*x = FilterCapabilities{}
+	mi := &file_storage_v2_capabilities_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCapabilities) ProtoMessage() {}
+
+func (x *FilterCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_capabilities_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCapabilities.ProtoReflect.Descriptor instead.
+func (*FilterCapabilities) Descriptor() ([]byte, []int) {
+	return file_storage_v2_capabilities_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FilterCapabilities) GetLevels() []string {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *FilterCapabilities) GetOperators() []string {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+// GetCapabilitiesRequest represents a request for the backend's capabilities.
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_storage_v2_capabilities_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_capabilities_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_storage_v2_capabilities_proto_rawDescGZIP(), []int{2}
+}
+
+// GetCapabilitiesResponse represents the capabilities of a storage backend, grouped by
+// the concern each set describes rather than as a flat list of flags, so that a later
+// addition is a sibling field.
+type GetCapabilitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Search        *SearchCapabilities    `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
+	mi := &file_storage_v2_capabilities_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesResponse) ProtoMessage() {}
+
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_v2_capabilities_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_storage_v2_capabilities_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCapabilitiesResponse) GetSearch() *SearchCapabilities {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+var File_storage_v2_capabilities_proto protoreflect.FileDescriptor
+
+const file_storage_v2_capabilities_proto_rawDesc = "" +
+	"\n" +
+	"\x1dstorage/v2/capabilities.proto\x12\x11jaeger.storage.v2\"\xb9\x01\n" +
+	"\x12SearchCapabilities\x120\n" +
+	"\x14without_service_name\x18\x01 \x01(\bR\x12withoutServiceName\x122\n" +
+	"\x15same_span_conjunction\x18\x02 \x01(\bR\x13sameSpanConjunction\x12=\n" +
+	"\x06filter\x18\x03 \x01(\v2%.jaeger.storage.v2.FilterCapabilitiesR\x06filter\"J\n" +
+	"\x12FilterCapabilities\x12\x16\n" +
+	"\x06levels\x18\x01 \x03(\tR\x06levels\x12\x1c\n" +
+	"\toperators\x18\x02 \x03(\tR\toperators\"\x18\n" +
+	"\x16GetCapabilitiesRequest\"X\n" +
+	"\x17GetCapabilitiesResponse\x12=\n" +
+	"\x06search\x18\x01 \x01(\v2%.jaeger.storage.v2.SearchCapabilitiesR\x06search2z\n" +
+	"\fCapabilities\x12j\n" +
+	"\x0fGetCapabilities\x12).jaeger.storage.v2.GetCapabilitiesRequest\x1a*.jaeger.storage.v2.GetCapabilitiesResponse\"\x00B\tZ\astorageb\x06proto3"
+
+var (
+	file_storage_v2_capabilities_proto_rawDescOnce sync.Once
+	file_storage_v2_capabilities_proto_rawDescData []byte
+)
+
+func file_storage_v2_capabilities_proto_rawDescGZIP() []byte {
+	file_storage_v2_capabilities_proto_rawDescOnce.Do(func() {
+		file_storage_v2_capabilities_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_storage_v2_capabilities_proto_rawDesc), len(file_storage_v2_capabilities_proto_rawDesc)))
+	})
+	return file_storage_v2_capabilities_proto_rawDescData
+}
+
+var file_storage_v2_capabilities_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_storage_v2_capabilities_proto_goTypes = []any{
+	(*SearchCapabilities)(nil),      // 0: jaeger.storage.v2.SearchCapabilities
+	(*FilterCapabilities)(nil),      // 1: jaeger.storage.v2.FilterCapabilities
+	(*GetCapabilitiesRequest)(nil),  // 2: jaeger.storage.v2.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil), // 3: jaeger.storage.v2.GetCapabilitiesResponse
+}
+var file_storage_v2_capabilities_proto_depIdxs = []int32{
+	1, // 0: jaeger.storage.v2.SearchCapabilities.filter:type_name -> jaeger.storage.v2.FilterCapabilities
+	0, // 1: jaeger.storage.v2.GetCapabilitiesResponse.search:type_name -> jaeger.storage.v2.SearchCapabilities
+	2, // 2: jaeger.storage.v2.Capabilities.GetCapabilities:input_type -> jaeger.storage.v2.GetCapabilitiesRequest
+	3, // 3: jaeger.storage.v2.Capabilities.GetCapabilities:output_type -> jaeger.storage.v2.GetCapabilitiesResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_storage_v2_capabilities_proto_init() }
+func file_storage_v2_capabilities_proto_init() {
+	if File_storage_v2_capabilities_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storage_v2_capabilities_proto_rawDesc), len(file_storage_v2_capabilities_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_storage_v2_capabilities_proto_goTypes,
+		DependencyIndexes: file_storage_v2_capabilities_proto_depIdxs,
+		MessageInfos:      file_storage_v2_capabilities_proto_msgTypes,
+	}.Build()
+	File_storage_v2_capabilities_proto = out.File
+	file_storage_v2_capabilities_proto_goTypes = nil
+	file_storage_v2_capabilities_proto_depIdxs = nil
+}
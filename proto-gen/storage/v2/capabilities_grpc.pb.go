@@ -0,0 +1,157 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: storage/v2/capabilities.proto
+
+package storage
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Capabilities_GetCapabilities_FullMethodName = "/jaeger.storage.v2.Capabilities/GetCapabilities"
+)
+
+// CapabilitiesClient is the client API for Capabilities service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Capabilities reports what a storage backend is able to do, so a caller can avoid
+// asking for something it cannot serve — Jaeger's query service passes the answer to
+// its UI, which offers a cross-service search only where the backend accepts one.
+//
+// It is a service of its own rather than a method on TraceReader because what it
+// reports is not confined to reading traces: jaeger.storage.v1 reported writer-side
+// capabilities through the same mechanism, and a v2 backend may need to report on
+// writes or dependencies without those answers arriving through the trace-reading API.
+//
+// A backend that does not implement this service returns gRPC status UNIMPLEMENTED,
+// which the caller reads as "unknown" and treats as the least capable backend. That
+// keeps every existing backend working unchanged: registering the service is opt-in,
+// and the generated UnimplementedCapabilitiesServer embedding provides the status for a
+// backend that registers it without overriding the method.
+//
+// Capabilities are expected to be stable for the lifetime of a connection, so a caller
+// may ask once and cache the answer.
+type CapabilitiesClient interface {
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+}
+
+type capabilitiesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCapabilitiesClient(cc grpc.ClientConnInterface) CapabilitiesClient {
+	return &capabilitiesClient{cc}
+}
+
+func (c *capabilitiesClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, Capabilities_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CapabilitiesServer is the server API for Capabilities service.
+// All implementations must embed UnimplementedCapabilitiesServer
+// for forward compatibility.
+//
+// Capabilities reports what a storage backend is able to do, so a caller can avoid
+// asking for something it cannot serve — Jaeger's query service passes the answer to
+// its UI, which offers a cross-service search only where the backend accepts one.
+//
+// It is a service of its own rather than a method on TraceReader because what it
+// reports is not confined to reading traces: jaeger.storage.v1 reported writer-side
+// capabilities through the same mechanism, and a v2 backend may need to report on
+// writes or dependencies without those answers arriving through the trace-reading API.
+//
+// A backend that does not implement this service returns gRPC status UNIMPLEMENTED,
+// which the caller reads as "unknown" and treats as the least capable backend. That
+// keeps every existing backend working unchanged: registering the service is opt-in,
+// and the generated UnimplementedCapabilitiesServer embedding provides the status for a
+// backend that registers it without overriding the method.
+//
+// Capabilities are expected to be stable for the lifetime of a connection, so a caller
+// may ask once and cache the answer.
+type CapabilitiesServer interface {
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	mustEmbedUnimplementedCapabilitiesServer()
+}
+
+// UnimplementedCapabilitiesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCapabilitiesServer struct{}
+
+func (UnimplementedCapabilitiesServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedCapabilitiesServer) mustEmbedUnimplementedCapabilitiesServer() {}
+func (UnimplementedCapabilitiesServer) testEmbeddedByValue()                      {}
+
+// UnsafeCapabilitiesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CapabilitiesServer will
+// result in compilation errors.
+type UnsafeCapabilitiesServer interface {
+	mustEmbedUnimplementedCapabilitiesServer()
+}
+
+func RegisterCapabilitiesServer(s grpc.ServiceRegistrar, srv CapabilitiesServer) {
+	// If the following call pancis, it indicates UnimplementedCapabilitiesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Capabilities_ServiceDesc, srv)
+}
+
+func _Capabilities_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CapabilitiesServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Capabilities_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CapabilitiesServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Capabilities_ServiceDesc is the grpc.ServiceDesc for Capabilities service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Capabilities_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.storage.v2.Capabilities",
+	HandlerType: (*CapabilitiesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Capabilities_GetCapabilities_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "storage/v2/capabilities.proto",
+}
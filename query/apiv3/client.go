@@ -0,0 +1,294 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apiv3 holds clients of the api_v3 QueryService, for a program that reads traces from
+// Jaeger rather than serves them: Client calls it over gRPC, and HTTPClient through the HTTP/JSON
+// endpoints of its OpenAPI document, with the same methods. Both take and return the generated
+// messages of proto-gen/api_v3, and spare their caller what the generated client leaves to it:
+//
+//   - GetTrace, FindTraces and FindTraceSummaries stream their results, and return iterators that
+//     yield one whole trace, or one summary, at a time. A trace may be streamed in several chunks,
+//     which the iterator joins, so its caller never sees half of one.
+//   - The filter of a search is a *expression.Call, which a client turns into the message
+//     TraceQueryParameters carries.
+//   - A trace ID is the hex string api_v3 takes and TraceSummary gives, which a client checks and
+//     writes in one form before it is sent, so that a malformed one fails before any call is made.
+package apiv3
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api_v3 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v3"
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/chunk"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// traceIDSize is the size of a trace ID in bytes. A 64-bit ID is a 128-bit one whose high half is
+// zero.
+const traceIDSize = 16
+
+// Client calls the api_v3 QueryService over a gRPC connection.
+type Client struct {
+	service api_v3.QueryServiceClient
+}
+
+// NewClient returns a client that calls the QueryService over the given connection, which the
+// caller keeps and closes.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{service: api_v3.NewQueryServiceClient(conn)}
+}
+
+// GetTraceOptions are the optional parameters of GetTrace. The zero value asks for the trace
+// wherever it is, enriched as Jaeger enriches what it returns.
+type GetTraceOptions struct {
+	// StartTime and EndTime bound where the trace is looked for, when not zero.
+	StartTime, EndTime time.Time
+	// RawTraces asks for the trace as it was stored, without enrichments such as clock skew
+	// adjustment.
+	RawTraces bool
+}
+
+// TraceQuery is a search for traces, as TraceQueryParameters carries it, with Go types in place of
+// the well-known ones. A field left zero does not narrow the search, except StartTimeMin and
+// StartTimeMax, which the QueryService requires.
+type TraceQuery struct {
+	ServiceName   string
+	OperationName string
+	// Attributes holds the attributes a span has to have, each with its value as a string.
+	Attributes                 map[string]string
+	StartTimeMin, StartTimeMax time.Time
+	DurationMin, DurationMax   time.Duration
+	// SearchDepth is the most traces to return, where the server leaves it to the caller.
+	SearchDepth int32
+	// RawTraces asks for traces as they were stored, without enrichments.
+	RawTraces bool
+	// Filter is the structured filter of RFC 0005. It is mutually exclusive with the legacy
+	// predicates, ServiceName, OperationName, Attributes, DurationMin and DurationMax, as
+	// query_service.proto defines it: a query that sets it with any of them, or a filter that does
+	// not validate, is refused before any call is made.
+	Filter *expression.Call
+}
+
+// GetTrace returns an iterator over the trace with the given ID, a hex string of 64 or 128 bits,
+// which yields it whole, once, when it is found. An ID that is not one, and an error of the call,
+// are yielded on their own, and end the iteration.
+func (c *Client) GetTrace(ctx context.Context, traceID string, options GetTraceOptions) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		id, err := ParseTraceID(traceID)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := c.service.GetTrace(ctx, &api_v3.GetTraceRequest{
+			TraceId:   FormatTraceID(id),
+			StartTime: timestamp(options.StartTime),
+			EndTime:   timestamp(options.EndTime),
+			RawTraces: options.RawTraces,
+		})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		Traces(stream.Recv)(yield)
+	}
+}
+
+// FindTraces returns an iterator over the traces a search finds, which yields each whole, in the
+// order the server streams them. An error of the query or the call is yielded on its own, and ends
+// the iteration; what was yielded before it stands. Stopping the iteration early cancels the call.
+func (c *Client) FindTraces(ctx context.Context, query TraceQuery) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		params, err := query.Proto()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := c.service.FindTraces(ctx, &api_v3.FindTracesRequest{Query: params})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		Traces(stream.Recv)(yield)
+	}
+}
+
+// FindTraceSummaries returns an iterator over the summaries of the traces a search finds, one at a
+// time, however the server groups them in its responses. Errors end the iteration as those of
+// FindTraces do.
+func (c *Client) FindTraceSummaries(ctx context.Context, query TraceQuery) iter.Seq2[*api_v3.TraceSummary, error] {
+	return func(yield func(*api_v3.TraceSummary, error) bool) {
+		params, err := query.Proto()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := c.service.FindTraceSummaries(ctx, &api_v3.FindTraceSummariesRequest{Query: params})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for {
+			response, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, summary := range response.GetSummaries() {
+				if !yield(summary, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetServices returns the names of the services the server knows of.
+func (c *Client) GetServices(ctx context.Context) ([]string, error) {
+	response, err := c.service.GetServices(ctx, &api_v3.GetServicesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetServices(), nil
+}
+
+// GetOperations returns the operations of a service, of the given span kind when it is not empty.
+func (c *Client) GetOperations(ctx context.Context, service, spanKind string) ([]*api_v3.Operation, error) {
+	response, err := c.service.GetOperations(ctx, &api_v3.GetOperationsRequest{Service: service, SpanKind: spanKind})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetOperations(), nil
+}
+
+// GetDependencies returns the links between services seen from start to end.
+func (c *Client) GetDependencies(ctx context.Context, start, end time.Time) ([]*api_v3.Dependency, error) {
+	response, err := c.service.GetDependencies(ctx, &api_v3.GetDependenciesRequest{
+		StartTime: timestamp(start),
+		EndTime:   timestamp(end),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetDependencies(), nil
+}
+
+// Proto returns the TraceQueryParameters that carry the query, with its filter validated and turned
+// into the message of proto-gen/expression/v1 by way of the proto3 JSON form both speak. The filter
+// is not finalized: the server finalizes what it is sent against its own fields. A query that sets a
+// filter with a legacy predicate is refused, as the server would refuse it.
+func (q TraceQuery) Proto() (*api_v3.TraceQueryParameters, error) {
+	params := &api_v3.TraceQueryParameters{
+		ServiceName:   q.ServiceName,
+		OperationName: q.OperationName,
+		Attributes:    q.Attributes,
+		StartTimeMin:  timestamp(q.StartTimeMin),
+		StartTimeMax:  timestamp(q.StartTimeMax),
+		DurationMin:   duration(q.DurationMin),
+		DurationMax:   duration(q.DurationMax),
+		SearchDepth:   q.SearchDepth,
+		RawTraces:     q.RawTraces,
+	}
+	if q.Filter != nil {
+		if legacy := q.legacyPredicates(); len(legacy) > 0 {
+			return nil, fmt.Errorf("invalid query: filter is mutually exclusive with the legacy predicates, and the query also sets %s", legacy[0])
+		}
+		if err := expression.ValidateFilter(q.Filter); err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		data, err := q.Filter.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		params.Filter = &expressionpb.Call{}
+		if err := protojson.Unmarshal(data, params.Filter); err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+	}
+	return params, nil
+}
+
+// legacyPredicates returns the names, as query_service.proto spells them, of the legacy predicates
+// the query sets.
+func (q TraceQuery) legacyPredicates() []string {
+	var set []string
+	if q.ServiceName != "" {
+		set = append(set, "service_name")
+	}
+	if q.OperationName != "" {
+		set = append(set, "operation_name")
+	}
+	if len(q.Attributes) > 0 {
+		set = append(set, "attributes")
+	}
+	if q.DurationMin != 0 {
+		set = append(set, "duration_min")
+	}
+	if q.DurationMax != 0 {
+		set = append(set, "duration_max")
+	}
+	return set
+}
+
+// Traces returns an iterator that reads chunks with recv until it returns io.EOF, and yields each
+// trace whole, as chunk.JoinTraces does. It is exported for a transport other than gRPC, which
+// reads chunks of its own.
+func Traces(recv func() (*tracev1.TracesData, error)) iter.Seq2[*tracev1.TracesData, error] {
+	return chunk.JoinTraces(recv)
+}
+
+// ParseTraceID reads a trace ID written in hex, as api_v3 writes it, in either case and with or
+// without the leading zeros of a 64-bit ID, and returns its 16 bytes, as OTLP carries it.
+func ParseTraceID(id string) ([]byte, error) {
+	if id == "" || len(id) > 2*traceIDSize {
+		return nil, fmt.Errorf("invalid trace ID %q: a trace ID has 1 to %d hex digits", id, 2*traceIDSize)
+	}
+	decoded, err := hex.DecodeString(strings.Repeat("0", 2*traceIDSize-len(id)) + id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace ID %q: %w", id, err)
+	}
+	return decoded, nil
+}
+
+// FormatTraceID writes the bytes of a trace ID as the hex string api_v3 takes: 32 lowercase digits.
+func FormatTraceID(id []byte) string {
+	return hex.EncodeToString(id)
+}
+
+// timestamp returns the Timestamp of t, or nil when t is zero, which api_v3 reads as not given.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// duration returns the Duration of d, or nil when d is zero, which api_v3 reads as not given.
+func duration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
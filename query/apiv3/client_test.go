@@ -0,0 +1,391 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apiv3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api_v3 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v3"
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeService streams the chunks and summaries it is given, or fails with err, and keeps the last
+// request of each method.
+type fakeService struct {
+	api_v3.UnimplementedQueryServiceServer
+	chunks    []*tracev1.TracesData
+	summaries []*api_v3.FindTraceSummariesResponse
+	err       error
+
+	getTrace     *api_v3.GetTraceRequest
+	findTraces   *api_v3.FindTracesRequest
+	findSummary  *api_v3.FindTraceSummariesRequest
+	operations   *api_v3.GetOperationsRequest
+	dependencies *api_v3.GetDependenciesRequest
+}
+
+func (f *fakeService) GetTrace(request *api_v3.GetTraceRequest, stream api_v3.QueryService_GetTraceServer) error {
+	f.getTrace = request
+	return f.stream(stream)
+}
+
+func (f *fakeService) FindTraces(request *api_v3.FindTracesRequest, stream api_v3.QueryService_FindTracesServer) error {
+	f.findTraces = request
+	return f.stream(stream)
+}
+
+func (f *fakeService) stream(stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	for _, chunk := range f.chunks {
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func (f *fakeService) FindTraceSummaries(request *api_v3.FindTraceSummariesRequest, stream api_v3.QueryService_FindTraceSummariesServer) error {
+	f.findSummary = request
+	for _, response := range f.summaries {
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func (f *fakeService) GetServices(context.Context, *api_v3.GetServicesRequest) (*api_v3.GetServicesResponse, error) {
+	return &api_v3.GetServicesResponse{Services: []string{"driver", "frontend"}}, f.err
+}
+
+func (f *fakeService) GetOperations(_ context.Context, request *api_v3.GetOperationsRequest) (*api_v3.GetOperationsResponse, error) {
+	f.operations = request
+	return &api_v3.GetOperationsResponse{Operations: []*api_v3.Operation{{Name: "GET /", SpanKind: "server"}}}, f.err
+}
+
+func (f *fakeService) GetDependencies(_ context.Context, request *api_v3.GetDependenciesRequest) (*api_v3.DependenciesResponse, error) {
+	f.dependencies = request
+	return &api_v3.DependenciesResponse{Dependencies: []*api_v3.Dependency{{Parent: "frontend", Child: "driver", CallCount: 2}}}, f.err
+}
+
+// serve serves a fake QueryService over an in-memory connection, and returns a client of it.
+func serve(t *testing.T, service *fakeService) *Client {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	api_v3.RegisterQueryServiceServer(server, service)
+	go server.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return NewClient(conn)
+}
+
+// traceChunk builds a chunk holding one span of a trace, named for the chunk.
+func traceChunk(trace byte, name string) *tracev1.TracesData {
+	id := make([]byte, traceIDSize)
+	id[traceIDSize-1] = trace
+	return &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{{
+		Resource:   &resourcev1.Resource{},
+		ScopeSpans: []*tracev1.ScopeSpans{{Spans: []*tracev1.Span{{TraceId: id, Name: name}}}},
+	}}}
+}
+
+// spanNames returns the names of the spans of each trace, in order.
+func spanNames(traces []*tracev1.TracesData) [][]string {
+	var names [][]string
+	for _, trace := range traces {
+		var traceNames []string
+		for _, rs := range trace.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, s := range ss.GetSpans() {
+					traceNames = append(traceNames, s.GetName())
+				}
+			}
+		}
+		names = append(names, traceNames)
+	}
+	return names
+}
+
+func collect[T any](t *testing.T, seq func(func(T, error) bool)) ([]T, error) {
+	t.Helper()
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func TestClient_GetTrace(t *testing.T) {
+	service := &fakeService{chunks: []*tracev1.TracesData{traceChunk(7, "root"), traceChunk(7, "child")}}
+	client := serve(t, service)
+
+	traces, err := collect(t, client.GetTrace(context.Background(), "7", GetTraceOptions{StartTime: base, RawTraces: true}))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"root", "child"}}, spanNames(traces), "the chunks of the trace are joined")
+	assert.True(t, proto.Equal(&api_v3.GetTraceRequest{
+		TraceId:   "00000000000000000000000000000007",
+		StartTime: timestamppb.New(base),
+		RawTraces: true,
+	}, service.getTrace), "got %v", service.getTrace)
+}
+
+func TestClient_GetTrace_Errors(t *testing.T) {
+	service := &fakeService{err: status.Error(codes.NotFound, "trace not found")}
+	client := serve(t, service)
+
+	_, err := collect(t, client.GetTrace(context.Background(), "not hex", GetTraceOptions{}))
+	require.ErrorContains(t, err, `invalid trace ID "not hex"`)
+	assert.Nil(t, service.getTrace, "a malformed ID is refused before the call")
+
+	_, err = collect(t, client.GetTrace(context.Background(), "abc", GetTraceOptions{}))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClient_FindTraces(t *testing.T) {
+	service := &fakeService{chunks: []*tracev1.TracesData{
+		traceChunk(1, "a1"), traceChunk(1, "a2"), {}, traceChunk(2, "b1"), traceChunk(3, "c1"), traceChunk(3, "c2"),
+	}}
+	client := serve(t, service)
+	query := TraceQuery{
+		ServiceName:  "frontend",
+		Attributes:   map[string]string{"http.method": "GET"},
+		StartTimeMin: base,
+		StartTimeMax: base.Add(time.Hour),
+		DurationMin:  time.Millisecond,
+		SearchDepth:  20,
+	}
+
+	traces, err := collect(t, client.FindTraces(context.Background(), query))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1", "a2"}, {"b1"}, {"c1", "c2"}}, spanNames(traces))
+
+	sent := service.findTraces.GetQuery()
+	assert.Equal(t, "frontend", sent.GetServiceName())
+	assert.Equal(t, map[string]string{"http.method": "GET"}, sent.GetAttributes())
+	assert.True(t, proto.Equal(timestamppb.New(base.Add(time.Hour)), sent.GetStartTimeMax()))
+	assert.True(t, proto.Equal(durationpb.New(time.Millisecond), sent.GetDurationMin()))
+	assert.Nil(t, sent.GetDurationMax(), "a zero duration is not sent")
+	assert.Equal(t, int32(20), sent.GetSearchDepth())
+	assert.Nil(t, sent.GetFilter())
+}
+
+func TestClient_FindTraces_Filter(t *testing.T) {
+	service := &fakeService{chunks: []*tracev1.TracesData{traceChunk(1, "a1")}}
+	client := serve(t, service)
+	query := TraceQuery{
+		StartTimeMin: base,
+		StartTimeMax: base.Add(time.Hour),
+		SearchDepth:  20,
+		Filter: &expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+			&expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldName},
+			&expression.AnyValue{Value: "GET /"},
+		}},
+	}
+
+	traces, err := collect(t, client.FindTraces(context.Background(), query))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1"}}, spanNames(traces))
+
+	sent := service.findTraces.GetQuery()
+	assert.Empty(t, sent.GetServiceName())
+	assert.Equal(t, "eq", sent.GetFilter().GetOp())
+	assert.True(t, proto.Equal(&expressionpb.FieldReference{Level: "span", Name: "name"}, sent.GetFilter().GetArgs()[0].GetField()),
+		"got %v", sent.GetFilter())
+}
+
+func TestTraceQuery_Proto_FilterWithLegacyPredicates(t *testing.T) {
+	filter := &expression.Call{Op: expression.OpExists, Args: []expression.Expression{&expression.AttributeRef{Key: "k"}}}
+	tests := []struct {
+		query    TraceQuery
+		expected string
+	}{
+		{query: TraceQuery{ServiceName: "frontend"}, expected: "service_name"},
+		{query: TraceQuery{OperationName: "GET /"}, expected: "operation_name"},
+		{query: TraceQuery{Attributes: map[string]string{"k": "v"}}, expected: "attributes"},
+		{query: TraceQuery{DurationMin: time.Second}, expected: "duration_min"},
+		{query: TraceQuery{DurationMax: time.Second}, expected: "duration_max"},
+	}
+	for _, test := range tests {
+		test.query.Filter = filter
+		_, err := test.query.Proto()
+		assert.EqualError(t, err,
+			"invalid query: filter is mutually exclusive with the legacy predicates, and the query also sets "+test.expected)
+	}
+}
+
+func TestClient_FindTraces_StopEarly(t *testing.T) {
+	service := &fakeService{chunks: []*tracev1.TracesData{traceChunk(1, "a1"), traceChunk(2, "b1"), traceChunk(3, "c1")}}
+	client := serve(t, service)
+	var names []string
+	for trace, err := range client.FindTraces(context.Background(), TraceQuery{StartTimeMin: base, StartTimeMax: base}) {
+		require.NoError(t, err)
+		names = append(names, spanNames([]*tracev1.TracesData{trace})[0]...)
+		break
+	}
+	assert.Equal(t, []string{"a1"}, names)
+}
+
+func TestClient_FindTraces_Errors(t *testing.T) {
+	t.Run("invalid filter", func(t *testing.T) {
+		service := &fakeService{}
+		client := serve(t, service)
+		query := TraceQuery{Filter: &expression.Call{Op: expression.OpEq}}
+		_, err := collect(t, client.FindTraces(context.Background(), query))
+		require.ErrorContains(t, err, "invalid filter")
+		assert.Nil(t, service.findTraces, "an invalid filter is refused before the call")
+	})
+	t.Run("filter with a legacy predicate", func(t *testing.T) {
+		service := &fakeService{}
+		client := serve(t, service)
+		query := TraceQuery{ServiceName: "frontend", Filter: &expression.Call{Op: expression.OpExists, Args: []expression.Expression{
+			&expression.AttributeRef{Key: "k"},
+		}}}
+		_, err := collect(t, client.FindTraces(context.Background(), query))
+		require.ErrorContains(t, err, "mutually exclusive")
+		assert.Nil(t, service.findTraces, "the query is refused before the call")
+	})
+	t.Run("error mid-stream", func(t *testing.T) {
+		service := &fakeService{
+			chunks: []*tracev1.TracesData{traceChunk(1, "a1"), traceChunk(2, "b1")},
+			err:    status.Error(codes.Unavailable, "storage is down"),
+		}
+		client := serve(t, service)
+		traces, err := collect(t, client.FindTraces(context.Background(), TraceQuery{}))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, [][]string{{"a1"}}, spanNames(traces), "the interrupted trace is dropped")
+	})
+}
+
+func TestClient_FindTraceSummaries(t *testing.T) {
+	service := &fakeService{summaries: []*api_v3.FindTraceSummariesResponse{
+		{Summaries: []*api_v3.TraceSummary{{TraceId: "01"}, {TraceId: "02"}}},
+		{},
+		{Summaries: []*api_v3.TraceSummary{{TraceId: "03"}}},
+	}}
+	client := serve(t, service)
+
+	summaries, err := collect(t, client.FindTraceSummaries(context.Background(), TraceQuery{ServiceName: "frontend"}))
+	require.NoError(t, err)
+	var ids []string
+	for _, summary := range summaries {
+		ids = append(ids, summary.GetTraceId())
+	}
+	assert.Equal(t, []string{"01", "02", "03"}, ids)
+	assert.Equal(t, "frontend", service.findSummary.GetQuery().GetServiceName())
+
+	service.err = status.Error(codes.Unimplemented, "no summaries")
+	service.summaries = nil
+	_, err = collect(t, client.FindTraceSummaries(context.Background(), TraceQuery{}))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestClient_Unary(t *testing.T) {
+	service := &fakeService{}
+	client := serve(t, service)
+	ctx := context.Background()
+
+	services, err := client.GetServices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"driver", "frontend"}, services)
+
+	operations, err := client.GetOperations(ctx, "frontend", "server")
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "GET /", operations[0].GetName())
+	assert.Equal(t, "server", service.operations.GetSpanKind())
+
+	dependencies, err := client.GetDependencies(ctx, base, base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	assert.Equal(t, uint64(2), dependencies[0].GetCallCount())
+	assert.True(t, proto.Equal(timestamppb.New(base), service.dependencies.GetStartTime()))
+
+	service.err = status.Error(codes.Internal, "boom")
+	_, err = client.GetServices(ctx)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	_, err = client.GetOperations(ctx, "frontend", "")
+	assert.Equal(t, codes.Internal, status.Code(err))
+	_, err = client.GetDependencies(ctx, base, base)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestTraces(t *testing.T) {
+	chunks := []*tracev1.TracesData{traceChunk(1, "a1"), traceChunk(1, "a2")}
+	recv := func() (*tracev1.TracesData, error) {
+		if len(chunks) == 0 {
+			return nil, io.EOF
+		}
+		next := chunks[0]
+		chunks = chunks[1:]
+		return next, nil
+	}
+	traces, err := collect(t, Traces(recv))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1", "a2"}}, spanNames(traces))
+
+	failed := errors.New("broken pipe")
+	_, err = collect(t, Traces(func() (*tracev1.TracesData, error) { return nil, failed }))
+	require.ErrorIs(t, err, failed)
+
+	traces, err = collect(t, Traces(func() (*tracev1.TracesData, error) { return nil, io.EOF }))
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+}
+
+func TestParseTraceID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+		err      string
+	}{
+		{name: "128-bit", id: "0102030405060708090A0B0C0D0E0F10", expected: "0102030405060708090a0b0c0d0e0f10"},
+		{name: "64-bit", id: "1234567890abcdef", expected: "00000000000000001234567890abcdef"},
+		{name: "odd length", id: "abc", expected: "00000000000000000000000000000abc"},
+		{name: "empty", id: "", err: `invalid trace ID "": a trace ID has 1 to 32 hex digits`},
+		{name: "too long", id: "0102030405060708090a0b0c0d0e0f1011", err: "a trace ID has 1 to 32 hex digits"},
+		{name: "not hex", id: "xyz", err: `invalid trace ID "xyz": encoding/hex: invalid byte`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, err := ParseTraceID(test.id)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, id, traceIDSize)
+			assert.Equal(t, test.expected, FormatTraceID(id))
+		})
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apiv3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	api_v3 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v3"
+	"github.com/jaegertracing/jaeger-idl/query/expression/v1/filterparam"
+)
+
+// maxErrorBody is the most of a response that is not JSON an error quotes.
+const maxErrorBody = 1 << 10
+
+// HTTPClient calls the api_v3 QueryService through the HTTP/JSON endpoints that
+// swagger/api_v3/query_service.openapi.yaml describes, where gRPC to the query service is not
+// allowed. It has the methods of Client and returns the same messages; an error the server reports
+// is a *GatewayError, which carries a gRPC code as an error of Client does, so that status.Code
+// reads either alike.
+type HTTPClient struct {
+	baseURL *url.URL
+	client  *http.Client
+	post    bool
+}
+
+// HTTPOption adjusts how an HTTPClient calls the server.
+type HTTPOption func(*HTTPClient)
+
+// WithHTTPClient sends requests with the given client rather than http.DefaultClient, for a
+// transport, a timeout or credentials of the caller's.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(c *HTTPClient) {
+		c.client = client
+	}
+}
+
+// WithPostSearches sends FindTraces and FindTraceSummaries as POST requests, with the query as a
+// JSON body, rather than as GET requests with the query in the query string. The two mean the same;
+// a query too long for a URL, as a large filter may make one, needs a body.
+func WithPostSearches() HTTPOption {
+	return func(c *HTTPClient) {
+		c.post = true
+	}
+}
+
+// NewHTTPClient returns a client of the server at baseURL, the address the query service serves
+// /api/v3 under, which may have a path of its own when a proxy serves it under one.
+func NewHTTPClient(baseURL string, opts ...HTTPOption) (*HTTPClient, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: the scheme has to be http or https", baseURL)
+	}
+	c := &HTTPClient{baseURL: u, client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// GatewayError is an error the server answered a call with. The server writes it as a
+// GRPCGatewayError, in place of a response or of a chunk of a streamed one, or as the
+// google.rpc.Status the OpenAPI document gives as the default response; a response that is
+// neither, such as the page of a proxy in front of the server, is read as one whose message is its
+// body.
+type GatewayError struct {
+	// GRPCCode is the gRPC code of the error. A server that reports only an HTTP status has it read
+	// from the status, as the reverse of the status grpc-gateway answers a code with.
+	GRPCCode codes.Code
+	// HTTPCode is the HTTP status of the error, which is that of the response unless the server
+	// wrote one of its own.
+	HTTPCode int
+	Message  string
+}
+
+func (e *GatewayError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d): %s", e.GRPCCode, e.HTTPCode, e.Message)
+}
+
+// GRPCStatus returns the status of the error, which is what status.Code and status.FromError read.
+func (e *GatewayError) GRPCStatus() *status.Status {
+	return status.New(e.GRPCCode, e.Message)
+}
+
+// GetTrace returns an iterator over the trace with the given ID, as Client.GetTrace does, read from
+// /api/v3/traces/{traceId}.
+func (c *HTTPClient) GetTrace(ctx context.Context, traceID string, options GetTraceOptions) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		id, err := ParseTraceID(traceID)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		values := url.Values{}
+		setTime(values, "startTime", options.StartTime)
+		setTime(values, "endTime", options.EndTime)
+		if options.RawTraces {
+			values.Set("rawTraces", "true")
+		}
+		c.traces(ctx, http.MethodGet, []string{"traces", FormatTraceID(id)}, values, nil)(yield)
+	}
+}
+
+// FindTraces returns an iterator over the traces a search finds, as Client.FindTraces does, read
+// from /api/v3/traces.
+func (c *HTTPClient) FindTraces(ctx context.Context, query TraceQuery) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		method, values, body, err := c.search(query, func(params *api_v3.TraceQueryParameters) proto.Message {
+			return &api_v3.FindTracesRequest{Query: params}
+		})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		c.traces(ctx, method, []string{"traces"}, values, body)(yield)
+	}
+}
+
+// FindTraceSummaries returns an iterator over the summaries of the traces a search finds, as
+// Client.FindTraceSummaries does, read from /api/v3/trace-summaries.
+func (c *HTTPClient) FindTraceSummaries(ctx context.Context, query TraceQuery) iter.Seq2[*api_v3.TraceSummary, error] {
+	return func(yield func(*api_v3.TraceSummary, error) bool) {
+		method, values, body, err := c.search(query, func(params *api_v3.TraceQueryParameters) proto.Message {
+			return &api_v3.FindTraceSummariesRequest{Query: params}
+		})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		response, err := c.do(ctx, method, []string{"trace-summaries"}, values, body)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer response.Body.Close()
+		recv := results(response)
+		for {
+			chunk := &api_v3.FindTraceSummariesResponse{}
+			if err := recv(chunk); errors.Is(err, io.EOF) {
+				return
+			} else if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, summary := range chunk.GetSummaries() {
+				if !yield(summary, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetServices returns the names of the services the server knows of, read from /api/v3/services.
+func (c *HTTPClient) GetServices(ctx context.Context) ([]string, error) {
+	response := &api_v3.GetServicesResponse{}
+	if err := c.get(ctx, []string{"services"}, nil, response); err != nil {
+		return nil, err
+	}
+	return response.GetServices(), nil
+}
+
+// GetOperations returns the operations of a service, of the given span kind when it is not empty,
+// read from /api/v3/operations.
+func (c *HTTPClient) GetOperations(ctx context.Context, service, spanKind string) ([]*api_v3.Operation, error) {
+	values := url.Values{"service": {service}}
+	if spanKind != "" {
+		values.Set("spanKind", spanKind)
+	}
+	response := &api_v3.GetOperationsResponse{}
+	if err := c.get(ctx, []string{"operations"}, values, response); err != nil {
+		return nil, err
+	}
+	return response.GetOperations(), nil
+}
+
+// GetDependencies returns the links between services seen from start to end, read from
+// /api/v3/dependencies.
+func (c *HTTPClient) GetDependencies(ctx context.Context, start, end time.Time) ([]*api_v3.Dependency, error) {
+	values := url.Values{}
+	setTime(values, "startTime", start)
+	setTime(values, "endTime", end)
+	response := &api_v3.DependenciesResponse{}
+	if err := c.get(ctx, []string{"dependencies"}, values, response); err != nil {
+		return nil, err
+	}
+	return response.GetDependencies(), nil
+}
+
+// search returns the request of a search in the form the client sends it in: a POST body holding
+// the request message wrap builds, or GET parameters. Either way the query is checked first, so a
+// filter that does not validate fails before any call is made.
+//
+// The GET parameters are those the OpenAPI document lists, with the filter in the single
+// query.filter parameter as package filterparam encodes it. The document has no parameter for
+// attributes, a map that a field path cannot expand, and they are sent as query.filter is, as
+// query.attributes holding a JSON object.
+func (c *HTTPClient) search(query TraceQuery, wrap func(*api_v3.TraceQueryParameters) proto.Message) (string, url.Values, proto.Message, error) {
+	params, err := query.Proto()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if c.post {
+		return http.MethodPost, nil, wrap(params), nil
+	}
+	values := url.Values{}
+	setString(values, "query.serviceName", query.ServiceName)
+	setString(values, "query.operationName", query.OperationName)
+	if len(query.Attributes) > 0 {
+		attributes, err := json.Marshal(query.Attributes)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		values.Set("query.attributes", string(attributes))
+	}
+	setTime(values, "query.startTimeMin", query.StartTimeMin)
+	setTime(values, "query.startTimeMax", query.StartTimeMax)
+	setDuration(values, "query.durationMin", query.DurationMin)
+	setDuration(values, "query.durationMax", query.DurationMax)
+	if query.SearchDepth != 0 {
+		values.Set("query.searchDepth", strconv.Itoa(int(query.SearchDepth)))
+	}
+	if query.RawTraces {
+		values.Set("query.rawTraces", "true")
+	}
+	if err := filterparam.Set(values, query.Filter); err != nil {
+		return "", nil, nil, err
+	}
+	return http.MethodGet, values, nil, nil
+}
+
+// traces returns an iterator over the traces a response streams, joined from its chunks as Traces
+// joins them.
+func (c *HTTPClient) traces(ctx context.Context, method string, path []string, values url.Values, body proto.Message) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		response, err := c.do(ctx, method, path, values, body)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer response.Body.Close()
+		recv := results(response)
+		Traces(func() (*tracev1.TracesData, error) {
+			chunk := &tracev1.TracesData{}
+			if err := recv(chunk); err != nil {
+				return nil, err
+			}
+			return chunk, nil
+		})(yield)
+	}
+}
+
+// get calls an endpoint that answers with one message.
+func (c *HTTPClient) get(ctx context.Context, path []string, values url.Values, message proto.Message) error {
+	response, err := c.do(ctx, http.MethodGet, path, values, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if err := results(response)(message); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("the response to %s is empty", response.Request.URL.Path)
+		}
+		return err
+	}
+	return nil
+}
+
+// do sends a request to the endpoint under /api/v3 at path, with values as its query string and
+// body, when not nil, as its JSON body. A response whose status is not a success is read as the
+// error it reports, and closed.
+func (c *HTTPClient) do(ctx context.Context, method string, path []string, values url.Values, body proto.Message) (*http.Response, error) {
+	endpoint := c.baseURL.JoinPath(append([]string{"api", "v3"}, path...)...)
+	endpoint.RawQuery = values.Encode()
+	var reader io.Reader
+	if body != nil {
+		data, err := protojson.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		return nil, responseError(response)
+	}
+	return response, nil
+}
+
+// unmarshalOptions reads what the server writes, which may hold fields added after this client was
+// built.
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// results returns a function that reads the JSON values of a response into a message one at a
+// time, and returns io.EOF after the last. A value is the message itself, or a GRPCGatewayWrapper
+// holding it in result, which is how the server writes a streamed message; an error written in
+// place of one is returned as a *GatewayError.
+func results(response *http.Response) func(proto.Message) error {
+	decoder := json.NewDecoder(response.Body)
+	return func(message proto.Message) error {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.EOF
+			}
+			return fmt.Errorf("cannot read the response: %w", err)
+		}
+		var envelope struct {
+			Result json.RawMessage `json:"result"`
+			Error  json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal(value, &envelope); err != nil {
+			return fmt.Errorf("cannot read the response: %w", err)
+		}
+		if envelope.Error != nil {
+			return gatewayError(response.StatusCode, value)
+		}
+		if envelope.Result != nil {
+			value = envelope.Result
+		}
+		if err := unmarshalOptions.Unmarshal(value, message); err != nil {
+			return fmt.Errorf("cannot read the response: %w", err)
+		}
+		return nil
+	}
+}
+
+// responseError reads the error a response whose status is not a success reports.
+func responseError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+	var rpcStatus struct {
+		Code    *int32 `json:"code"`
+		Message string `json:"message"`
+		Error   any    `json:"error"`
+	}
+	switch {
+	case json.Unmarshal(body, &rpcStatus) != nil:
+	case rpcStatus.Error != nil:
+		return gatewayError(response.StatusCode, body)
+	case rpcStatus.Code != nil:
+		return &GatewayError{GRPCCode: codes.Code(*rpcStatus.Code), HTTPCode: response.StatusCode, Message: rpcStatus.Message}
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = response.Status
+	}
+	return &GatewayError{GRPCCode: codeOf(response.StatusCode), HTTPCode: response.StatusCode, Message: message}
+}
+
+// gatewayError reads a GRPCGatewayError the server wrote in a response of the given status.
+func gatewayError(statusCode int, value []byte) error {
+	wrapped := &api_v3.GRPCGatewayError{}
+	if err := unmarshalOptions.Unmarshal(value, wrapped); err != nil {
+		return fmt.Errorf("cannot read the error in the response: %w", err)
+	}
+	details := wrapped.GetError()
+	e := &GatewayError{
+		GRPCCode: codes.Code(details.GetGrpcCode()),
+		HTTPCode: int(details.GetHttpCode()),
+		Message:  details.GetMessage(),
+	}
+	if e.HTTPCode == 0 {
+		e.HTTPCode = statusCode
+	}
+	if e.GRPCCode == codes.OK {
+		e.GRPCCode = codeOf(e.HTTPCode)
+	}
+	return e
+}
+
+// codeOf returns the gRPC code of an HTTP status, the reverse of the status grpc-gateway answers a
+// code with. Where several codes share a status the one a caller most likely acts on is taken, 500
+// as Internal and 409 as Aborted; 502, which no code has but a proxy answers for a server it cannot
+// reach, is Unavailable; any other status is Unknown.
+func codeOf(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case 499:
+		return codes.Canceled
+	case http.StatusInternalServerError:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+func setString(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+// setTime sets a time in the RFC 3339 form the OpenAPI document gives, unless it is zero.
+func setTime(values url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		values.Set(key, t.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+// setDuration sets a duration in the proto3 JSON form, seconds with a fraction and an "s", which
+// the pattern of the OpenAPI document takes, and which Go's time.ParseDuration reads too, unless it
+// is zero.
+func setDuration(values url.Values, key string, d time.Duration) {
+	if d == 0 {
+		return
+	}
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	seconds := strconv.FormatInt(int64(d/time.Second), 10)
+	if nanos := d % time.Second; nanos != 0 {
+		seconds += "." + strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+	}
+	values.Set(key, sign+seconds+"s")
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apiv3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	api_v3 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v3"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// fakeGateway answers every request with a status and a body, and keeps the last request.
+type fakeGateway struct {
+	status int
+	body   string
+
+	method string
+	path   string
+	query  url.Values
+	sent   []byte
+}
+
+// serveHTTP serves a fake gateway under a path prefix, as a proxy may, and returns a client of it.
+func serveHTTP(t *testing.T, gateway *fakeGateway, opts ...HTTPOption) *HTTPClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gateway.method, gateway.path, gateway.query = r.Method, r.URL.Path, r.URL.Query()
+		gateway.sent, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(gateway.status)
+		io.WriteString(w, gateway.body)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewHTTPClient(server.URL+"/jaeger", append([]HTTPOption{WithHTTPClient(server.Client())}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
+
+// wrapped writes chunks as the server streams them: each in a GRPCGatewayWrapper, one after another.
+func wrapped(t *testing.T, chunks ...*tracev1.TracesData) string {
+	var body string
+	for _, chunk := range chunks {
+		data, err := protojson.Marshal(&api_v3.GRPCGatewayWrapper{Result: chunk})
+		require.NoError(t, err)
+		body += string(data) + "\n"
+	}
+	return body
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	_, err := NewHTTPClient("localhost:16686")
+	require.ErrorContains(t, err, "the scheme has to be http or https")
+	_, err = NewHTTPClient("http://[::1")
+	require.ErrorContains(t, err, "invalid base URL")
+}
+
+func TestHTTPClient_GetTrace(t *testing.T) {
+	gateway := &fakeGateway{status: http.StatusOK}
+	client := serveHTTP(t, gateway)
+	gateway.body = wrapped(t, traceChunk(7, "root"), traceChunk(7, "child"))
+
+	traces, err := collect(t, client.GetTrace(context.Background(), "7", GetTraceOptions{StartTime: base, RawTraces: true}))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"root", "child"}}, spanNames(traces), "the chunks of the trace are joined")
+	assert.Equal(t, http.MethodGet, gateway.method)
+	assert.Equal(t, "/jaeger/api/v3/traces/00000000000000000000000000000007", gateway.path)
+	assert.Equal(t, url.Values{"startTime": {"2026-03-01T12:00:00Z"}, "rawTraces": {"true"}}, gateway.query)
+}
+
+func TestHTTPClient_FindTraces(t *testing.T) {
+	filter := &expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+		&expression.AttributeRef{Key: "http.status_code"},
+		&expression.AnyValue{Value: "500"},
+	}}
+	query := TraceQuery{
+		ServiceName:  "frontend",
+		Attributes:   map[string]string{"http.method": "GET"},
+		StartTimeMin: base,
+		StartTimeMax: base.Add(time.Hour),
+		DurationMin:  1500 * time.Millisecond,
+		DurationMax:  2 * time.Second,
+		SearchDepth:  20,
+	}
+	filtered := TraceQuery{StartTimeMin: base, StartTimeMax: base.Add(time.Hour), SearchDepth: 20, Filter: filter}
+
+	t.Run("GET", func(t *testing.T) {
+		gateway := &fakeGateway{status: http.StatusOK}
+		client := serveHTTP(t, gateway)
+		gateway.body = wrapped(t, traceChunk(1, "a1"), traceChunk(1, "a2"), traceChunk(2, "b1"))
+
+		traces, err := collect(t, client.FindTraces(context.Background(), query))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a1", "a2"}, {"b1"}}, spanNames(traces))
+		assert.Equal(t, http.MethodGet, gateway.method)
+		assert.Equal(t, "/jaeger/api/v3/traces", gateway.path)
+		assert.Equal(t, url.Values{
+			"query.serviceName":  {"frontend"},
+			"query.attributes":   {`{"http.method":"GET"}`},
+			"query.startTimeMin": {"2026-03-01T12:00:00Z"},
+			"query.startTimeMax": {"2026-03-01T13:00:00Z"},
+			"query.durationMin":  {"1.5s"},
+			"query.durationMax":  {"2s"},
+			"query.searchDepth":  {"20"},
+		}, gateway.query)
+	})
+	t.Run("GET filter", func(t *testing.T) {
+		gateway := &fakeGateway{status: http.StatusOK}
+		client := serveHTTP(t, gateway)
+		gateway.body = wrapped(t, traceChunk(1, "a1"))
+
+		_, err := collect(t, client.FindTraces(context.Background(), filtered))
+		require.NoError(t, err)
+		assert.Equal(t, url.Values{
+			"query.startTimeMin": {"2026-03-01T12:00:00Z"},
+			"query.startTimeMax": {"2026-03-01T13:00:00Z"},
+			"query.searchDepth":  {"20"},
+			"query.filter":       {`{"op":"eq","args":[{"attr":{"key":"http.status_code"}},{"scalar":{"value":"500"}}]}`},
+		}, gateway.query)
+	})
+	t.Run("POST", func(t *testing.T) {
+		gateway := &fakeGateway{status: http.StatusOK}
+		client := serveHTTP(t, gateway, WithPostSearches())
+		gateway.body = wrapped(t, traceChunk(1, "a1"))
+
+		traces, err := collect(t, client.FindTraces(context.Background(), filtered))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a1"}}, spanNames(traces))
+		assert.Equal(t, http.MethodPost, gateway.method)
+		assert.Empty(t, gateway.query)
+		sent := &api_v3.FindTracesRequest{}
+		require.NoError(t, protojson.Unmarshal(gateway.sent, sent))
+		params, err := filtered.Proto()
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(params, sent.GetQuery()), "got %v", sent)
+	})
+	t.Run("bare TracesData", func(t *testing.T) {
+		data, err := protojson.Marshal(traceChunk(1, "a1"))
+		require.NoError(t, err)
+		client := serveHTTP(t, &fakeGateway{status: http.StatusOK, body: string(data)})
+		traces, err := collect(t, client.FindTraces(context.Background(), TraceQuery{}))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a1"}}, spanNames(traces))
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		gateway := &fakeGateway{status: http.StatusOK}
+		client := serveHTTP(t, gateway)
+		_, err := collect(t, client.FindTraces(context.Background(), TraceQuery{Filter: &expression.Call{Op: expression.OpEq}}))
+		require.ErrorContains(t, err, "invalid filter")
+		assert.Empty(t, gateway.method, "an invalid filter is refused before the call")
+	})
+	t.Run("filter with a legacy predicate", func(t *testing.T) {
+		gateway := &fakeGateway{status: http.StatusOK}
+		client := serveHTTP(t, gateway)
+		_, err := collect(t, client.FindTraces(context.Background(), TraceQuery{DurationMin: time.Second, Filter: filter}))
+		require.ErrorContains(t, err, "mutually exclusive")
+		assert.Empty(t, gateway.method, "the query is refused before the call")
+	})
+}
+
+func TestHTTPClient_FindTraceSummaries(t *testing.T) {
+	gateway := &fakeGateway{
+		status: http.StatusOK,
+		body:   `{"summaries":[{"traceId":"01"},{"traceId":"02"}]}{"result":{"summaries":[{"traceId":"03","futureField":1}]}}`,
+	}
+	client := serveHTTP(t, gateway)
+	summaries, err := collect(t, client.FindTraceSummaries(context.Background(), TraceQuery{ServiceName: "frontend"}))
+	require.NoError(t, err)
+	var ids []string
+	for _, summary := range summaries {
+		ids = append(ids, summary.GetTraceId())
+	}
+	assert.Equal(t, []string{"01", "02", "03"}, ids)
+	assert.Equal(t, "/jaeger/api/v3/trace-summaries", gateway.path)
+	assert.Equal(t, url.Values{"query.serviceName": {"frontend"}}, gateway.query)
+}
+
+func TestHTTPClient_Unary(t *testing.T) {
+	ctx := context.Background()
+	gateway := &fakeGateway{status: http.StatusOK}
+	client := serveHTTP(t, gateway)
+
+	gateway.body = `{"services":["driver","frontend"]}`
+	services, err := client.GetServices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"driver", "frontend"}, services)
+	assert.Equal(t, "/jaeger/api/v3/services", gateway.path)
+
+	gateway.body = `{"operations":[{"name":"GET /","spanKind":"server"}]}`
+	operations, err := client.GetOperations(ctx, "frontend", "server")
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "GET /", operations[0].GetName())
+	assert.Equal(t, url.Values{"service": {"frontend"}, "spanKind": {"server"}}, gateway.query)
+
+	gateway.body = `{"dependencies":[{"parent":"frontend","child":"driver","callCount":"2"}]}`
+	dependencies, err := client.GetDependencies(ctx, base, base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	assert.Equal(t, uint64(2), dependencies[0].GetCallCount())
+	assert.Equal(t, url.Values{"startTime": {"2026-03-01T12:00:00Z"}, "endTime": {"2026-03-01T13:00:00Z"}}, gateway.query)
+
+	gateway.body = ""
+	_, err = client.GetServices(ctx)
+	require.ErrorContains(t, err, "the response to /jaeger/api/v3/services is empty")
+
+	gateway.body = "not json"
+	_, err = client.GetServices(ctx)
+	require.ErrorContains(t, err, "cannot read the response")
+}
+
+func TestHTTPClient_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected *GatewayError
+	}{
+		{
+			name:     "GRPCGatewayError",
+			status:   http.StatusNotFound,
+			body:     `{"error":{"grpcCode":5,"httpCode":404,"message":"trace not found","httpStatus":"Not Found"}}`,
+			expected: &GatewayError{GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Message: "trace not found"},
+		},
+		{
+			name:     "GRPCGatewayError without a gRPC code",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"httpCode":400,"message":"malformed parameter"}}`,
+			expected: &GatewayError{GRPCCode: codes.InvalidArgument, HTTPCode: http.StatusBadRequest, Message: "malformed parameter"},
+		},
+		{
+			name:     "google.rpc.Status",
+			status:   http.StatusServiceUnavailable,
+			body:     `{"code":14,"message":"storage is down","details":[]}`,
+			expected: &GatewayError{GRPCCode: codes.Unavailable, HTTPCode: http.StatusServiceUnavailable, Message: "storage is down"},
+		},
+		{
+			name:     "a proxy's page",
+			status:   http.StatusBadGateway,
+			body:     "<html>Bad Gateway</html>\n",
+			expected: &GatewayError{GRPCCode: codes.Unavailable, HTTPCode: http.StatusBadGateway, Message: "<html>Bad Gateway</html>"},
+		},
+		{
+			name:     "no body",
+			status:   http.StatusTeapot,
+			expected: &GatewayError{GRPCCode: codes.Unknown, HTTPCode: http.StatusTeapot, Message: "418 I'm a teapot"},
+		},
+		{
+			name:     "an error mid-stream",
+			status:   http.StatusOK,
+			body:     `{"result":{}}{"error":{"grpcCode":13,"message":"storage failed"}}`,
+			expected: &GatewayError{GRPCCode: codes.Internal, HTTPCode: http.StatusOK, Message: "storage failed"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := serveHTTP(t, &fakeGateway{status: test.status, body: test.body})
+			_, err := collect(t, client.GetTrace(context.Background(), "1", GetTraceOptions{}))
+			var gatewayErr *GatewayError
+			require.ErrorAs(t, err, &gatewayErr)
+			assert.Equal(t, test.expected, gatewayErr)
+			assert.Equal(t, test.expected.GRPCCode, status.Code(err), "status.Code reads the gRPC code")
+		})
+	}
+}
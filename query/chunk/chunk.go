@@ -0,0 +1,111 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chunk splits the traces a query streams into messages under a size limit, and joins the
+// messages back into traces. gRPC refuses a message larger than its receiver accepts, 4MB unless it
+// is configured otherwise, so a trace too large for one message has to be streamed in several: the
+// SpansResponseChunk of the api_v2 QueryService's GetTrace and FindTraces, and the TracesData of
+// api_v3's.
+//
+// SplitSpans and SplitTraces give the chunks a server sends. A chunk holds spans of one trace only,
+// and never part of a span, and every span in it keeps its process, or its resource and scope, so
+// that a chunk can be read on its own. A span too large for a chunk of its own is an error rather
+// than a message gRPC would refuse. JoinSpans and JoinTraces are what a client reads them with: they
+// join the chunks of one trace that arrive one after another back into the whole trace.
+package chunk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// MaxMessageSize is the size of the largest message a gRPC receiver accepts by default, and the
+// limit a chunk is kept under when none is given.
+const MaxMessageSize = 4 << 20
+
+// SplitSpans returns an iterator that yields the spans in chunks whose encoded size is at most
+// maxSize bytes, or MaxMessageSize when maxSize is not positive. The spans are yielded in the
+// order given, and a chunk ends where the trace ID changes, so the spans of one trace have to be
+// given together to arrive together. Each span carries its own process, as a SpansResponseChunk
+// has it; a span whose Process is nil is sent without one.
+//
+// A chunk's Spans is a part of spans, which is not copied. A span that does not fit a chunk on its
+// own is yielded as an error, after the chunks before it, and ends the iteration.
+func SplitSpans(spans []model.Span, maxSize int) iter.Seq2[*api_v2.SpansResponseChunk, error] {
+	if maxSize <= 0 {
+		maxSize = MaxMessageSize
+	}
+	return func(yield func(*api_v2.SpansResponseChunk, error) bool) {
+		start, size := 0, 0
+		for i := range spans {
+			spanSize := field(spans[i].Size())
+			if i > start && (spans[i].TraceID != spans[start].TraceID || size+spanSize > maxSize) {
+				if !yield(&api_v2.SpansResponseChunk{Spans: spans[start:i:i]}, nil) {
+					return
+				}
+				start, size = i, 0
+			}
+			if spanSize > maxSize {
+				yield(nil, fmt.Errorf("span %s of trace %s is %d bytes, more than a chunk of %d bytes holds",
+					spans[i].SpanID, spans[i].TraceID, spanSize, maxSize))
+				return
+			}
+			size += spanSize
+		}
+		if start < len(spans) {
+			yield(&api_v2.SpansResponseChunk{Spans: spans[start:len(spans):len(spans)]}, nil)
+		}
+	}
+}
+
+// JoinSpans returns an iterator that reads chunks with recv until it returns io.EOF, and yields
+// each trace whole, its spans in the order they arrived. A server streams the spans of one trace
+// one after another, so a span of another trace ends the trace before it, whether or not it shares
+// its chunk. The spans carry their processes, and the ProcessMap of the trace is left empty.
+//
+// An error of recv drops the trace it interrupted, which may be missing spans, and is yielded on
+// its own.
+func JoinSpans(recv func() (*api_v2.SpansResponseChunk, error)) iter.Seq2[*model.Trace, error] {
+	return func(yield func(*model.Trace, error) bool) {
+		var current *model.Trace
+		for {
+			chunk, err := recv()
+			if errors.Is(err, io.EOF) {
+				if current != nil {
+					yield(current, nil)
+				}
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for i := range chunk.Spans {
+				span := &chunk.Spans[i]
+				if current != nil && current.Spans[0].TraceID != span.TraceID {
+					if !yield(current, nil) {
+						return
+					}
+					current = nil
+				}
+				if current == nil {
+					current = &model.Trace{}
+				}
+				current.Spans = append(current.Spans, span)
+			}
+		}
+	}
+}
+
+// field returns the size of a length-delimited field of a message, of a field number below 16,
+// whose value is size bytes.
+func field(size int) int {
+	return 1 + protowire.SizeVarint(uint64(size)) + size
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package chunk
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// modelSpans returns count spans of each trace, in order, whose sizes differ, each with a process.
+func modelSpans(count int, traces ...uint64) []model.Span {
+	var spans []model.Span
+	for _, trace := range traces {
+		for i := range count {
+			spans = append(spans, model.Span{
+				TraceID:       model.NewTraceID(0, trace),
+				SpanID:        model.SpanID(i + 1),
+				OperationName: strings.Repeat("x", 10*(i%4)),
+				Process:       model.NewProcess("service", []model.KeyValue{model.String("host.name", "web-1")}),
+			})
+		}
+	}
+	return spans
+}
+
+// recvFrom returns a function that receives the given chunks, and then io.EOF.
+func recvFrom[T any](chunks ...T) func() (T, error) {
+	return func() (T, error) {
+		var zero T
+		if len(chunks) == 0 {
+			return zero, io.EOF
+		}
+		next := chunks[0]
+		chunks = chunks[1:]
+		return next, nil
+	}
+}
+
+func TestSplitSpans(t *testing.T) {
+	spans := modelSpans(20, 1, 2)
+	const maxSize = 600
+	var chunks []*api_v2.SpansResponseChunk
+	for chunk, err := range SplitSpans(spans, maxSize) {
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+
+	var joined []model.Span
+	for i, chunk := range chunks {
+		assert.LessOrEqual(t, chunk.Size(), maxSize)
+		for _, span := range chunk.Spans {
+			assert.Equal(t, chunk.Spans[0].TraceID, span.TraceID, "chunk %d holds one trace", i)
+			assert.NotNil(t, span.Process, "a span keeps its process")
+		}
+		if i+1 < len(chunks) && chunks[i+1].Spans[0].TraceID == chunk.Spans[0].TraceID {
+			next := chunks[i+1].Spans[0]
+			assert.Greater(t, chunk.Size()+field(next.Size()), maxSize, "chunk %d is as full as it can be", i)
+		}
+		joined = append(joined, chunk.Spans...)
+	}
+	assert.Equal(t, spans, joined, "in order, with nothing lost or split")
+	assert.Greater(t, len(chunks), 2)
+}
+
+func TestSplitSpans_DefaultSize(t *testing.T) {
+	var sizes []int
+	for chunk, err := range SplitSpans(modelSpans(1000, 1, 2), 0) {
+		require.NoError(t, err)
+		sizes = append(sizes, len(chunk.Spans))
+	}
+	assert.Equal(t, []int{1000, 1000}, sizes, "a chunk for each trace, which MaxMessageSize holds whole")
+}
+
+func TestSplitSpans_SpanTooLarge(t *testing.T) {
+	spans := modelSpans(3, 1)
+	spans[2].OperationName = strings.Repeat("x", 1000)
+	var chunks int
+	var errs []error
+	for chunk, err := range SplitSpans(spans, 200) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		chunks++
+		assert.Len(t, chunk.Spans, 2)
+	}
+	assert.Equal(t, 1, chunks, "the chunks before the span are yielded")
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "span 0000000000000003 of trace 0000000000000001")
+}
+
+func TestSplitSpans_Stop(t *testing.T) {
+	var chunks int
+	for range SplitSpans(modelSpans(20, 1), 200) {
+		chunks++
+		break
+	}
+	assert.Equal(t, 1, chunks)
+}
+
+func TestJoinSpans(t *testing.T) {
+	spans := modelSpans(20, 1, 2, 3)
+	var chunks []*api_v2.SpansResponseChunk
+	for chunk, err := range SplitSpans(spans, 500) {
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	// A server that does not end a chunk with a trace: the last trace's spans share one.
+	chunks = append(chunks, &api_v2.SpansResponseChunk{Spans: modelSpans(2, 4, 5, 6)})
+
+	var traces []*model.Trace
+	for trace, err := range JoinSpans(recvFrom(chunks...)) {
+		require.NoError(t, err)
+		traces = append(traces, trace)
+	}
+	require.Len(t, traces, 6)
+	for i, trace := range traces {
+		expected := 20
+		if i >= 3 {
+			expected = 2
+		}
+		assert.Len(t, trace.Spans, expected)
+		for _, span := range trace.Spans {
+			assert.Equal(t, model.NewTraceID(0, uint64(i+1)), span.TraceID)
+		}
+	}
+	assert.Equal(t, spans[20], *traces[1].Spans[0])
+}
+
+func TestJoinSpans_Error(t *testing.T) {
+	failure := errors.New("connection reset")
+	chunks := []*api_v2.SpansResponseChunk{{Spans: modelSpans(2, 1)}, {Spans: modelSpans(2, 2)}}
+	recv := func() (*api_v2.SpansResponseChunk, error) {
+		if len(chunks) == 0 {
+			return nil, failure
+		}
+		next := chunks[0]
+		chunks = chunks[1:]
+		return next, nil
+	}
+	var traces []*model.Trace
+	var errs []error
+	for trace, err := range JoinSpans(recv) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		traces = append(traces, trace)
+	}
+	require.Len(t, traces, 1, "the interrupted trace is dropped")
+	assert.Equal(t, model.NewTraceID(0, 1), traces[0].Spans[0].TraceID)
+	assert.Equal(t, []error{failure}, errs)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package chunk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// SplitTraces returns an iterator that yields the spans of data in chunks whose encoded size is at
+// most maxSize bytes, or MaxMessageSize when maxSize is not positive. The spans are yielded in the
+// order data holds them, and a chunk ends where the trace ID changes, so the spans of one trace have
+// to be held together to arrive together. Each span is held under its resource and scope, which are
+// repeated in every chunk that holds a span of theirs; spans of one resource and scope that are
+// next to each other in data share a ResourceSpans and ScopeSpans in a chunk.
+//
+// A chunk shares the resources, scopes and spans of data, which are not copied. A span that does
+// not fit a chunk on its own is yielded as an error, after the chunks before it, and ends the
+// iteration.
+func SplitTraces(data *tracev1.TracesData, maxSize int) iter.Seq2[*tracev1.TracesData, error] {
+	if maxSize <= 0 {
+		maxSize = MaxMessageSize
+	}
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		var b builder
+		for _, rs := range data.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					spanSize := proto.Size(span)
+					if b.chunk != nil && (!bytes.Equal(span.GetTraceId(), b.traceID) || b.with(rs, ss, spanSize).total() > maxSize) {
+						if !yield(b.chunk, nil) {
+							return
+						}
+						b = builder{}
+					}
+					next := b.with(rs, ss, spanSize)
+					if next.total() > maxSize {
+						yield(nil, fmt.Errorf("span %x of trace %x is %d bytes with its resource and scope, more than a chunk of %d bytes holds",
+							span.GetSpanId(), span.GetTraceId(), next.total(), maxSize))
+						return
+					}
+					b.add(rs, ss, span, next)
+				}
+			}
+		}
+		if b.chunk != nil {
+			yield(b.chunk, nil)
+		}
+	}
+}
+
+// builder is the chunk SplitTraces is filling, and the sizes it keeps to tell, without encoding
+// the chunk again, how large it would be with another span.
+type builder struct {
+	chunk   *tracev1.TracesData
+	traceID []byte
+	// resource and scope are those of data the last ResourceSpans and ScopeSpans of the chunk
+	// were made from.
+	resource *tracev1.ResourceSpans
+	scope    *tracev1.ScopeSpans
+	sizes    sizes
+}
+
+// sizes are the parts of the encoded size of a chunk that its last ResourceSpans and ScopeSpans,
+// which grow with each span, leave unknown until they are written.
+type sizes struct {
+	// closed is the size of the fields of the ResourceSpans before the last.
+	closed int
+	// resource is the size of the last ResourceSpans without its ScopeSpans, and scopes that of
+	// the fields of the ScopeSpans before its last.
+	resource, scopes int
+	// scope is the size of the last ScopeSpans.
+	scope int
+}
+
+// total returns the encoded size of the chunk.
+func (s sizes) total() int {
+	return s.closed + field(s.resource+s.scopes+field(s.scope))
+}
+
+// with returns the sizes of the chunk the builder is filling with a span of size spanSize added,
+// under rs and ss, or of a new chunk holding only the span when the builder is empty.
+func (b *builder) with(rs *tracev1.ResourceSpans, ss *tracev1.ScopeSpans, spanSize int) sizes {
+	s := b.sizes
+	switch {
+	case b.chunk == nil:
+		s = sizes{resource: resourceSize(rs), scope: scopeSize(ss)}
+	case rs != b.resource:
+		s = sizes{closed: s.total(), resource: resourceSize(rs), scope: scopeSize(ss)}
+	case ss != b.scope:
+		s.scopes += field(s.scope)
+		s.scope = scopeSize(ss)
+	}
+	s.scope += field(spanSize)
+	return s
+}
+
+// add adds a span to the chunk, under a ResourceSpans and ScopeSpans made from rs and ss, and
+// records the sizes with computed.
+func (b *builder) add(rs *tracev1.ResourceSpans, ss *tracev1.ScopeSpans, span *tracev1.Span, sizes sizes) {
+	if b.chunk == nil {
+		b.chunk = &tracev1.TracesData{}
+		b.traceID = span.GetTraceId()
+	}
+	if rs != b.resource {
+		b.chunk.ResourceSpans = append(b.chunk.ResourceSpans, &tracev1.ResourceSpans{Resource: rs.GetResource(), SchemaUrl: rs.GetSchemaUrl()})
+		b.resource, b.scope = rs, nil
+	}
+	last := b.chunk.ResourceSpans[len(b.chunk.ResourceSpans)-1]
+	if ss != b.scope {
+		last.ScopeSpans = append(last.ScopeSpans, &tracev1.ScopeSpans{Scope: ss.GetScope(), SchemaUrl: ss.GetSchemaUrl()})
+		b.scope = ss
+	}
+	scope := last.ScopeSpans[len(last.ScopeSpans)-1]
+	scope.Spans = append(scope.Spans, span)
+	b.sizes = sizes
+}
+
+// resourceSize returns the encoded size of a ResourceSpans without its ScopeSpans.
+func resourceSize(rs *tracev1.ResourceSpans) int {
+	return proto.Size(&tracev1.ResourceSpans{Resource: rs.GetResource(), SchemaUrl: rs.GetSchemaUrl()})
+}
+
+// scopeSize returns the encoded size of a ScopeSpans without its spans.
+func scopeSize(ss *tracev1.ScopeSpans) int {
+	return proto.Size(&tracev1.ScopeSpans{Scope: ss.GetScope(), SchemaUrl: ss.GetSchemaUrl()})
+}
+
+// JoinTraces returns an iterator that reads chunks with recv until it returns io.EOF, and yields
+// each trace whole. A server streams the chunks of one trace one after another, so a chunk of a
+// trace other than the last one's ends that trace; a chunk without spans adds nothing. A chunk is
+// taken to hold spans of one trace, as SplitTraces makes it, and the trace of its first span is
+// the one it is joined to.
+//
+// A trace yielded is a TracesData of its own, holding the ResourceSpans of its chunks, so the
+// chunks recv returned are left as they were.
+//
+// An error of recv drops the trace it interrupted, which may be missing spans, and is yielded on
+// its own.
+func JoinTraces(recv func() (*tracev1.TracesData, error)) iter.Seq2[*tracev1.TracesData, error] {
+	return func(yield func(*tracev1.TracesData, error) bool) {
+		var current *tracev1.TracesData
+		var currentID []byte
+		for {
+			chunk, err := recv()
+			if errors.Is(err, io.EOF) {
+				if current != nil {
+					yield(current, nil)
+				}
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			id := chunkTraceID(chunk)
+			if id == nil {
+				continue
+			}
+			if current != nil && !bytes.Equal(id, currentID) {
+				if !yield(current, nil) {
+					return
+				}
+				current = nil
+			}
+			if current == nil {
+				current, currentID = &tracev1.TracesData{}, id
+			}
+			current.ResourceSpans = append(current.ResourceSpans, chunk.GetResourceSpans()...)
+		}
+	}
+}
+
+// chunkTraceID returns the trace ID of the first span of a chunk, or nil when it has none.
+func chunkTraceID(chunk *tracev1.TracesData) []byte {
+	for _, rs := range chunk.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				return s.GetTraceId()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package chunk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func otlpSpan(trace byte, name string) *tracev1.Span {
+	return &tracev1.Span{TraceId: []byte{15: trace}, SpanId: []byte{7: byte(len(name))}, Name: name}
+}
+
+func resourceSpans(service string, scopes ...*tracev1.ScopeSpans) *tracev1.ResourceSpans {
+	return &tracev1.ResourceSpans{
+		Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{{
+			Key:   "service.name",
+			Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: service}},
+		}}},
+		SchemaUrl:  "https://opentelemetry.io/schemas/1.26.0",
+		ScopeSpans: scopes,
+	}
+}
+
+func scopeSpans(scope string, spans ...*tracev1.Span) *tracev1.ScopeSpans {
+	return &tracev1.ScopeSpans{Scope: &commonv1.InstrumentationScope{Name: scope}, Spans: spans}
+}
+
+// tracesData is two traces, the first over two resources and three scopes, each of whose spans is
+// one letter longer than the one before.
+func tracesData() *tracev1.TracesData {
+	return &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{
+		resourceSpans("frontend",
+			scopeSpans("http", otlpSpan(1, "a"), otlpSpan(1, "ab"), otlpSpan(1, "abc")),
+			scopeSpans("db", otlpSpan(1, "abcd"), otlpSpan(1, "abcde"))),
+		resourceSpans("driver",
+			scopeSpans("grpc", otlpSpan(1, "abcdef"), otlpSpan(1, "abcdefg"))),
+		resourceSpans("batch",
+			scopeSpans("jobs", otlpSpan(2, "abcdefgh"), otlpSpan(2, "abcdefghi"))),
+	}}
+}
+
+func spanNames(data *tracev1.TracesData) []string {
+	var names []string
+	for _, rs := range data.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				names = append(names, span.GetName())
+			}
+		}
+	}
+	return names
+}
+
+func splitTraces(t *testing.T, data *tracev1.TracesData, maxSize int) []*tracev1.TracesData {
+	t.Helper()
+	var chunks []*tracev1.TracesData
+	for chunk, err := range SplitTraces(data, maxSize) {
+		require.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// prefix returns the first n spans of a chunk, under their resources and scopes.
+func prefix(chunk *tracev1.TracesData, n int) *tracev1.TracesData {
+	result := &tracev1.TracesData{}
+	for _, rs := range chunk.ResourceSpans {
+		resource := &tracev1.ResourceSpans{Resource: rs.Resource, SchemaUrl: rs.SchemaUrl}
+		for _, ss := range rs.ScopeSpans {
+			scope := &tracev1.ScopeSpans{Scope: ss.Scope, SchemaUrl: ss.SchemaUrl}
+			for _, span := range ss.Spans {
+				if n == 0 {
+					break
+				}
+				scope.Spans = append(scope.Spans, span)
+				n--
+			}
+			if len(scope.Spans) > 0 {
+				resource.ScopeSpans = append(resource.ScopeSpans, scope)
+			}
+		}
+		if len(resource.ScopeSpans) > 0 {
+			result.ResourceSpans = append(result.ResourceSpans, resource)
+		}
+	}
+	return result
+}
+
+func TestSplitTraces(t *testing.T) {
+	chunks := splitTraces(t, tracesData(), 0)
+	require.Len(t, chunks, 2, "a chunk for each trace")
+	assert.True(t, proto.Equal(&tracev1.TracesData{ResourceSpans: tracesData().ResourceSpans[:2]}, chunks[0]))
+	assert.True(t, proto.Equal(&tracev1.TracesData{ResourceSpans: tracesData().ResourceSpans[2:]}, chunks[1]))
+}
+
+// TestSplitTraces_ExactSize checks the size a chunk is kept under is its encoded size: given the
+// size of the chunk of the first n spans of the trace, that is the first chunk it yields.
+func TestSplitTraces_ExactSize(t *testing.T) {
+	whole := splitTraces(t, tracesData(), 0)[0]
+	for n := 1; n <= 7; n++ {
+		expected := prefix(whole, n)
+		for chunk, err := range SplitTraces(tracesData(), proto.Size(expected)) {
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(expected, chunk), "%d spans: got %v", n, spanNames(chunk))
+			break
+		}
+	}
+}
+
+func TestSplitTraces_Small(t *testing.T) {
+	const maxSize = 150
+	chunks := splitTraces(t, tracesData(), maxSize)
+	var names []string
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, proto.Size(chunk), maxSize)
+		for _, rs := range chunk.ResourceSpans {
+			assert.NotNil(t, rs.Resource, "a span keeps its resource")
+			for _, ss := range rs.ScopeSpans {
+				assert.NotNil(t, ss.Scope, "a span keeps its scope")
+			}
+		}
+		names = append(names, spanNames(chunk)...)
+	}
+	assert.Equal(t, spanNames(tracesData()), names)
+	assert.Greater(t, len(chunks), 2)
+}
+
+func TestSplitTraces_SpanTooLarge(t *testing.T) {
+	data := tracesData()
+	data.ResourceSpans[1].ScopeSpans[0].Spans[0].Name = strings.Repeat("x", 1000)
+	var chunks []*tracev1.TracesData
+	var errs []error
+	for chunk, err := range SplitTraces(data, 300) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		chunks = append(chunks, chunk)
+	}
+	require.Len(t, chunks, 1, "the chunks before the span are yielded")
+	assert.Equal(t, []string{"a", "ab", "abc", "abcd", "abcde"}, spanNames(chunks[0]))
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "of trace 00000000000000000000000000000001")
+}
+
+func TestJoinTraces(t *testing.T) {
+	chunks := splitTraces(t, tracesData(), 150)
+	require.Greater(t, len(chunks), 2)
+	chunks = append(chunks[:1], append([]*tracev1.TracesData{{}}, chunks[1:]...)...)
+
+	var traces []*tracev1.TracesData
+	for trace, err := range JoinTraces(recvFrom(chunks...)) {
+		require.NoError(t, err)
+		traces = append(traces, trace)
+	}
+	require.Len(t, traces, 2)
+	assert.Equal(t, []string{"a", "ab", "abc", "abcd", "abcde", "abcdef", "abcdefg"}, spanNames(traces[0]))
+	assert.Equal(t, []string{"abcdefgh", "abcdefghi"}, spanNames(traces[1]))
+}
+
+func TestJoinTraces_LeavesChunks(t *testing.T) {
+	chunks := splitTraces(t, tracesData(), 150)
+	require.Greater(t, len(chunks), 2)
+	received := make([]*tracev1.TracesData, len(chunks))
+	for i, chunk := range chunks {
+		received[i] = proto.Clone(chunk).(*tracev1.TracesData)
+	}
+	for _, err := range JoinTraces(recvFrom(chunks...)) {
+		require.NoError(t, err)
+	}
+	for i := range chunks {
+		assert.True(t, proto.Equal(received[i], chunks[i]), "chunk %d was modified", i)
+	}
+}
+
+func TestJoinTraces_Error(t *testing.T) {
+	failure := errors.New("connection reset")
+	chunks := splitTraces(t, tracesData(), 150)
+	recv := func() (*tracev1.TracesData, error) {
+		if len(chunks) == 1 {
+			return nil, failure
+		}
+		next := chunks[0]
+		chunks = chunks[1:]
+		return next, nil
+	}
+	var traces []*tracev1.TracesData
+	var errs []error
+	for trace, err := range JoinTraces(recv) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		traces = append(traces, trace)
+	}
+	require.Len(t, traces, 1, "the interrupted trace is dropped")
+	assert.Equal(t, []error{failure}, errs)
+}
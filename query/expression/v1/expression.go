@@ -11,7 +11,9 @@
 // describe a filter arriving on the public query API, reaching a storage backend, and
 // being gated by a query interceptor, so nothing in that path has to translate between two
 // representations of the same tree. Converting to and from the wire is the business of whoever
-// owns a wire.
+// owns a wire, with one exception: a Call reads and writes the proto3 JSON form itself (see
+// Call.MarshalJSON), since that needs nothing beyond the standard library and is what a saved
+// search is written in.
 package expression
 
 import "time"
@@ -133,7 +135,8 @@ type NestedRef struct {
 // AnyValue is a constant under no type constraint: the caller wrote a value and said nothing
 // about how to read it, so a backend matches it at whatever type the value was stored. It is
 // also what an unhinted duration or timestamp arrives as, until it is resolved against the
-// field it is compared with (see ResolveConstants).
+// field it is compared with (see ResolveConstants), and what a value compared against an attribute
+// stays unless a schema declares that attribute's type (see AttributeSchema).
 type AnyValue struct {
 	expressionTerm
 
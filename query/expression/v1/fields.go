@@ -3,7 +3,11 @@
 
 package expression
 
-import "slices"
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
 
 // Built-in fields are the values a span carries directly, rather than entries in one of its
 // attribute maps. A FieldRef names one by giving its level and its name.
@@ -12,7 +16,8 @@ import "slices"
 // name is part of the query API: a caller writes one query against Jaeger, not a different one
 // per storage backend. Which of them a given backend can actually serve is the separate
 // question that SearchCapabilities answers, so a field being valid here does not promise that
-// every deployment can filter on it.
+// every deployment can filter on it. A deployment can also serve more than these, by registering
+// its own beside them (see FieldRegistry).
 //
 // Most fields are a field of the corresponding OTLP message. A few are derived, computed from
 // the OTLP data rather than stored in it — a span's duration from its two timestamps, an
@@ -52,36 +57,52 @@ const (
 	SpanFieldDuration      = "duration"
 	SpanFieldStatus        = "status"
 	SpanFieldStatusMessage = "statusMessage"
+	SpanFieldFlags         = "flags"
+	SpanFieldEventCount    = "eventCount"
+	SpanFieldLinkCount     = "linkCount"
+
+	SpanFieldDroppedAttributesCount = "droppedAttributesCount"
+	SpanFieldDroppedEventsCount     = "droppedEventsCount"
+	SpanFieldDroppedLinksCount      = "droppedLinksCount"
 
-	ResourceFieldService   = "service"
-	ResourceFieldSchemaURL = "schemaURL"
+	ResourceFieldService                = "service"
+	ResourceFieldSchemaURL              = "schemaURL"
+	ResourceFieldDroppedAttributesCount = "droppedAttributesCount"
 
-	ScopeFieldName      = "name"
-	ScopeFieldVersion   = "version"
-	ScopeFieldSchemaURL = "schemaURL"
+	ScopeFieldName                   = "name"
+	ScopeFieldVersion                = "version"
+	ScopeFieldSchemaURL              = "schemaURL"
+	ScopeFieldDroppedAttributesCount = "droppedAttributesCount"
 
-	EventFieldName           = "name"
-	EventFieldTime           = "time"
-	EventFieldTimeSinceStart = "timeSinceStart"
+	EventFieldName                   = "name"
+	EventFieldTime                   = "time"
+	EventFieldTimeSinceStart         = "timeSinceStart"
+	EventFieldDroppedAttributesCount = "droppedAttributesCount"
 
-	LinkFieldTraceID    = "traceID"
-	LinkFieldSpanID     = "spanID"
-	LinkFieldTraceState = "traceState"
+	LinkFieldTraceID                = "traceID"
+	LinkFieldSpanID                 = "spanID"
+	LinkFieldTraceState             = "traceState"
+	LinkFieldFlags                  = "flags"
+	LinkFieldDroppedAttributesCount = "droppedAttributesCount"
 )
 
 // FieldType is the type a built-in field holds, and so the type a constant compared against
 // that field is read as. It is what ResolveConstants rewrites an unconstrained constant into,
 // and what makes `span.duration > "banana"` refusable at the query boundary.
 //
-// It is a smaller vocabulary than it might be, because the fields below are the only ones that
-// exist: IDs, a status, a span kind and a trace state are all text this API checks, and a
-// distinct type only pays once something wants the parsed form (RFC 0005 §5.4). A level gains
-// numeric fields the day one is defined, and the type for it is added here with the rule that
-// parses it.
+// It is a smaller vocabulary than it might be, because a distinct type only pays once something
+// wants the parsed form (RFC 0005 §5.4): IDs, a status, a span kind and a trace state are all text
+// this API checks. The counts and flags OTLP carries are the numbers, and a number is read in the
+// same syntax Scalar.type `int` and `double` are, so a constant compared against one reads the same
+// wherever it appears.
 type FieldType string
 
 const (
-	FieldTypeString    FieldType = "string"
+	FieldTypeString FieldType = "string"
+	FieldTypeInt    FieldType = "int"
+	// FieldTypeDouble is held by no field RFC 0005 defines. It is declared for a field a
+	// deployment registers (see FieldRegistry), a ratio or a score its backend derives.
+	FieldTypeDouble    FieldType = "double"
 	FieldTypeDuration  FieldType = "duration"
 	FieldTypeTimestamp FieldType = "timestamp"
 	// FieldTypeSpanKind and FieldTypeSpanStatus hold one of a closed set of words, so a
@@ -95,7 +116,7 @@ const (
 // fieldTypes is every declared field type, walked by a test so that a type added without a
 // rule to parse its constants fails there rather than when a caller sends one.
 var fieldTypes = []FieldType{
-	FieldTypeString, FieldTypeDuration, FieldTypeTimestamp,
+	FieldTypeString, FieldTypeInt, FieldTypeDouble, FieldTypeDuration, FieldTypeTimestamp,
 	FieldTypeSpanKind, FieldTypeSpanStatus,
 }
 
@@ -155,8 +176,18 @@ var fields = []Field{
 	{Level: LevelSpan, Name: SpanFieldEndTime, Type: FieldTypeTimestamp},
 	{Level: LevelSpan, Name: SpanFieldStatus, Type: FieldTypeSpanStatus},
 	{Level: LevelSpan, Name: SpanFieldStatusMessage, Type: FieldTypeString},
+	// The W3C trace flags in the low byte, and OTLP's own flags above it, compared as a number.
+	{Level: LevelSpan, Name: SpanFieldFlags, Type: FieldTypeInt},
+	{Level: LevelSpan, Name: SpanFieldDroppedAttributesCount, Type: FieldTypeInt},
+	{Level: LevelSpan, Name: SpanFieldDroppedEventsCount, Type: FieldTypeInt},
+	{Level: LevelSpan, Name: SpanFieldDroppedLinksCount, Type: FieldTypeInt},
 	// end_time_unix_nano - start_time_unix_nano, compared as a Go duration string.
 	{Level: LevelSpan, Name: SpanFieldDuration, Type: FieldTypeDuration, Derived: true},
+	// The number of events and of links the span holds, which is what the two collections `some`
+	// quantifies over have no way to ask: whether a span has many of them, rather than one that
+	// matches. Neither counts what the SDK dropped, which the dropped counts above report.
+	{Level: LevelSpan, Name: SpanFieldEventCount, Type: FieldTypeInt, Derived: true},
+	{Level: LevelSpan, Name: SpanFieldLinkCount, Type: FieldTypeInt, Derived: true},
 
 	// Resource — opentelemetry.proto.resource.v1.Resource, which carries only attributes, plus
 	// the schema URL from the enclosing ResourceSpans.
@@ -166,23 +197,28 @@ var fields = []Field{
 	// search index of several backends — so a query says resource.service, not a tag lookup.
 	{Level: LevelResource, Name: ResourceFieldService, Type: FieldTypeString, Derived: true},
 	{Level: LevelResource, Name: ResourceFieldSchemaURL, Type: FieldTypeString},
+	{Level: LevelResource, Name: ResourceFieldDroppedAttributesCount, Type: FieldTypeInt},
 
 	// Scope — opentelemetry.proto.common.v1.InstrumentationScope, plus the
 	// schema URL from the enclosing ScopeSpans.
 	{Level: LevelScope, Name: ScopeFieldName, Type: FieldTypeString},
 	{Level: LevelScope, Name: ScopeFieldVersion, Type: FieldTypeString},
 	{Level: LevelScope, Name: ScopeFieldSchemaURL, Type: FieldTypeString},
+	{Level: LevelScope, Name: ScopeFieldDroppedAttributesCount, Type: FieldTypeInt},
 
 	// Event — Span.Event.
 	{Level: LevelEvent, Name: EventFieldName, Type: FieldTypeString},
 	{Level: LevelEvent, Name: EventFieldTime, Type: FieldTypeTimestamp},
 	// Event.time_unix_nano - Span.start_time_unix_nano, compared as a Go duration string.
 	{Level: LevelEvent, Name: EventFieldTimeSinceStart, Type: FieldTypeDuration, Derived: true},
+	{Level: LevelEvent, Name: EventFieldDroppedAttributesCount, Type: FieldTypeInt},
 
 	// Link — Span.Link. The IDs are the linked span's, not the linking one's.
 	{Level: LevelLink, Name: LinkFieldTraceID, Type: FieldTypeString},
 	{Level: LevelLink, Name: LinkFieldSpanID, Type: FieldTypeString},
 	{Level: LevelLink, Name: LinkFieldTraceState, Type: FieldTypeString},
+	{Level: LevelLink, Name: LinkFieldFlags, Type: FieldTypeInt},
+	{Level: LevelLink, Name: LinkFieldDroppedAttributesCount, Type: FieldTypeInt},
 }
 
 // Fields returns every built-in field a query may name. A caller that offers fields to choose
@@ -195,6 +231,10 @@ func Fields() []Field {
 // LookupField returns the built-in field of that level and name. Its second result is false
 // when no such field is defined, which is what ValidateFilter refuses.
 func LookupField(level Level, name string) (Field, bool) {
+	return lookup(fields, level, name)
+}
+
+func lookup(fields []Field, level Level, name string) (Field, bool) {
 	for _, f := range fields {
 		if f.Level == level && f.Name == name {
 			return f, true
@@ -202,3 +242,64 @@ func LookupField(level Level, name string) (Field, bool) {
 	}
 	return Field{}, false
 }
+
+// FieldRegistry is the set of built-in fields one deployment serves: the fields RFC 0005 defines,
+// and whichever a deployment adds beside them — a value its backend derives, such as a span's
+// self time, or an attribute it promotes to an indexed column and would rather be queried as one.
+// Passed to ValidateFilter, ResolveConstants or Finalize with WithFields, it is what a filter's
+// field references are checked and read against.
+//
+// A registry only grows. A field RFC 0005 defines means the same thing on every deployment, so one
+// cannot be redefined or withdrawn here; a backend that cannot serve one says so in its declared
+// capabilities instead.
+//
+// A registry is filled in when a deployment starts and read from then on. Register is not safe to
+// call while a filter is being checked against the same registry.
+type FieldRegistry struct {
+	fields []Field
+}
+
+// NewFieldRegistry returns a registry holding the fields RFC 0005 defines, ready for a
+// deployment's own to be registered beside them.
+func NewFieldRegistry() *FieldRegistry {
+	return &FieldRegistry{fields: Fields()}
+}
+
+// Register adds a field to the registry. The field has to be complete — named, at one of the
+// five levels, of a declared type — and it has to be new: a level and name already registered is
+// refused, since two definitions would leave a filter naming it meaning whichever was found first.
+func (r *FieldRegistry) Register(field Field) error {
+	if field.Name == "" {
+		return errors.New("a built-in field has to be named")
+	}
+	if !slices.Contains(levels, field.Level) {
+		return fmt.Errorf("built-in field %q is at unknown level %q", field.Name, field.Level)
+	}
+	if !slices.Contains(fieldTypes, field.Type) {
+		return fmt.Errorf("built-in field %s.%s holds unknown type %q", field.Level, field.Name, field.Type)
+	}
+	if _, ok := r.Lookup(field.Level, field.Name); ok {
+		return fmt.Errorf("built-in field %s.%s is already registered", field.Level, field.Name)
+	}
+	r.fields = append(r.fields, field)
+	return nil
+}
+
+// Lookup returns the field of that level and name, as LookupField does for the fields RFC 0005
+// defines. A nil registry holds those fields alone, which is what a filter checked without
+// WithFields is checked against.
+func (r *FieldRegistry) Lookup(level Level, name string) (Field, bool) {
+	if r == nil {
+		return LookupField(level, name)
+	}
+	return lookup(r.fields, level, name)
+}
+
+// Fields returns every field in the registry, the RFC's first and then the registered ones in the
+// order they were added. It returns a copy, like the package-level Fields.
+func (r *FieldRegistry) Fields() []Field {
+	if r == nil {
+		return Fields()
+	}
+	return slices.Clone(r.fields)
+}
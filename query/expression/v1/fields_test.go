@@ -30,6 +30,8 @@ func TestFields(t *testing.T) {
 	}
 	assert.Equal(t, map[string]bool{
 		"span.duration":        true,
+		"span.eventCount":      true,
+		"span.linkCount":       true,
 		"resource.service":     true,
 		"event.timeSinceStart": true,
 	}, derived, "the fields computed rather than read")
@@ -67,6 +69,16 @@ func TestFields_DeclaredTypes(t *testing.T) {
 	assert.Equal(t, []string{"span.startTime", "span.endTime", "event.time"}, byType[FieldTypeTimestamp])
 	assert.Equal(t, []string{"span.kind"}, byType[FieldTypeSpanKind])
 	assert.Equal(t, []string{"span.status"}, byType[FieldTypeSpanStatus])
+	assert.Equal(t, []string{
+		"span.flags",
+		"span.droppedAttributesCount", "span.droppedEventsCount", "span.droppedLinksCount",
+		"span.eventCount", "span.linkCount",
+		"resource.droppedAttributesCount",
+		"scope.droppedAttributesCount",
+		"event.droppedAttributesCount",
+		"link.flags", "link.droppedAttributesCount",
+	}, byType[FieldTypeInt])
+	assert.Empty(t, byType[FieldTypeDouble], "the RFC defines no floating-point field; a deployment may register one")
 	for _, name := range []string{"span.traceID", "span.statusMessage", "resource.service"} {
 		assert.Contains(t, byType[FieldTypeString], name,
 			"an ID is a string: an ID nobody recorded reads the same as one being looked for")
@@ -111,6 +123,10 @@ func TestFieldTypes_AreAllReadable(t *testing.T) {
 // not turn into a test of each type's parser.
 func textFor(t FieldType) string {
 	switch t {
+	case FieldTypeInt:
+		return "42"
+	case FieldTypeDouble:
+		return "0.25"
 	case FieldTypeDuration:
 		return "2s"
 	case FieldTypeTimestamp:
@@ -123,3 +139,82 @@ func textFor(t FieldType) string {
 		return "anything"
 	}
 }
+
+// deploymentFields is a registry as a deployment would build one: a value its backend derives, and
+// an attribute promoted to a column of its own.
+func deploymentFields(t *testing.T) *FieldRegistry {
+	registry := NewFieldRegistry()
+	require.NoError(t, registry.Register(Field{Level: LevelSpan, Name: "selfTime", Type: FieldTypeDuration, Derived: true}))
+	require.NoError(t, registry.Register(Field{Level: LevelResource, Name: "k8sNamespace", Type: FieldTypeString}))
+	return registry
+}
+
+func TestFieldRegistry(t *testing.T) {
+	registry := deploymentFields(t)
+
+	f, ok := registry.Lookup(LevelSpan, "selfTime")
+	require.True(t, ok)
+	assert.True(t, f.Derived)
+	assert.Equal(t, FieldTypeDuration, f.Type)
+
+	_, ok = registry.Lookup(LevelSpan, SpanFieldDuration)
+	assert.True(t, ok, "a registry starts from the RFC's fields")
+
+	_, ok = LookupField(LevelSpan, "selfTime")
+	assert.False(t, ok, "registering a field does not change what RFC 0005 defines")
+	_, ok = NewFieldRegistry().Lookup(LevelSpan, "selfTime")
+	assert.False(t, ok, "nor what another registry holds")
+
+	all := registry.Fields()
+	assert.Len(t, all, len(Fields())+2)
+	assert.Equal(t, "k8sNamespace", all[len(all)-1].Name, "registered fields follow the RFC's, in order")
+	all[0].Name = "mutated"
+	_, ok = registry.Lookup(LevelSpan, SpanFieldTraceID)
+	assert.True(t, ok, "Fields returns a copy")
+
+	var none *FieldRegistry
+	_, ok = none.Lookup(LevelSpan, SpanFieldDuration)
+	assert.True(t, ok, "a nil registry holds the RFC's fields")
+	assert.Equal(t, Fields(), none.Fields())
+}
+
+func TestFieldRegistry_RefusesAnIncompleteOrRepeatedField(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       Field
+		expectedErr string
+	}{
+		{
+			name:        "no name",
+			field:       Field{Level: LevelSpan, Type: FieldTypeString},
+			expectedErr: "a built-in field has to be named",
+		},
+		{
+			name:        "no level",
+			field:       Field{Name: "depth", Type: FieldTypeString},
+			expectedErr: `built-in field "depth" is at unknown level ""`,
+		},
+		{
+			name:        "an unknown type",
+			field:       Field{Level: LevelSpan, Name: "depth", Type: "number"},
+			expectedErr: `built-in field span.depth holds unknown type "number"`,
+		},
+		{
+			name:        "a field RFC 0005 defines",
+			field:       Field{Level: LevelSpan, Name: SpanFieldDuration, Type: FieldTypeString},
+			expectedErr: "built-in field span.duration is already registered",
+		},
+		{
+			name:        "a field registered already",
+			field:       Field{Level: LevelSpan, Name: "selfTime", Type: FieldTypeDuration},
+			expectedErr: "built-in field span.selfTime is already registered",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := deploymentFields(t)
+			require.EqualError(t, registry.Register(test.field), test.expectedErr)
+			assert.Len(t, registry.Fields(), len(Fields())+2, "a refused field is not added")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filterparam carries a structured filter in the query.filter parameter of the GET form of
+// api_v3's FindTraces and FindTraceSummaries. A Call nests to no fixed depth, which the field-path
+// expansion a GET binding gives a message cannot spell, so prune-openapi collapses that expansion
+// into the one string parameter the OpenAPI document lists, and this package defines the string:
+// the filter's proto3 JSON form, the one the POST body carries it in, which the query string
+// percent-encodes.
+//
+// Sharing the POST form's spelling is what gives the two forms one meaning: a filter read from
+// the parameter is the tree the same filter read from a body is, once finalized. Encoding is
+// deterministic, so one filter always gives one URL, and a search can be bookmarked and compared.
+package filterparam
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// Name is the name of the parameter.
+const Name = "query.filter"
+
+// Encode returns the value of the parameter that carries a filter. A filter that does not validate
+// is refused, so that a client fails before sending what the server would refuse; it is not
+// finalized, which the server does against its own fields.
+func Encode(filter *expression.Call) (string, error) {
+	if err := expression.ValidateFilter(filter); err != nil {
+		return "", fmt.Errorf("invalid filter: %w", err)
+	}
+	data, err := filter.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("invalid filter: %w", err)
+	}
+	return string(data), nil
+}
+
+// Parse reads the value of the parameter, already percent-decoded, into a finalized filter, as the
+// options finalize it. An empty value is no filter, and returns nil.
+func Parse(value string, opts ...expression.Option) (*expression.Call, error) {
+	if value == "" {
+		return nil, nil
+	}
+	filter := &expression.Call{}
+	if err := filter.UnmarshalJSON([]byte(value)); err != nil {
+		return nil, fmt.Errorf("invalid %s parameter: %w", Name, err)
+	}
+	finalized, err := expression.Finalize(filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameter: %w", Name, err)
+	}
+	return finalized, nil
+}
+
+// Set sets the parameter in a query string to carry a filter, or removes it when the filter is nil.
+func Set(values url.Values, filter *expression.Call) error {
+	if filter == nil {
+		values.Del(Name)
+		return nil
+	}
+	value, err := Encode(filter)
+	if err != nil {
+		return err
+	}
+	values.Set(Name, value)
+	return nil
+}
+
+// Get reads the filter a query string carries, or nil when it carries none.
+//
+// The parameter given twice is refused, since either choice would drop a conjunct the caller
+// meant. So is a parameter named as a field of the filter, such as query.filter.op: it is what a
+// client built from the OpenAPI document before the expansion was collapsed sends, and ignoring it
+// would widen the search to traces the caller did not ask for.
+func Get(values url.Values, opts ...expression.Option) (*expression.Call, error) {
+	var expanded []string
+	for key := range values {
+		if strings.HasPrefix(key, Name+".") {
+			expanded = append(expanded, key)
+		}
+	}
+	if len(expanded) > 0 {
+		slices.Sort(expanded)
+		return nil, fmt.Errorf("unsupported parameter %s: the filter is carried whole in %s", expanded[0], Name)
+	}
+	switch found := values[Name]; len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return Parse(found[0], opts...)
+	default:
+		return nil, errors.New("the " + Name + " parameter is given more than once")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filterparam
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+func call(op expression.Operator, args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: op, Args: args}
+}
+
+// filter uses every kind of term, and constants holding what a query string gives a meaning to.
+func filter() *expression.Call {
+	return call(expression.OpAnd,
+		call(expression.OpGt, &expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldDuration}, &expression.AnyValue{Value: "2s"}),
+		call(expression.OpEq, &expression.AttributeRef{Key: "http.route"}, &expression.StringValue{Value: "/a?b=c&d=e#f +%20é"}),
+		call(expression.OpIn, &expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldKind}, &expression.List{Values: []string{"server", "client"}}),
+		call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+			call(expression.OpExists, &expression.AttributeRef{Level: expression.LevelEvent, Key: "exception.type"})),
+	)
+}
+
+func TestEncode(t *testing.T) {
+	value, err := Encode(call(expression.OpEq, &expression.AttributeRef{Key: "http.status_code"}, &expression.AnyValue{Value: "500"}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"op":"eq","args":[{"attr":{"key":"http.status_code"}},{"scalar":{"value":"500"}}]}`, value,
+		"the example of the OpenAPI document")
+
+	again, err := Encode(call(expression.OpEq, &expression.AttributeRef{Key: "http.status_code"}, &expression.AnyValue{Value: "500"}))
+	require.NoError(t, err)
+	assert.Equal(t, value, again, "one filter gives one value")
+
+	_, err = Encode(call(expression.OpEq))
+	require.ErrorContains(t, err, "invalid filter")
+	_, err = Encode(nil)
+	require.ErrorContains(t, err, "invalid filter")
+}
+
+// TestRoundTrip is what a bookmarked search makes: the filter, through a URL, is read back as the
+// filter finalized.
+func TestRoundTrip(t *testing.T) {
+	values := url.Values{}
+	require.NoError(t, Set(values, filter()))
+	u := url.URL{Path: "/api/v3/traces", RawQuery: values.Encode()}
+
+	parsed, err := url.Parse(u.String())
+	require.NoError(t, err)
+	read, err := Get(parsed.Query())
+	require.NoError(t, err)
+	expected, err := expression.Finalize(filter())
+	require.NoError(t, err)
+	assert.Equal(t, expected, read)
+}
+
+// TestParse_SameAsPost checks the two forms mean the same: the filter read from the parameter is
+// the one read from the message a POST body carries, once finalized.
+func TestParse_SameAsPost(t *testing.T) {
+	value, err := Encode(filter())
+	require.NoError(t, err)
+	fromGet, err := Parse(value)
+	require.NoError(t, err)
+
+	message := &expressionpb.Call{}
+	require.NoError(t, protojson.Unmarshal([]byte(value), message))
+	body, err := protojson.Marshal(message)
+	require.NoError(t, err)
+	fromPost := &expression.Call{}
+	require.NoError(t, fromPost.UnmarshalJSON(body))
+	fromPost, err = expression.Finalize(fromPost)
+	require.NoError(t, err)
+	assert.Equal(t, fromPost, fromGet)
+}
+
+func TestParse(t *testing.T) {
+	read, err := Parse("")
+	require.NoError(t, err)
+	assert.Nil(t, read, "an empty value is no filter")
+
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "not JSON", value: "{op:eq", err: "invalid query.filter parameter"},
+		{name: "not a filter", value: `{"op":"eq","args":[]}`, err: "invalid query.filter parameter"},
+		{name: "unknown operator", value: `{"op":"like","args":[{"attr":{"key":"a"}},{"scalar":{"value":"b"}}]}`, err: "invalid query.filter parameter"},
+		{name: "constant of the wrong type", value: `{"op":"gt","args":[{"field":{"level":"span","name":"duration"}},{"scalar":{"value":"slow"}}]}`, err: "invalid query.filter parameter"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.value)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	read, err := Get(url.Values{"query.serviceName": {"frontend"}})
+	require.NoError(t, err)
+	assert.Nil(t, read, "no parameter is no filter")
+
+	_, err = Get(url.Values{Name: {`{"op":"exists","args":[{"attr":{"key":"a"}}]}`, `{"op":"exists","args":[{"attr":{"key":"b"}}]}`}})
+	require.EqualError(t, err, "the query.filter parameter is given more than once")
+
+	_, err = Get(url.Values{"query.filter.op": {"eq"}, "query.filter.args": {"x"}})
+	require.EqualError(t, err, "unsupported parameter query.filter.args: the filter is carried whole in query.filter")
+
+	read, err = Get(url.Values{"query.filter_mode": {"x"}, Name: {`{"op":"exists","args":[{"attr":{"key":"a"}}]}`}})
+	require.NoError(t, err, "a sibling that only shares the prefix is not the expansion")
+	assert.Equal(t, expression.OpExists, read.Op)
+}
+
+func TestSet(t *testing.T) {
+	values := url.Values{Name: {"stale"}}
+	require.NoError(t, Set(values, nil))
+	assert.Empty(t, values, "a nil filter removes the parameter")
+
+	require.Error(t, Set(values, call(expression.OpNot)))
+	assert.Empty(t, values, "a filter that does not validate sets nothing")
+}
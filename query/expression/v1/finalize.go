@@ -10,9 +10,12 @@ package expression
 // Running it again on its own result changes nothing, which is what lets each boundary finalize a
 // filter it did not build — the query service after an interceptor has edited one, and the
 // remote-storage server on whatever a client sent it (RFC 0005 §7).
-func Finalize(filter *Call) (*Call, error) {
-	if err := ValidateFilter(filter); err != nil {
+//
+// The options are passed to both stages, so a filter is validated and resolved against the same
+// fields.
+func Finalize(filter *Call, opts ...Option) (*Call, error) {
+	if err := ValidateFilter(filter, opts...); err != nil {
 		return nil, err
 	}
-	return ResolveConstants(filter)
+	return ResolveConstants(filter, opts...)
 }
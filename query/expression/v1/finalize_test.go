@@ -93,3 +93,32 @@ func TestFinalize_RefusesWhatValidationRefuses(t *testing.T) {
 	}})
 	require.ErrorContains(t, err, `cannot compare span.duration against "banana"`)
 }
+
+// TestFinalize_WithFields pins that a registered field is validated and resolved like one the RFC
+// defines, and that without the registry the same filter is refused.
+func TestFinalize_WithFields(t *testing.T) {
+	registry := deploymentFields(t)
+	filter := &Call{Op: OpAnd, Args: []Expression{
+		&Call{Op: OpGt, Args: []Expression{spanField("selfTime"), &AnyValue{Value: "250ms"}}},
+		&Call{Op: OpIn, Args: []Expression{
+			&FieldRef{Name: "k8sNamespace", Level: LevelResource},
+			&List{Values: []string{"checkout", "cart"}},
+		}},
+	}}
+
+	finalized, err := Finalize(filter, WithFields(registry))
+	require.NoError(t, err)
+	assert.Equal(t, &DurationValue{Value: 250 * time.Millisecond}, finalized.Args[0].(*Call).Args[1])
+
+	_, err = Finalize(filter)
+	require.ErrorContains(t, err, `unknown built-in field "selfTime" at the "span" level`)
+
+	_, err = Finalize(&Call{Op: OpGt, Args: []Expression{spanField("selfTime"), &AnyValue{Value: "banana"}}},
+		WithFields(registry))
+	require.ErrorContains(t, err, `cannot compare span.selfTime against "banana"`)
+
+	_, err = Finalize(&Call{Op: OpGt, Args: []Expression{spanField("selfTime"), &StringValue{Value: "1s"}}},
+		WithFields(registry))
+	require.ErrorContains(t, err, `operator "gt" compares span.selfTime against a string constant`,
+		"the registered type decides what the field compares against")
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzFinalize checks what the package promises of any tree it is given, whatever shape it has:
+//
+//   - none of ValidateFilter, ResolveConstants and Finalize panics, however broken the tree;
+//   - none of them modifies the tree it was given;
+//   - Finalize accepts its own result and returns it unchanged;
+//   - a tree ValidateFilter accepts, ResolveConstants resolves, unless a constant it holds as text
+//     does not read as the type it is compared at — which is the one question resolution adds. A
+//     string is text too: whether one is a span kind or a status is read at resolution. The
+//     constant the error names has to be one the tree compares as the error says it does, so a
+//     refusal of anything else is not excused by some other constant being text.
+//
+// The input is not a filter in any encoding but the choices a treeBuilder makes, so every input is
+// a tree and the fuzzer spends its time on trees rather than on syntax. Building twice from one
+// input gives two equal trees, one to hand over and one to compare it against afterwards.
+//
+// What a finalized filter answers is checked by FuzzEvaluator in storage/v2/memory, against Implies
+// and against a naive reading of the filter's structure. The evaluator imports this package, so
+// this fuzz target cannot call it, and the properties here are the ones that hold of the tree alone.
+func FuzzFinalize(f *testing.F) {
+	// The seeds in testdata/fuzz/FuzzFinalize cover deep nesting, a huge list, typed nils and text
+	// read as a field's type; these cover the shape of each operator.
+	for op := range operators {
+		f.Add([]byte{byte(op), 2, 3, 0, 11, 0})
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		filter := buildTree(data)
+		pristine := buildTree(data)
+
+		validateErr := ValidateFilter(filter)
+		assert.Equal(t, pristine, filter, "ValidateFilter modified its input")
+
+		resolved, resolveErr := ResolveConstants(filter)
+		assert.Equal(t, pristine, filter, "ResolveConstants modified its input")
+		if validateErr == nil && resolveErr != nil {
+			require.True(t, comparesSubject(filter, resolveErr),
+				"a filter ValidateFilter accepted did not resolve: %v", resolveErr)
+		}
+
+		finalized, err := Finalize(filter)
+		assert.Equal(t, pristine, filter, "Finalize modified its input")
+		if validateErr != nil {
+			require.Equal(t, validateErr, err)
+			return
+		}
+		require.Equal(t, resolveErr, err)
+		if err != nil {
+			return
+		}
+		assert.Equal(t, resolved, finalized)
+
+		again, err := Finalize(finalized)
+		require.NoError(t, err, "Finalize refused its own result")
+		assert.Equal(t, finalized, again, "Finalize is not idempotent")
+	})
+}
+
+// The refusals of ResolveConstants, each naming the constant it could not read and what it was
+// compared against.
+var (
+	unreadableConstant = regexp.MustCompile(`^cannot compare (\S+)\.(\S+) against (".*?"): `)
+	mismatchedList     = regexp.MustCompile(`^cannot compare (\S+)\.(\S+) against a list of (\S+): `)
+	unreadableElement  = regexp.MustCompile(`^element (".*?") of a list of (\S+): `)
+)
+
+// comparesSubject reports whether a tree holds the comparison a resolution error names: a field
+// compared against text that does not read as its type, a field compared against a list declared
+// as a type it cannot hold, or a list holding an element that does not read as its declared type.
+func comparesSubject(filter *Call, err error) bool {
+	message := err.Error()
+	if m := unreadableConstant.FindStringSubmatch(message); m != nil {
+		text, unquoteErr := strconv.Unquote(m[3])
+		return unquoteErr == nil && anyComparison(filter, func(ref *FieldRef, other Expression) bool {
+			return string(ref.Level) == m[1] && ref.Name == m[2] && holdsText(other, text)
+		})
+	}
+	if m := mismatchedList.FindStringSubmatch(message); m != nil {
+		return anyComparison(filter, func(ref *FieldRef, other Expression) bool {
+			list, ok := other.(*List)
+			return ok && list != nil && string(ref.Level) == m[1] && ref.Name == m[2] && string(list.Type) == m[3]
+		})
+	}
+	if m := unreadableElement.FindStringSubmatch(message); m != nil {
+		element, unquoteErr := strconv.Unquote(m[1])
+		return unquoteErr == nil && anyTerm(filter, func(e Expression) bool {
+			list, ok := e.(*List)
+			return ok && list != nil && string(list.Type) == m[2] && slices.Contains(list.Values, element)
+		})
+	}
+	return false
+}
+
+// holdsText reports whether a term is the text given, as a constant or as an element of a list.
+func holdsText(e Expression, text string) bool {
+	switch term := e.(type) {
+	case *AnyValue:
+		return term != nil && term.Value == text
+	case *StringValue:
+		return term != nil && term.Value == text
+	case *List:
+		return term != nil && slices.Contains(term.Values, text)
+	}
+	return false
+}
+
+// anyComparison reports whether a call of two arguments anywhere in a tree compares a field, on
+// either side, against a term the predicate accepts.
+func anyComparison(filter *Call, predicate func(ref *FieldRef, other Expression) bool) bool {
+	return anyTerm(filter, func(e Expression) bool {
+		call, ok := e.(*Call)
+		if !ok || call == nil || len(call.Args) != 2 {
+			return false
+		}
+		for i, arg := range call.Args {
+			if ref, ok := arg.(*FieldRef); ok && ref != nil && predicate(ref, call.Args[1-i]) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// anyTerm reports whether a tree holds a term, itself included, that the predicate accepts.
+func anyTerm(e Expression, predicate func(Expression) bool) bool {
+	if predicate(e) {
+		return true
+	}
+	call, ok := e.(*Call)
+	if !ok || call == nil {
+		return false
+	}
+	return slices.ContainsFunc(call.Args, func(arg Expression) bool { return anyTerm(arg, predicate) })
+}
+
+// treeBuilder turns fuzz input into a tree. Each byte it reads is one choice, taken modulo the
+// number of options, and an exhausted input chooses 0 from then on, which ends every branch, so
+// every input is a finite tree.
+type treeBuilder struct {
+	data []byte
+	// huge is whether the tree holds its one huge list already; more only make an input slow.
+	huge bool
+}
+
+func buildTree(data []byte) *Call {
+	b := &treeBuilder{data: data}
+	return b.call(0)
+}
+
+func (b *treeBuilder) next() int {
+	if len(b.data) == 0 {
+		return 0
+	}
+	choice := int(b.data[0])
+	b.data = b.data[1:]
+	return choice
+}
+
+func choose[T any](b *treeBuilder, options []T) T {
+	return options[b.next()%len(options)]
+}
+
+// The pools mix what a filter may hold with what it may not: an operator and a level no version
+// defines, keys and text that are empty, and text that reads as every type and as none.
+var (
+	fuzzOperators  = append(append([]Operator{}, operators...), "", "xor")
+	fuzzLevels     = append(append([]Level{}, levels...), "", "trace")
+	fuzzValueTypes = append(append([]ValueType{}, valueTypes...), "", "timestamp")
+	fuzzKeys       = []string{"http.method", "", "service.name", "a.b.c", "ключ"}
+	fuzzTexts      = []string{
+		"", "0", "-1", "42", "0.25", "NaN", "true", "false", "2s", "1h30m", "-5ms",
+		"2026-01-02T03:04:05Z", "server", "error", "banana", ".*", "^a", `\bx`, "a*?", "(?i)a", "(", "ü",
+	}
+	fuzzInstants = []time.Time{{}, time.Unix(0, 0).UTC(), time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)}
+)
+
+// call builds a call. The first choice of a call is its operator, so the seeds above start with an
+// index into operators; the second is how many arguments it takes, up to four.
+func (b *treeBuilder) call(depth int) *Call {
+	call := &Call{Op: choose(b, fuzzOperators)}
+	n := b.next() % 5
+	for range n {
+		call.Args = append(call.Args, b.term(depth))
+	}
+	return call
+}
+
+// term builds one argument. Nesting is bounded only by the input, well past MaxNestingDepth, since
+// refusing a tree too deep without overflowing the stack is part of what is being checked.
+func (b *treeBuilder) term(depth int) Expression {
+	switch b.next() % 19 {
+	case 0:
+		if depth > 4*MaxNestingDepth {
+			return nil
+		}
+		return b.call(depth + 1)
+	case 1:
+		return nil
+	case 2:
+		return choose(b, []Expression{
+			(*Call)(nil), (*AttributeRef)(nil), (*FieldRef)(nil), (*NestedRef)(nil), (*AnyValue)(nil),
+			(*StringValue)(nil), (*IntValue)(nil), (*DoubleValue)(nil), (*BoolValue)(nil),
+			(*DurationValue)(nil), (*TimestampValue)(nil), (*List)(nil),
+		})
+	case 3:
+		return &AttributeRef{Key: choose(b, fuzzKeys), Level: choose(b, fuzzLevels)}
+	case 4:
+		field := choose(b, fields)
+		if b.next()%4 == 0 {
+			return &FieldRef{Level: choose(b, fuzzLevels), Name: field.Name}
+		}
+		return &FieldRef{Level: field.Level, Name: field.Name}
+	case 5:
+		return &NestedRef{Level: choose(b, fuzzLevels)}
+	case 6, 7:
+		return &AnyValue{Value: choose(b, fuzzTexts)}
+	case 8:
+		return &StringValue{Value: choose(b, fuzzTexts)}
+	case 9:
+		return &IntValue{Value: int64(b.next()) - 128}
+	case 10:
+		return &DoubleValue{Value: float64(b.next()) / 8}
+	case 11:
+		return &BoolValue{Value: b.next()%2 == 0}
+	case 12:
+		return &DurationValue{Value: time.Duration(b.next()-64) * time.Millisecond}
+	case 13:
+		return &TimestampValue{Value: choose(b, fuzzInstants)}
+	case 14, 15:
+		list := &List{Type: choose(b, fuzzValueTypes)}
+		for range b.next() % 6 {
+			list.Values = append(list.Values, choose(b, fuzzTexts))
+		}
+		return list
+	case 16:
+		// A huge list, which every stage walks element by element.
+		if b.huge {
+			return nil
+		}
+		b.huge = true
+		list := &List{Type: choose(b, fuzzValueTypes)}
+		text := choose(b, fuzzTexts)
+		list.Values = make([]string, 1<<12)
+		for i := range list.Values {
+			list.Values[i] = text
+		}
+		return list
+	default:
+		// A chain of one operator, deeper than a filter may nest.
+		op := choose(b, []Operator{OpAnd, OpNot, OpOr})
+		var chain Expression = &Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "k"}}}
+		for range MaxNestingDepth + b.next()%3 - 1 {
+			args := []Expression{chain}
+			if op != OpNot {
+				args = append(args, &Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "k"}}})
+			}
+			chain = &Call{Op: op, Args: args}
+		}
+		return chain
+	}
+}
+
+func TestBuildTree(t *testing.T) {
+	// An exhausted input chooses the first of everything.
+	assert.Equal(t, &Call{Op: fuzzOperators[0]}, buildTree(nil))
+	// and(<a chain of and one level too deep>, <any value>)
+	deep := buildTree([]byte{0, 2, 18, 0, 2, 6, 0})
+	require.Len(t, deep.Args, 2)
+	assert.ErrorIs(t, ValidateFilter(deep), ErrTooDeeplyNested)
+	_, err := ResolveConstants(deep)
+	assert.ErrorIs(t, err, ErrTooDeeplyNested)
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"cmp"
+	"math"
+	"strings"
+)
+
+// Implies reports whether every span a matches, b matches too — that a is at least as narrow as b —
+// which is what lets a client answer a narrower search from the results of a wider one it already
+// holds rather than asking storage again. A search is strictly narrower when it implies the other
+// and is not implied by it.
+//
+// The answer is true only when an argument proves it and false whenever none is found, so false
+// means "not known", never "known not to". The arguments are syntactic, taken over the clauses of
+// both filters as Satisfies takes them, and about values where one comparison bounds another:
+//
+//   - a range inside another, over a duration, an instant, a number or text: `duration > 2s`
+//     implies `duration > 1s`, and `duration > 2s and duration < 5s` implies `duration < 10s`;
+//   - a list inside another: `kind in (server)` implies `kind in (server, consumer)`, and `eq`
+//     implies `in` as the list of one element it is;
+//   - a value that is not excluded: `kind = server` implies `kind != client`, and `not_in (a, b)`
+//     implies `not_in (a)`;
+//   - a comparison that holds implies its reference exists, and a quantifier implies another over
+//     the same collection whose predicate its own implies.
+//
+// Values are compared as the typed constants ResolveConstants produces, so both filters are
+// finalized first and one that is not well formed implies nothing. An untyped constant, which
+// matches whatever type storage holds, bounds nothing. A nil filter matches every span: it implies
+// only another nil filter, and every filter implies it.
+func Implies(a, b *Call, opts ...Option) bool {
+	if b == nil {
+		return a == nil || ValidateFilter(a, opts...) == nil
+	}
+	if a == nil {
+		return false
+	}
+	x, err := Finalize(a, opts...)
+	if err != nil {
+		return false
+	}
+	y, err := Finalize(b, opts...)
+	if err != nil {
+		return false
+	}
+	return prover{fields: newOptions(opts).fields}.implies(x, y)
+}
+
+// prover carries the fields a list's elements are read against through an implication, as
+// resolver does for resolution.
+type prover struct {
+	fields *FieldRegistry
+}
+
+// implies decides implication over finalized trees. The order of the cases matters: a clause is
+// split into its conjuncts before a filter is split into its disjuncts, so that each step keeps the
+// proof obligation exact rather than strengthening it.
+func (p prover) implies(filter, clause *Call) bool {
+	switch {
+	case clause.Op == OpAnd:
+		return allArgs(clause, func(c *Call) bool { return p.implies(filter, c) })
+	case filter.Op == OpOr:
+		return allArgs(filter, func(f *Call) bool { return p.implies(f, clause) })
+	case filter.Op == OpAnd && anyArg(filter, func(f *Call) bool { return p.implies(f, clause) }):
+		return true
+	case clause.Op == OpOr:
+		return anyArg(clause, func(c *Call) bool { return p.implies(filter, c) })
+	default:
+		return sameTerm(filter, clause) || p.impliesAtom(filter, clause)
+	}
+}
+
+// impliesAtom decides implication between two calls neither of which is a conjunction or a
+// disjunction to split.
+func (p prover) impliesAtom(filter, clause *Call) bool {
+	switch {
+	case filter.Op == OpNot && clause.Op == OpNot:
+		// not x implies not y exactly when y implies x.
+		f, ok := filter.Args[0].(*Call)
+		c, ok2 := clause.Args[0].(*Call)
+		return ok && ok2 && p.implies(c, f)
+	case filter.Op == OpSome && clause.Op == OpSome:
+		f, ok := filter.Args[1].(*Call)
+		c, ok2 := clause.Args[1].(*Call)
+		return ok && ok2 && sameTerm(filter.Args[0], clause.Args[0]) && p.implies(f, c)
+	case clause.Op == OpExists:
+		// A comparison that holds has a value to compare, and so does a pattern that matches.
+		switch filter.Op {
+		case OpEq, OpGt, OpGte, OpLt, OpLte, OpIn, OpRegex:
+			return sameTerm(filter.Args[0], clause.Args[0])
+		}
+		return false
+	}
+	if !sameTerm(filter.Args[0], clause.Args[0]) {
+		return false
+	}
+	f, ok := p.valuesOf(filter)
+	if !ok {
+		return false
+	}
+	c, ok := p.valuesOf(clause)
+	return ok && f.within(c)
+}
+
+// valueSet is the set of values one comparison admits of the reference it reads: a finite set of
+// points for eq and in, a range for the ordered comparisons, or everything but a finite set for ne
+// and not_in.
+type valueSet struct {
+	kind      setKind
+	points    []Expression
+	low, high *bound
+}
+
+type setKind int
+
+const (
+	setPoints setKind = iota
+	setRange
+	setExcept
+)
+
+// bound is one end of a range; a nil bound is an open end.
+type bound struct {
+	value Expression
+	// strict is whether the end is excluded, as it is for gt and lt.
+	strict bool
+}
+
+// valuesOf reads the set a comparison admits. A list is read as the typed constants its elements
+// stand for, so `in ("1.50")` and `eq 1.5` over a double are the same point.
+func (p prover) valuesOf(call *Call) (valueSet, bool) {
+	if len(call.Args) != 2 {
+		return valueSet{}, false
+	}
+	switch call.Op {
+	case OpEq:
+		return valueSet{kind: setPoints, points: call.Args[1:]}, true
+	case OpNe:
+		return valueSet{kind: setExcept, points: call.Args[1:]}, true
+	case OpGt, OpGte:
+		return valueSet{kind: setRange, low: &bound{value: call.Args[1], strict: call.Op == OpGt}}, true
+	case OpLt, OpLte:
+		return valueSet{kind: setRange, high: &bound{value: call.Args[1], strict: call.Op == OpLt}}, true
+	case OpIn, OpNotIn:
+		points, ok := p.elements(call.Args[0], call.Args[1])
+		if !ok {
+			return valueSet{}, false
+		}
+		if call.Op == OpIn {
+			return valueSet{kind: setPoints, points: points}, true
+		}
+		return valueSet{kind: setExcept, points: points}, true
+	}
+	return valueSet{}, false
+}
+
+func (p prover) elements(subject, arg Expression) ([]Expression, bool) {
+	list, ok := arg.(*List)
+	if !ok || list == nil {
+		return nil, false
+	}
+	var fieldType FieldType
+	if ref, ok := subject.(*FieldRef); ok && ref != nil {
+		field, _ := p.fields.Lookup(ref.Level, ref.Name)
+		fieldType = field.Type
+	}
+	points := make([]Expression, len(list.Values))
+	for i, element := range list.Values {
+		value, err := ReadElement(list, fieldType, element)
+		if err != nil {
+			return nil, false
+		}
+		points[i] = value
+	}
+	return points, true
+}
+
+// within reports whether every value s admits, other admits too.
+func (s valueSet) within(other valueSet) bool {
+	switch {
+	case s.kind == setPoints:
+		for _, point := range s.points {
+			if !other.admits(point) {
+				return false
+			}
+		}
+		return true
+	case s.kind == setRange && other.kind == setRange:
+		return covers(other.low, s.low, 1) && covers(other.high, s.high, -1)
+	case s.kind == setRange && other.kind == setExcept:
+		for _, point := range other.points {
+			if s.admitsAny(point) {
+				return false
+			}
+		}
+		return true
+	case s.kind == setExcept && other.kind == setExcept:
+		// Everything but A lies within everything but B when B is part of A.
+		for _, point := range other.points {
+			if !(valueSet{kind: setPoints, points: s.points}).admits(point) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// admits reports whether a set is known to hold a value.
+func (s valueSet) admits(value Expression) bool {
+	switch s.kind {
+	case setPoints:
+		for _, point := range s.points {
+			if order, ok := compareConstants(value, point); ok && order == 0 {
+				return true
+			}
+		}
+		return false
+	case setExcept:
+		for _, point := range s.points {
+			if order, ok := compareConstants(value, point); !ok || order == 0 {
+				return false
+			}
+		}
+		return true
+	default:
+		return s.low.admits(value, 1) && s.high.admits(value, -1)
+	}
+}
+
+// admitsAny reports whether a range might hold a value, which is whether it is not known to exclude it.
+func (s valueSet) admitsAny(value Expression) bool {
+	return !s.low.excludes(value, 1) && !s.high.excludes(value, -1)
+}
+
+// admits reports whether a value is known to lie on the inner side of a bound. side is 1 for a lower
+// bound, whose inner side is above it, and -1 for an upper one.
+func (b *bound) admits(value Expression, side int) bool {
+	if b == nil {
+		return true
+	}
+	order, ok := compareConstants(value, b.value)
+	if !ok {
+		return false
+	}
+	return order*side > 0 || (order == 0 && !b.strict)
+}
+
+// excludes reports whether a value is known to lie on the outer side of a bound.
+func (b *bound) excludes(value Expression, side int) bool {
+	if b == nil {
+		return false
+	}
+	order, ok := compareConstants(value, b.value)
+	if !ok {
+		return false
+	}
+	return order*side < 0 || (order == 0 && b.strict)
+}
+
+// covers reports whether the bound outer lets through everything inner does, on the given side.
+func covers(outer, inner *bound, side int) bool {
+	if outer == nil {
+		return true
+	}
+	if inner == nil {
+		return false
+	}
+	order, ok := compareConstants(inner.value, outer.value)
+	if !ok {
+		return false
+	}
+	return order*side > 0 || (order == 0 && (inner.strict || !outer.strict))
+}
+
+// compareConstants orders two typed constants of one type, and reports false where they have no
+// order to be compared within: constants of two types, an untyped constant, and NaN. Two booleans
+// compare for equality only, and an unequal pair orders arbitrarily, which is all a set of points
+// or of exceptions asks of them.
+func compareConstants(a, b Expression) (int, bool) {
+	if isMissing(a) || isMissing(b) {
+		return 0, false
+	}
+	switch x := a.(type) {
+	case *IntValue:
+		if y, ok := b.(*IntValue); ok {
+			return cmp.Compare(x.Value, y.Value), true
+		}
+	case *DoubleValue:
+		if y, ok := b.(*DoubleValue); ok && !math.IsNaN(x.Value) && !math.IsNaN(y.Value) {
+			return cmp.Compare(x.Value, y.Value), true
+		}
+	case *DurationValue:
+		if y, ok := b.(*DurationValue); ok {
+			return cmp.Compare(x.Value, y.Value), true
+		}
+	case *TimestampValue:
+		if y, ok := b.(*TimestampValue); ok {
+			return x.Value.Compare(y.Value), true
+		}
+	case *StringValue:
+		if y, ok := b.(*StringValue); ok {
+			return strings.Compare(x.Value, y.Value), true
+		}
+	case *BoolValue:
+		if y, ok := b.(*BoolValue); ok {
+			if x.Value == y.Value {
+				return 0, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+// Difference lists what changed between two filters, clause by clause: a clause is a conjunct at the
+// root, and a filter that is not a conjunction is one clause.
+type Difference struct {
+	// Added holds the clauses of the second filter the first does not have.
+	Added []*Call
+	// Removed holds the clauses of the first filter the second does not have.
+	Removed []*Call
+}
+
+// Diff compares two filters clause by clause, which is what a user is shown to say how a search
+// changed — "added duration > 2s, removed kind = server". Both are finalized first, so a clause
+// written in another orientation or another spelling of the same constant is the same clause, and a
+// filter that is not well formed is refused as Finalize refuses it. A nil filter has no clauses.
+//
+// A clause that changed appears as removed and added. Whether the new filter is narrower, which a
+// changed clause can make it, is what Implies answers.
+func Diff(a, b *Call, opts ...Option) (Difference, error) {
+	x, err := clauses(a, opts)
+	if err != nil {
+		return Difference{}, err
+	}
+	y, err := clauses(b, opts)
+	if err != nil {
+		return Difference{}, err
+	}
+	return Difference{Added: missingFrom(y, x), Removed: missingFrom(x, y)}, nil
+}
+
+func clauses(filter *Call, opts []Option) ([]*Call, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	finalized, err := Finalize(filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if finalized.Op != OpAnd {
+		return []*Call{finalized}, nil
+	}
+	out := make([]*Call, 0, len(finalized.Args))
+	for _, arg := range finalized.Args {
+		out = append(out, arg.(*Call))
+	}
+	return out, nil
+}
+
+// missingFrom returns the clauses of from that other has no clause the same as.
+func missingFrom(from, other []*Call) []*Call {
+	var missing []*Call
+	for _, clause := range from {
+		found := false
+		for _, candidate := range other {
+			if sameTerm(clause, candidate) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, clause)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compare(op Operator, left, right Expression) *Call {
+	return &Call{Op: op, Args: []Expression{left, right}}
+}
+
+func seconds(n int) *AnyValue {
+	return &AnyValue{Value: (time.Duration(n) * time.Second).String()}
+}
+
+func TestImplies(t *testing.T) {
+	duration := spanField(SpanFieldDuration)
+	kind := spanField(SpanFieldKind)
+	startTime := spanField(SpanFieldStartTime)
+	code := &AttributeRef{Key: "http.status_code", Level: LevelSpan}
+	ints := func(values ...string) *List { return &List{Values: values, Type: ValueTypeInt} }
+	words := func(values ...string) *List { return &List{Values: values} }
+	errorEvent := &Call{Op: OpSome, Args: []Expression{
+		&NestedRef{Level: LevelEvent},
+		and(
+			eq(&FieldRef{Level: LevelEvent, Name: EventFieldName}, &AnyValue{Value: "exception"}),
+			&Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "exception.type", Level: LevelEvent}}},
+		),
+	}}
+	tests := []struct {
+		name     string
+		a, b     *Call
+		expected bool
+	}{
+		{name: "a narrower lower bound", a: compare(OpGt, duration, seconds(2)), b: compare(OpGt, duration, seconds(1)), expected: true},
+		{name: "a wider lower bound", a: compare(OpGt, duration, seconds(1)), b: compare(OpGt, duration, seconds(2)), expected: false},
+		{name: "an exclusive bound within an inclusive one", a: compare(OpGt, duration, seconds(2)), b: compare(OpGte, duration, seconds(2)), expected: true},
+		{name: "an inclusive bound beyond an exclusive one", a: compare(OpGte, duration, seconds(2)), b: compare(OpGt, duration, seconds(2)), expected: false},
+		{
+			name:     "a range inside a range",
+			a:        and(compare(OpGt, duration, seconds(2)), compare(OpLt, duration, seconds(5))),
+			b:        and(compare(OpGte, duration, seconds(1)), compare(OpLte, duration, seconds(10))),
+			expected: true,
+		},
+		{
+			name:     "a range reaching past a range",
+			a:        and(compare(OpGt, duration, seconds(2)), compare(OpLt, duration, seconds(15))),
+			b:        and(compare(OpGte, duration, seconds(1)), compare(OpLte, duration, seconds(10))),
+			expected: false,
+		},
+		{name: "a bound written the other way round", a: compare(OpLt, seconds(2), duration), b: compare(OpGt, duration, seconds(1)), expected: true},
+		{name: "a point inside a range", a: eq(duration, seconds(3)), b: compare(OpLte, duration, seconds(3)), expected: true},
+		{
+			name:     "a narrower time range",
+			a:        compare(OpGte, startTime, &AnyValue{Value: "2026-08-16T20:00:00+02:00"}),
+			b:        compare(OpGte, startTime, &AnyValue{Value: "2026-08-16T17:00:00Z"}),
+			expected: true,
+		},
+		{name: "a range over integers", a: compare(OpGte, code, &IntValue{Value: 500}), b: compare(OpGt, code, &IntValue{Value: 499}), expected: true},
+		{name: "an integer bound against a double", a: compare(OpGte, code, &IntValue{Value: 500}), b: compare(OpGt, code, &DoubleValue{Value: 499}), expected: false},
+		{name: "an untyped bound, which bounds nothing", a: compare(OpGt, code, &AnyValue{Value: "500"}), b: compare(OpGt, code, &AnyValue{Value: "400"}), expected: false},
+		{name: "bounds on different values", a: compare(OpGt, duration, seconds(2)), b: compare(OpGt, spanField(SpanFieldEventCount), &IntValue{Value: 1}), expected: false},
+		{name: "a list inside a list", a: &Call{Op: OpIn, Args: []Expression{kind, words("server")}}, b: &Call{Op: OpIn, Args: []Expression{kind, words("server", "consumer")}}, expected: true},
+		{name: "a list reaching past a list", a: &Call{Op: OpIn, Args: []Expression{kind, words("server", "client")}}, b: &Call{Op: OpIn, Args: []Expression{kind, words("server", "consumer")}}, expected: false},
+		{name: "eq inside a list", a: eq(kind, &AnyValue{Value: "server"}), b: &Call{Op: OpIn, Args: []Expression{kind, words("consumer", "server")}}, expected: true},
+		{name: "a list of one as eq", a: &Call{Op: OpIn, Args: []Expression{code, ints("500")}}, b: eq(code, &IntValue{Value: 500}), expected: true},
+		{name: "list elements read as their type", a: &Call{Op: OpIn, Args: []Expression{code, ints("0500")}}, b: &Call{Op: OpIn, Args: []Expression{code, ints("500", "503")}}, expected: true},
+		{name: "a list inside a range", a: &Call{Op: OpIn, Args: []Expression{code, ints("500", "503")}}, b: compare(OpGte, code, &IntValue{Value: 500}), expected: true},
+		{name: "a value that is not excluded", a: eq(kind, &AnyValue{Value: "server"}), b: &Call{Op: OpNe, Args: []Expression{kind, &AnyValue{Value: "client"}}}, expected: true},
+		{name: "a value that is excluded", a: eq(kind, &AnyValue{Value: "server"}), b: &Call{Op: OpNe, Args: []Expression{kind, &AnyValue{Value: "server"}}}, expected: false},
+		{name: "a range that excludes a value", a: compare(OpGt, code, &IntValue{Value: 499}), b: &Call{Op: OpNotIn, Args: []Expression{code, ints("200", "404")}}, expected: true},
+		{name: "a range that holds an excluded value", a: compare(OpGt, code, &IntValue{Value: 299}), b: &Call{Op: OpNotIn, Args: []Expression{code, ints("200", "404")}}, expected: false},
+		{name: "fewer exclusions within more", a: &Call{Op: OpNotIn, Args: []Expression{code, ints("200", "404")}}, b: &Call{Op: OpNe, Args: []Expression{code, &IntValue{Value: 404}}}, expected: true},
+		{name: "more exclusions within fewer", a: &Call{Op: OpNe, Args: []Expression{code, &IntValue{Value: 404}}}, b: &Call{Op: OpNotIn, Args: []Expression{code, ints("200", "404")}}, expected: false},
+		{name: "a comparison implies its reference exists", a: compare(OpGt, code, &IntValue{Value: 499}), b: &Call{Op: OpExists, Args: []Expression{code}}, expected: true},
+		{name: "an inequality does not", a: &Call{Op: OpNe, Args: []Expression{code, &IntValue{Value: 404}}}, b: &Call{Op: OpExists, Args: []Expression{code}}, expected: false},
+		{
+			name:     "a quantifier over a narrower predicate",
+			a:        errorEvent,
+			b:        &Call{Op: OpSome, Args: []Expression{&NestedRef{Level: LevelEvent}, eq(&FieldRef{Level: LevelEvent, Name: EventFieldName}, &AnyValue{Value: "exception"})}},
+			expected: true,
+		},
+		{
+			name:     "a quantifier over another collection",
+			a:        errorEvent,
+			b:        &Call{Op: OpSome, Args: []Expression{&NestedRef{Level: LevelLink}, &Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "exception.type", Level: LevelLink}}}}},
+			expected: false,
+		},
+		{
+			name:     "a negation of something wider",
+			a:        &Call{Op: OpNot, Args: []Expression{compare(OpGt, duration, seconds(1))}},
+			b:        &Call{Op: OpNot, Args: []Expression{compare(OpGt, duration, seconds(2))}},
+			expected: true,
+		},
+		{
+			name:     "a conjunct added",
+			a:        and(eq(kind, &AnyValue{Value: "server"}), compare(OpGt, duration, seconds(2))),
+			b:        eq(kind, &AnyValue{Value: "server"}),
+			expected: true,
+		},
+		{
+			name:     "every branch of a disjunction narrower",
+			a:        or(compare(OpGt, duration, seconds(3)), compare(OpGte, duration, seconds(5))),
+			b:        compare(OpGt, duration, seconds(2)),
+			expected: true,
+		},
+		{name: "no filter implies no filter", expected: true},
+		{name: "a filter implies no filter", a: eq(kind, &AnyValue{Value: "server"}), expected: true},
+		{name: "no filter implies nothing narrower", b: eq(kind, &AnyValue{Value: "server"}), expected: false},
+		{name: "a malformed filter implies nothing", a: and(eq(kind, &AnyValue{Value: "server"})), b: nil, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Implies(test.a, test.b))
+		})
+	}
+}
+
+// TestImplies_WithAttributes shows where a schema helps: an attribute's constant is typed, so it
+// bounds what an untyped one cannot.
+func TestImplies_WithAttributes(t *testing.T) {
+	code := &AttributeRef{Key: "http.response.status_code", Level: LevelSpan}
+	a := compare(OpGt, code, &AnyValue{Value: "500"})
+	b := compare(OpGt, code, &AnyValue{Value: "400"})
+	assert.False(t, Implies(a, b))
+	assert.True(t, Implies(a, b, WithAttributes(semanticConventions(t))))
+}
+
+// TestImplies_IsAPreorder pins the two properties a cache relies on: every filter implies itself,
+// and implication carries through a chain.
+func TestImplies_IsAPreorder(t *testing.T) {
+	duration := spanField(SpanFieldDuration)
+	chain := []*Call{
+		and(compare(OpGt, duration, seconds(5)), eq(spanField(SpanFieldKind), &AnyValue{Value: "server"})),
+		compare(OpGt, duration, seconds(5)),
+		compare(OpGte, duration, seconds(5)),
+		compare(OpGt, duration, seconds(1)),
+	}
+	for i, a := range chain {
+		assert.True(t, Implies(a, a), "filter %d implies itself", i)
+		for _, b := range chain[i:] {
+			assert.True(t, Implies(a, b))
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	kind := eq(spanField(SpanFieldKind), &AnyValue{Value: "server"})
+	slow := compare(OpGt, spanField(SpanFieldDuration), seconds(2))
+	slower := compare(OpGt, spanField(SpanFieldDuration), seconds(5))
+	route := eq(&AttributeRef{Key: "http.route"}, &AnyValue{Value: "/cart"})
+
+	finalized := func(call *Call) *Call {
+		out, err := Finalize(call)
+		require.NoError(t, err)
+		return out
+	}
+
+	diff, err := Diff(and(kind, slow), and(compare(OpLt, seconds(5), spanField(SpanFieldDuration)), kind, route))
+	require.NoError(t, err)
+	assert.Equal(t, []*Call{finalized(slower), finalized(route)}, diff.Added)
+	assert.Equal(t, []*Call{finalized(slow)}, diff.Removed)
+
+	diff, err = Diff(kind, and(eq(&AnyValue{Value: "server"}, spanField(SpanFieldKind)), route))
+	require.NoError(t, err)
+	assert.Equal(t, Difference{Added: []*Call{finalized(route)}}, diff, "the same clause in another orientation is unchanged")
+
+	diff, err = Diff(nil, kind)
+	require.NoError(t, err)
+	assert.Equal(t, Difference{Added: []*Call{finalized(kind)}}, diff)
+
+	diff, err = Diff(or(kind, slow), nil)
+	require.NoError(t, err)
+	assert.Equal(t, Difference{Removed: []*Call{finalized(or(kind, slow))}}, diff, "a disjunction is one clause")
+
+	_, err = Diff(kind, and(kind))
+	require.Error(t, err)
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A filter's JSON form is the proto3 JSON form of jaeger.expression.v1.Call, which is what the
+// query API takes as its filter parameter and what a saved search is naturally written as. It is
+// implemented here, against the standard library alone, so that a tool reading or writing filters
+// does not link protobuf to do it; the Go types stay independent of the generated ones.
+//
+//	{"op":"eq","args":[{"attr":{"key":"http.status_code"}},{"scalar":{"value":"500"}}]}
+
+// Each Expression is an object holding exactly one of these members, named as the oneof in the
+// proto names them and as the published schema's oneOf requires them.
+const (
+	memberAttr   = "attr"
+	memberField  = "field"
+	memberNested = "nested"
+	memberScalar = "scalar"
+	memberList   = "list"
+	memberCall   = "call"
+)
+
+var members = []string{memberAttr, memberField, memberNested, memberScalar, memberList, memberCall}
+
+// messageMembers lists the members of each message in the form, by the message's name in the
+// proto, which is what a member outside them is refused against.
+var messageMembers = map[string][]string{
+	"Expression":         members,
+	"Call":               {"op", "args"},
+	"AttributeReference": {"key", "level"},
+	"FieldReference":     {"name", "level"},
+	"NestedReference":    {"level"},
+	"Scalar":             {"value", "type"},
+	"List":               {"values", "type"},
+}
+
+// MarshalJSON writes a filter in the proto3 JSON form of jaeger.expression.v1.Call. A typed constant
+// carries its type; a duration, a timestamp and an AnyValue travel as an unhinted scalar, since the
+// wire has no type for the first two (§5.4), so reading one back yields an AnyValue that finalizing
+// resolves against its field again (see DurationValue).
+//
+// It writes any tree it can represent, valid or not, so a tool can save a filter it has yet to
+// finish, and refuses only what the form has no spelling for: a missing term, and nesting deeper
+// than a filter may, which is also what keeps a tree that contains itself from recursing forever.
+func (c *Call) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCall(&buf, c, 1); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCall(buf *bytes.Buffer, call *Call, depth int) error {
+	if call == nil {
+		return errors.New("filter has a missing predicate")
+	}
+	if depth > MaxNestingDepth {
+		return ErrTooDeeplyNested
+	}
+	buf.WriteString(`{"op":`)
+	writeString(buf, string(call.Op))
+	buf.WriteString(`,"args":[`)
+	for i, arg := range call.Args {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeExpression(buf, arg, depth); err != nil {
+			return err
+		}
+	}
+	buf.WriteString(`]}`)
+	return nil
+}
+
+func writeExpression(buf *bytes.Buffer, e Expression, depth int) error {
+	if isMissing(e) {
+		return errors.New("filter has a missing term")
+	}
+	switch term := e.(type) {
+	case *Call:
+		buf.WriteString(`{"call":`)
+		if err := writeCall(buf, term, depth+1); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	case *AttributeRef:
+		buf.WriteString(`{"attr":{"key":`)
+		writeString(buf, term.Key)
+		writeOptional(buf, "level", string(term.Level))
+		buf.WriteString(`}}`)
+	case *FieldRef:
+		buf.WriteString(`{"field":{"name":`)
+		writeString(buf, term.Name)
+		buf.WriteString(`,"level":`)
+		writeString(buf, string(term.Level))
+		buf.WriteString(`}}`)
+	case *NestedRef:
+		buf.WriteString(`{"nested":{"level":`)
+		writeString(buf, string(term.Level))
+		buf.WriteString(`}}`)
+	case *List:
+		buf.WriteString(`{"list":{"values":[`)
+		for i, value := range term.Values {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, value)
+		}
+		buf.WriteByte(']')
+		writeOptional(buf, "type", string(term.Type))
+		buf.WriteString(`}}`)
+	default:
+		value, valueType := scalarOf(term)
+		buf.WriteString(`{"scalar":{"value":`)
+		writeString(buf, value)
+		writeOptional(buf, "type", string(valueType))
+		buf.WriteString(`}}`)
+	}
+	return nil
+}
+
+// scalarOf spells a constant as a scalar: its text, and the type that text is read as.
+func scalarOf(e Expression) (string, ValueType) {
+	switch value := e.(type) {
+	case *StringValue:
+		return value.Value, ValueTypeString
+	case *IntValue:
+		return strconv.FormatInt(value.Value, 10), ValueTypeInt
+	case *DoubleValue:
+		return strconv.FormatFloat(value.Value, 'g', -1, 64), ValueTypeDouble
+	case *BoolValue:
+		return strconv.FormatBool(value.Value), ValueTypeBool
+	case *DurationValue:
+		return value.Value.String(), ""
+	case *TimestampValue:
+		return value.Value.Format(time.RFC3339Nano), ""
+	case *AnyValue:
+		return value.Value, ""
+	}
+	panic(fmt.Sprintf("expression: no scalar form for %T", e))
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	// Marshaling a string cannot fail.
+	text, _ := json.Marshal(s)
+	buf.Write(text)
+}
+
+// writeOptional writes a member proto3 JSON leaves out when it holds the empty string, as it does
+// for an unqualified attribute's level and an unhinted scalar's type.
+func writeOptional(buf *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+	buf.WriteString(`,"` + name + `":`)
+	writeString(buf, value)
+}
+
+// UnmarshalJSON reads a filter in the proto3 JSON form of jaeger.expression.v1.Call, as protojson
+// would: a member is named exactly, a member the message does not define is refused, and a null
+// stands for the member's default.
+//
+// An Expression holds exactly one of attr, field, nested, scalar, list and call, which is the oneOf
+// the published schema states: one holding none of them is refused, and so is one holding two. A
+// member outside them is refused wherever it appears, as protojson refuses it, which is also what
+// the schema's oneOf does to a term a later version adds when it is the only member. A scalar's type
+// decides the node it becomes, so its text has to read as that type; an unhinted one becomes an
+// AnyValue.
+//
+// Nothing else is checked here: which operators, levels and fields exist, and how many arguments an
+// operator takes, are ValidateFilter's to answer, as they are for a filter built in Go. What is read
+// is ready to finalize.
+func (c *Call) UnmarshalJSON(data []byte) error {
+	call, err := readCall(data, "", 1)
+	if err != nil {
+		return err
+	}
+	*c = *call
+	return nil
+}
+
+// readCall reads one Call. path locates it for the error messages, in the member names the JSON
+// uses (`args[1].call.args[0]`), and is empty at the root.
+func readCall(data []byte, path string, depth int) (*Call, error) {
+	if depth > MaxNestingDepth {
+		return nil, ErrTooDeeplyNested
+	}
+	var wire struct {
+		Op   string            `json:"op"`
+		Args []json.RawMessage `json:"args"`
+	}
+	if err := readObject(data, path, &wire, "Call"); err != nil {
+		return nil, err
+	}
+	call := &Call{Op: Operator(wire.Op)}
+	for i, raw := range wire.Args {
+		arg, err := readExpression(raw, join(path, fmt.Sprintf("args[%d]", i)), depth)
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+	}
+	return call, nil
+}
+
+func readExpression(data []byte, path string, depth int) (Expression, error) {
+	fields, err := readMembers(data, path, "Expression")
+	if err != nil {
+		return nil, err
+	}
+	var held []string
+	for _, name := range members {
+		if value, ok := fields[name]; ok && !isNull(value) {
+			held = append(held, name)
+		}
+	}
+	switch len(held) {
+	case 0:
+		return nil, fmt.Errorf("%s holds none of %s, and an expression holds one", where(path), strings.Join(members, ", "))
+	case 1:
+	default:
+		return nil, fmt.Errorf("%s holds %s, and an expression holds only one", where(path), strings.Join(held, " and "))
+	}
+	member, data := held[0], fields[held[0]]
+	path = join(path, member)
+	switch member {
+	case memberCall:
+		return readCall(data, path, depth+1)
+	case memberAttr:
+		var wire struct {
+			Key   string `json:"key"`
+			Level string `json:"level"`
+		}
+		err := readObject(data, path, &wire, "AttributeReference")
+		return &AttributeRef{Key: wire.Key, Level: Level(wire.Level)}, err
+	case memberField:
+		var wire struct {
+			Name  string `json:"name"`
+			Level string `json:"level"`
+		}
+		err := readObject(data, path, &wire, "FieldReference")
+		return &FieldRef{Name: wire.Name, Level: Level(wire.Level)}, err
+	case memberNested:
+		var wire struct {
+			Level string `json:"level"`
+		}
+		err := readObject(data, path, &wire, "NestedReference")
+		return &NestedRef{Level: Level(wire.Level)}, err
+	case memberList:
+		var wire struct {
+			Values []string `json:"values"`
+			Type   string   `json:"type"`
+		}
+		err := readObject(data, path, &wire, "List")
+		return &List{Values: wire.Values, Type: ValueType(wire.Type)}, err
+	default:
+		var wire struct {
+			Value string `json:"value"`
+			Type  string `json:"type"`
+		}
+		if err := readObject(data, path, &wire, "Scalar"); err != nil {
+			return nil, err
+		}
+		return readScalar(wire.Value, ValueType(wire.Type), path)
+	}
+}
+
+// readScalar builds the node a scalar's type names, the reading ReadElement gives a list element.
+func readScalar(value string, t ValueType, path string) (Expression, error) {
+	if t == "" {
+		return &AnyValue{Value: value}, nil
+	}
+	if err := validateValueType(t); err != nil {
+		return nil, fmt.Errorf("%s: %w", where(path), err)
+	}
+	if err := readValue(t, value); err != nil {
+		return nil, fmt.Errorf("%s: %q does not read as %s: %w", where(path), value, t, err)
+	}
+	return typedValue(t, value)
+}
+
+// readObject decodes one message into wire, refusing a member the message does not define.
+func readObject(data []byte, path string, wire any, message string) error {
+	if _, err := readMembers(data, path, message); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, wire); err != nil {
+		return fmt.Errorf("%s: %w", where(path), err)
+	}
+	return nil
+}
+
+// readMembers reads an object's members, refusing one the message does not define. The names are
+// matched exactly, which encoding/json alone would not do: it also accepts "OP" for "op".
+func readMembers(data []byte, path, message string) (map[string]json.RawMessage, error) {
+	names := messageMembers[message]
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("%s is not an object", where(path))
+	}
+	for name := range fields {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("%s has unknown member %q; it holds %s", where(path), name, strings.Join(names, ", "))
+		}
+	}
+	return fields, nil
+}
+
+func join(path, member string) string {
+	if path == "" {
+		return member
+	}
+	return path + "." + member
+}
+
+func where(path string) string {
+	if path == "" {
+		return "filter"
+	}
+	return "filter member " + path
+}
+
+func isNull(data json.RawMessage) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
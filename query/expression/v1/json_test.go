@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallJSON(t *testing.T) {
+	startTime := &FieldRef{Level: LevelSpan, Name: SpanFieldStartTime}
+	tests := []struct {
+		name     string
+		filter   *Call
+		json     string
+		readBack *Call // what reading the JSON gives, where that is not filter itself
+	}{
+		{
+			name:   "the example the published schema gives",
+			filter: &Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "http.status_code"}, &AnyValue{Value: "500"}}},
+			json:   `{"op":"eq","args":[{"attr":{"key":"http.status_code"}},{"scalar":{"value":"500"}}]}`,
+		},
+		{
+			name: "every reference and every typed constant",
+			filter: and(
+				&Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "k", Level: LevelResource}, &StringValue{Value: "a\"b"}}},
+				&Call{Op: OpGt, Args: []Expression{&AttributeRef{Key: "n", Level: LevelSpan}, &IntValue{Value: -3}}},
+				&Call{Op: OpLt, Args: []Expression{&AttributeRef{Key: "r", Level: LevelSpan}, &DoubleValue{Value: 0.25}}},
+				&Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "b", Level: LevelSpan}, &BoolValue{Value: true}}},
+				&Call{Op: OpSome, Args: []Expression{
+					&NestedRef{Level: LevelEvent},
+					&Call{Op: OpEq, Args: []Expression{&FieldRef{Level: LevelEvent, Name: EventFieldName}, &StringValue{Value: "exception"}}},
+				}},
+				&Call{Op: OpIn, Args: []Expression{&AttributeRef{Key: "c"}, &List{Values: []string{"1", "2"}, Type: ValueTypeInt}}},
+			),
+			json: `{"op":"and","args":[` +
+				`{"call":{"op":"eq","args":[{"attr":{"key":"k","level":"resource"}},{"scalar":{"value":"a\"b","type":"string"}}]}},` +
+				`{"call":{"op":"gt","args":[{"attr":{"key":"n","level":"span"}},{"scalar":{"value":"-3","type":"int"}}]}},` +
+				`{"call":{"op":"lt","args":[{"attr":{"key":"r","level":"span"}},{"scalar":{"value":"0.25","type":"double"}}]}},` +
+				`{"call":{"op":"eq","args":[{"attr":{"key":"b","level":"span"}},{"scalar":{"value":"true","type":"bool"}}]}},` +
+				`{"call":{"op":"some","args":[{"nested":{"level":"event"}},` +
+				`{"call":{"op":"eq","args":[{"field":{"name":"name","level":"event"}},{"scalar":{"value":"exception","type":"string"}}]}}]}},` +
+				`{"call":{"op":"in","args":[{"attr":{"key":"c"}},{"list":{"values":["1","2"],"type":"int"}}]}}]}`,
+		},
+		{
+			name: "a duration and a timestamp, which travel unhinted",
+			filter: and(
+				&Call{Op: OpGt, Args: []Expression{spanDuration(), &DurationValue{Value: 1500 * time.Millisecond}}},
+				&Call{Op: OpGte, Args: []Expression{startTime, &TimestampValue{Value: time.Date(2026, 8, 16, 18, 0, 0, 5, time.UTC)}}},
+			),
+			json: `{"op":"and","args":[` +
+				`{"call":{"op":"gt","args":[{"field":{"name":"duration","level":"span"}},{"scalar":{"value":"1.5s"}}]}},` +
+				`{"call":{"op":"gte","args":[{"field":{"name":"startTime","level":"span"}},{"scalar":{"value":"2026-08-16T18:00:00.000000005Z"}}]}}]}`,
+			readBack: and(
+				&Call{Op: OpGt, Args: []Expression{spanDuration(), &AnyValue{Value: "1.5s"}}},
+				&Call{Op: OpGte, Args: []Expression{startTime, &AnyValue{Value: "2026-08-16T18:00:00.000000005Z"}}},
+			),
+		},
+		{
+			name:     "a call that is not a valid filter, which is still written",
+			filter:   &Call{Op: "xor", Args: []Expression{}},
+			json:     `{"op":"xor","args":[]}`,
+			readBack: &Call{Op: "xor"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := json.Marshal(test.filter)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.json, string(text))
+			assert.Equal(t, test.json, string(text), "the codec writes one spelling")
+
+			var read Call
+			require.NoError(t, json.Unmarshal([]byte(test.json), &read))
+			expected := test.readBack
+			if expected == nil {
+				expected = test.filter
+			}
+			assert.Equal(t, expected, &read)
+		})
+	}
+}
+
+func spanDuration() *FieldRef {
+	return &FieldRef{Level: LevelSpan, Name: SpanFieldDuration}
+}
+
+// TestCallJSON_FinalizesToTheSameFilter is the round trip a saved search makes: what a finalized
+// filter is read back as finalizes to the filter that was written.
+func TestCallJSON_FinalizesToTheSameFilter(t *testing.T) {
+	filter, err := Finalize(and(
+		&Call{Op: OpGt, Args: []Expression{spanDuration(), &AnyValue{Value: "2s"}}},
+		&Call{Op: OpLt, Args: []Expression{&FieldRef{Level: LevelSpan, Name: SpanFieldEndTime}, &AnyValue{Value: "2026-08-16T20:00:00+02:00"}}},
+		&Call{Op: OpEq, Args: []Expression{&FieldRef{Level: LevelSpan, Name: SpanFieldKind}, &AnyValue{Value: "server"}}},
+		&Call{Op: OpRegex, Args: []Expression{&AttributeRef{Key: "http.route"}, &StringValue{Value: "/api/.*"}}},
+	))
+	require.NoError(t, err)
+
+	text, err := json.Marshal(filter)
+	require.NoError(t, err)
+	var read Call
+	require.NoError(t, json.Unmarshal(text, &read))
+	again, err := Finalize(&read)
+	require.NoError(t, err)
+	assert.Equal(t, filter, again)
+}
+
+// TestCallJSON_InADocument covers the use the codec is for: a filter held in a larger document.
+func TestCallJSON_InADocument(t *testing.T) {
+	type savedSearch struct {
+		Name   string `json:"name"`
+		Filter *Call  `json:"filter,omitempty"`
+	}
+	saved := savedSearch{Name: "errors", Filter: &Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "error"}}}}
+	text, err := json.Marshal(saved)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"errors","filter":{"op":"exists","args":[{"attr":{"key":"error"}}]}}`, string(text))
+
+	var read savedSearch
+	require.NoError(t, json.Unmarshal(text, &read))
+	assert.Equal(t, saved, read)
+}
+
+func TestCallJSON_RefusesWhatTheFormDoesNotHold(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{
+			name:     "no member of the oneof",
+			json:     `{"op":"exists","args":[{}]}`,
+			expected: "filter member args[0] holds none of attr, field, nested, scalar, list, call, and an expression holds one",
+		},
+		{
+			name:     "only a null member",
+			json:     `{"op":"exists","args":[{"attr":null}]}`,
+			expected: "filter member args[0] holds none of attr, field, nested, scalar, list, call",
+		},
+		{
+			name:     "two members of the oneof",
+			json:     `{"op":"exists","args":[{"attr":{"key":"a"},"field":{"name":"duration","level":"span"}}]}`,
+			expected: "filter member args[0] holds attr and field, and an expression holds only one",
+		},
+		{
+			name:     "a member no version defines",
+			json:     `{"op":"exists","args":[{"function":{"name":"lower"}}]}`,
+			expected: `filter member args[0] has unknown member "function"; it holds attr, field, nested, scalar, list, call`,
+		},
+		{
+			name:     "a member no version defines, beside one it does",
+			json:     `{"op":"exists","args":[{"attr":{"key":"a"},"negate":true}]}`,
+			expected: `filter member args[0] has unknown member "negate"`,
+		},
+		{
+			name:     "a member of a message spelled in another case",
+			json:     `{"op":"and","args":[{"call":{"OP":"exists","args":[]}}]}`,
+			expected: `filter member args[0].call has unknown member "OP"; it holds op, args`,
+		},
+		{
+			name:     "an unknown member at the root",
+			json:     `{"op":"exists","args":[],"version":2}`,
+			expected: `filter has unknown member "version"`,
+		},
+		{
+			name:     "a scalar whose text does not read as its type",
+			json:     `{"op":"eq","args":[{"attr":{"key":"a"}},{"scalar":{"value":"many","type":"int"}}]}`,
+			expected: `filter member args[1].scalar: "many" does not read as int`,
+		},
+		{
+			name:     "a scalar of a type no version defines",
+			json:     `{"op":"eq","args":[{"attr":{"key":"a"}},{"scalar":{"value":"2s","type":"duration"}}]}`,
+			expected: `filter member args[1].scalar: unknown filter value type "duration"`,
+		},
+		{
+			name:     "a scalar written as a JSON number",
+			json:     `{"op":"eq","args":[{"attr":{"key":"a"}},{"scalar":{"value":500}}]}`,
+			expected: "filter member args[1].scalar: json: cannot unmarshal number",
+		},
+		{
+			name:     "an expression that is not an object",
+			json:     `{"op":"exists","args":["error"]}`,
+			expected: "filter member args[0] is not an object",
+		},
+		{
+			name:     "a filter that is not an object",
+			json:     `[]`,
+			expected: "filter is not an object",
+		},
+		{
+			name:     "nesting no consumer could walk",
+			json:     strings.Repeat(`{"op":"not","args":[{"call":`, MaxNestingDepth) + `{"op":"exists","args":[{"attr":{"key":"a"}}]}` + strings.Repeat(`}]}`, MaxNestingDepth),
+			expected: ErrTooDeeplyNested.Error(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var read Call
+			err := json.Unmarshal([]byte(test.json), &read)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestCallJSON_RefusesWhatItCannotWrite(t *testing.T) {
+	deep := &Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "a"}}}
+	for range MaxNestingDepth {
+		deep = &Call{Op: OpNot, Args: []Expression{deep}}
+	}
+	cyclic := &Call{Op: OpNot}
+	cyclic.Args = []Expression{cyclic}
+
+	for name, filter := range map[string]*Call{
+		"a missing term":      {Op: OpExists, Args: []Expression{nil}},
+		"a typed-nil term":    {Op: OpExists, Args: []Expression{(*AttributeRef)(nil)}},
+		"nesting too deep":    deep,
+		"a tree in a cycle":   cyclic,
+		"a missing predicate": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := filter.MarshalJSON()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+// Option adjusts what ValidateFilter, ResolveConstants and Finalize check a filter against. With
+// none, a filter is checked against RFC 0005 alone.
+type Option func(*options)
+
+type options struct {
+	// fields is nil for the fields RFC 0005 defines (see FieldRegistry.Lookup).
+	fields *FieldRegistry
+	// attributes is nil when nothing is known about any attribute, which is the RFC's own answer.
+	attributes *AttributeSchema
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithFields checks field references against a deployment's registry rather than the fields RFC
+// 0005 defines alone. Pass the same registry at every stage: a filter validated against one set of
+// fields and resolved against another is read against a type validation never checked.
+func WithFields(registry *FieldRegistry) Option {
+	return func(o *options) {
+		o.fields = registry
+	}
+}
+
+// WithAttributes reads an untyped constant compared against an attribute the schema declares as
+// that attribute's type (see AttributeSchema). It changes what ResolveConstants produces and
+// nothing ValidateFilter accepts: validation answers whether a filter is well formed, which no
+// schema of what instrumentation writes can change.
+func WithAttributes(schema *AttributeSchema) Option {
+	return func(o *options) {
+		o.attributes = schema
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy gates structured trace-query filters by who is asking. A query interceptor holds a
+// Policy naming, for each principal, which attribute keys and which levels that principal may search
+// on, and checks every filter against it before the filter reaches storage. It is the gate the
+// expression package's one AST exists for: the tree an interceptor checks is the tree a backend
+// runs, so nothing can be searched on that the check did not see.
+//
+// The package also redacts a filter for logging, which is the other place a constant compared
+// against a sensitive attribute would otherwise leak.
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// Rule is what one principal may search on. An empty allow list allows everything its deny list
+// does not name, so the zero Rule allows every filter.
+//
+// A key ending in `*` names every key with the text before it as a prefix, so `user.*` covers
+// `user.email` and `user.id`. An unqualified attribute reference searches the span and resource
+// levels both (RFC 0005 §5.1), so it is allowed only where both of those are.
+//
+// Levels govern every reference: an attribute at that level, a built-in field of it, and the
+// collection `some` quantifies over. Keys govern attribute references only; a built-in field is
+// named by the API rather than by whoever wrote the span, and is governed by its level.
+type Rule struct {
+	AllowLevels []expression.Level
+	DenyLevels  []expression.Level
+	AllowKeys   []string
+	DenyKeys    []string
+}
+
+// Policy maps principals to the Rule each is held to. A principal with no rule of its own is held to
+// the policy's fallback rule, which is passed when the policy is built so that what an unknown
+// principal may do is decided rather than defaulted. So the zero value is not ready for use; a
+// Policy is made with New.
+//
+// Like the registries of the expression package, a policy is filled in when a deployment starts and
+// read from then on.
+type Policy struct {
+	rules    map[string]Rule
+	fallback Rule
+}
+
+// New returns a policy holding no rules, under which every principal is held to fallback.
+func New(fallback Rule) *Policy {
+	return &Policy{rules: map[string]Rule{}, fallback: fallback}
+}
+
+// Set holds a principal to a rule, replacing any it was held to.
+func (p *Policy) Set(principal string, rule Rule) {
+	p.rules[principal] = rule
+}
+
+// Rule returns the rule a principal is held to, which is the fallback for one the policy does not
+// name.
+func (p *Policy) Rule(principal string) Rule {
+	if rule, ok := p.rules[principal]; ok {
+		return rule
+	}
+	return p.fallback
+}
+
+// Violation is one reference a principal may not search on, located by its path from the root of
+// the filter in the field names of the proto3 JSON form: `args[1].args[0]` is the first argument of
+// the root's second argument, and the empty path is the root itself.
+type Violation struct {
+	Path   string
+	Reason string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Reason
+	}
+	return v.Path + ": " + v.Reason
+}
+
+// Check reports every reference in a filter that the principal may not search on, in the order they
+// appear. No violations means the filter may run. A caller refuses the query on any: removing the
+// offending predicate instead would hand back results for a question nobody asked.
+//
+// Check is meant for a filter that has been finalized, but answers for any tree. One nested beyond
+// expression.MaxNestingDepth — which includes one containing itself — is reported rather than walked,
+// since what lies below the bound was never checked.
+func (p *Policy) Check(principal string, filter *expression.Call) []Violation {
+	c := checker{rule: p.Rule(principal)}
+	c.call(filter, "", 1)
+	return c.violations
+}
+
+type checker struct {
+	rule       Rule
+	violations []Violation
+}
+
+func (c *checker) report(path, format string, args ...any) {
+	c.violations = append(c.violations, Violation{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) call(call *expression.Call, path string, depth int) {
+	if call == nil {
+		return
+	}
+	if depth > expression.MaxNestingDepth {
+		c.report(path, "filter nests calls more than %d deep", expression.MaxNestingDepth)
+		return
+	}
+	for i, arg := range call.Args {
+		argPath := fmt.Sprintf("args[%d]", i)
+		if path != "" {
+			argPath = path + "." + argPath
+		}
+		c.term(arg, argPath, depth)
+	}
+}
+
+func (c *checker) term(e expression.Expression, path string, depth int) {
+	switch term := e.(type) {
+	case *expression.Call:
+		c.call(term, path, depth+1)
+	case *expression.AttributeRef:
+		if term == nil {
+			return
+		}
+		c.attribute(term, path)
+	case *expression.FieldRef:
+		if term == nil {
+			return
+		}
+		c.level(term.Level, path, fmt.Sprintf("field %s.%s", term.Level, term.Name))
+	case *expression.NestedRef:
+		if term == nil {
+			return
+		}
+		c.level(term.Level, path, fmt.Sprintf("the %s collection", term.Level))
+	}
+}
+
+func (c *checker) attribute(ref *expression.AttributeRef, path string) {
+	if !c.keyAllowed(ref.Key) {
+		c.report(path, "attribute %q may not be searched", ref.Key)
+		return
+	}
+	what := fmt.Sprintf("attribute %q", ref.Key)
+	if ref.Level != "" {
+		c.level(ref.Level, path, what)
+		return
+	}
+	for _, level := range []expression.Level{expression.LevelSpan, expression.LevelResource} {
+		if !c.levelAllowed(level) {
+			c.report(path, "%s searches the %s level, which may not be searched; name the level to search", what, level)
+			return
+		}
+	}
+}
+
+func (c *checker) level(level expression.Level, path, what string) {
+	if !c.levelAllowed(level) {
+		c.report(path, "%s is at the %s level, which may not be searched", what, level)
+	}
+}
+
+func (c *checker) levelAllowed(level expression.Level) bool {
+	if slices.Contains(c.rule.DenyLevels, level) {
+		return false
+	}
+	return len(c.rule.AllowLevels) == 0 || slices.Contains(c.rule.AllowLevels, level)
+}
+
+func (c *checker) keyAllowed(key string) bool {
+	if matchesAny(c.rule.DenyKeys, key) {
+		return false
+	}
+	return len(c.rule.AllowKeys) == 0 || matchesAny(c.rule.AllowKeys, key)
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if pattern == key {
+			return true
+		}
+	}
+	return false
+}
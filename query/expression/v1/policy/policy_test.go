@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+func eq(left, right expression.Expression) *expression.Call {
+	return &expression.Call{Op: expression.OpEq, Args: []expression.Expression{left, right}}
+}
+
+func attr(key string, level expression.Level) *expression.AttributeRef {
+	return &expression.AttributeRef{Key: key, Level: level}
+}
+
+func and(args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: expression.OpAnd, Args: args}
+}
+
+// supportPolicy is a policy as a multi-tenant deployment would hold one: support staff may not
+// search on what identifies an end user or what a database was asked, and an auditor may search on
+// resource attributes only.
+func supportPolicy() *Policy {
+	p := New(Rule{DenyLevels: []expression.Level{
+		expression.LevelSpan, expression.LevelResource, expression.LevelScope,
+		expression.LevelEvent, expression.LevelLink,
+	}})
+	p.Set("support", Rule{DenyKeys: []string{"user.*", "db.statement"}})
+	p.Set("auditor", Rule{AllowLevels: []expression.Level{expression.LevelResource}})
+	p.Set("oncall", Rule{})
+	return p
+}
+
+func TestPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		filter    *expression.Call
+		expected  []Violation
+	}{
+		{
+			name:      "a key nobody denied",
+			principal: "support",
+			filter:    eq(attr("http.route", ""), &expression.AnyValue{Value: "/cart"}),
+		},
+		{
+			name:      "a denied key, named exactly",
+			principal: "support",
+			filter: and(
+				eq(attr("http.route", ""), &expression.AnyValue{Value: "/cart"}),
+				&expression.Call{Op: expression.OpNot, Args: []expression.Expression{
+					&expression.Call{Op: expression.OpExists, Args: []expression.Expression{attr("db.statement", expression.LevelSpan)}},
+				}},
+			),
+			expected: []Violation{{Path: "args[1].args[0].args[0]", Reason: `attribute "db.statement" may not be searched`}},
+		},
+		{
+			name:      "a denied key, named by its prefix, on either side of a comparison",
+			principal: "support",
+			filter: &expression.Call{Op: expression.OpOr, Args: []expression.Expression{
+				eq(&expression.AnyValue{Value: "a@example.com"}, attr("user.email", "")),
+				eq(attr("user.id", expression.LevelResource), &expression.AnyValue{Value: "42"}),
+			}},
+			expected: []Violation{
+				{Path: "args[0].args[1]", Reason: `attribute "user.email" may not be searched`},
+				{Path: "args[1].args[0]", Reason: `attribute "user.id" may not be searched`},
+			},
+		},
+		{
+			name:      "a level outside the allowed ones",
+			principal: "auditor",
+			filter: and(
+				eq(attr("k8s.namespace.name", expression.LevelResource), &expression.AnyValue{Value: "cart"}),
+				eq(&expression.FieldRef{Name: expression.SpanFieldName, Level: expression.LevelSpan}, &expression.AnyValue{Value: "GET"}),
+				&expression.Call{Op: expression.OpSome, Args: []expression.Expression{
+					&expression.NestedRef{Level: expression.LevelEvent},
+					&expression.Call{Op: expression.OpExists, Args: []expression.Expression{attr("exception.type", expression.LevelEvent)}},
+				}},
+			),
+			expected: []Violation{
+				{Path: "args[1].args[0]", Reason: "field span.name is at the span level, which may not be searched"},
+				{Path: "args[2].args[0]", Reason: "the event collection is at the event level, which may not be searched"},
+				{Path: "args[2].args[1].args[0]", Reason: `attribute "exception.type" is at the event level, which may not be searched`},
+			},
+		},
+		{
+			name:      "an unqualified attribute, which searches a level the principal may not",
+			principal: "auditor",
+			filter:    eq(attr("k8s.namespace.name", ""), &expression.AnyValue{Value: "cart"}),
+			expected: []Violation{{Path: "args[0]",
+				Reason: `attribute "k8s.namespace.name" searches the span level, which may not be searched; name the level to search`}},
+		},
+		{
+			name:      "a principal the policy does not name, held to the fallback",
+			principal: "stranger",
+			filter:    &expression.Call{Op: expression.OpExists, Args: []expression.Expression{attr("http.route", expression.LevelSpan)}},
+			expected:  []Violation{{Path: "args[0]", Reason: `attribute "http.route" is at the span level, which may not be searched`}},
+		},
+		{
+			name:      "a principal held to the zero rule",
+			principal: "oncall",
+			filter:    eq(attr("user.email", ""), &expression.AnyValue{Value: "a@example.com"}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, supportPolicy().Check(test.principal, test.filter))
+		})
+	}
+}
+
+func TestPolicy_AllowKeys(t *testing.T) {
+	p := New(Rule{AllowKeys: []string{"http.*", "service.name"}, DenyKeys: []string{"http.request.header.*"}})
+
+	assert.Empty(t, p.Check("anyone", eq(attr("http.route", ""), &expression.AnyValue{Value: "/"})))
+	assert.Empty(t, p.Check("anyone", eq(attr("service.name", ""), &expression.AnyValue{Value: "cart"})))
+	assert.Equal(t, []Violation{{Path: "args[0]", Reason: `attribute "service.namespace" may not be searched`}},
+		p.Check("anyone", eq(attr("service.namespace", ""), &expression.AnyValue{Value: "shop"})))
+	assert.Equal(t, []Violation{{Path: "args[0]", Reason: `attribute "http.request.header.authorization" may not be searched`}},
+		p.Check("anyone", eq(attr("http.request.header.authorization", ""), &expression.AnyValue{Value: "x"})),
+		"a deny list narrows an allow list")
+}
+
+// TestPolicy_Check_RefusesWhatItCannotWalk pins that a tree the check did not finish is reported
+// rather than passed: a filter is allowed only when every reference in it was seen.
+func TestPolicy_Check_RefusesWhatItCannotWalk(t *testing.T) {
+	cycle := &expression.Call{Op: expression.OpNot}
+	cycle.Args = []expression.Expression{cycle}
+
+	violations := New(Rule{}).Check("anyone", cycle)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "filter nests calls more than 20 deep", violations[0].Reason)
+
+	assert.NotPanics(t, func() {
+		New(Rule{}).Check("anyone", &expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+			(*expression.AttributeRef)(nil), (*expression.FieldRef)(nil), (*expression.NestedRef)(nil), (*expression.Call)(nil), nil,
+		}})
+	})
+	assert.Empty(t, New(Rule{}).Check("anyone", nil))
+}
+
+func TestViolation_String(t *testing.T) {
+	assert.Equal(t, "args[0]: no", Violation{Path: "args[0]", Reason: "no"}.String())
+	assert.Equal(t, "no", Violation{Reason: "no"}.String())
+}
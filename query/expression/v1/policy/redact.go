@@ -0,0 +1,91 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// Placeholder is what a redacted constant reads as.
+const Placeholder = "<redacted>"
+
+// Redact returns a copy of a filter fit to be logged: each constant compared against a sensitive
+// attribute is replaced by Placeholder, and the structure — every operator and every reference — is
+// kept, since that is what makes a logged filter worth reading. With no keys given every constant is
+// sensitive, which is the answer for a log whose readers may see nothing the caller wrote.
+//
+// Keys are written as in a Rule, so the deny list a policy already holds can be passed as it is. A
+// constant is only ever compared against one reference, so it is the reference beside it that
+// decides; a constant beside a built-in field is sensitive only when every constant is.
+//
+// What it returns is for a log line and not for a backend: a placeholder is untyped text, and would
+// not survive being finalized against the field it stands beside. The filter it is given is not
+// modified. A tree nested beyond expression.MaxNestingDepth is cut off at the bound rather than
+// followed.
+func Redact(filter *expression.Call, keys ...string) *expression.Call {
+	return redactCall(filter, keys, 1)
+}
+
+func redactCall(call *expression.Call, keys []string, depth int) *expression.Call {
+	if call == nil {
+		return nil
+	}
+	if depth > expression.MaxNestingDepth {
+		return &expression.Call{Op: call.Op}
+	}
+	sensitive := len(keys) == 0 || comparesSensitive(call, keys)
+	args := make([]expression.Expression, len(call.Args))
+	for i, arg := range call.Args {
+		switch term := arg.(type) {
+		case *expression.Call:
+			args[i] = redactCall(term, keys, depth+1)
+		case *expression.List:
+			if sensitive && term != nil {
+				args[i] = &expression.List{Values: []string{Placeholder}}
+			} else {
+				args[i] = arg
+			}
+		default:
+			if sensitive && isConstant(arg) {
+				args[i] = &expression.AnyValue{Value: Placeholder}
+			} else {
+				args[i] = arg
+			}
+		}
+	}
+	return &expression.Call{Op: call.Op, Args: args}
+}
+
+// comparesSensitive reports whether a call reads an attribute whose key is one of keys, which makes
+// every constant among its own arguments sensitive.
+func comparesSensitive(call *expression.Call, keys []string) bool {
+	for _, arg := range call.Args {
+		if ref, ok := arg.(*expression.AttributeRef); ok && ref != nil && matchesAny(keys, ref.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConstant reports whether a term is a single constant, holding a value rather than naming one.
+func isConstant(e expression.Expression) bool {
+	switch term := e.(type) {
+	case *expression.AnyValue:
+		return term != nil
+	case *expression.StringValue:
+		return term != nil
+	case *expression.IntValue:
+		return term != nil
+	case *expression.DoubleValue:
+		return term != nil
+	case *expression.BoolValue:
+		return term != nil
+	case *expression.DurationValue:
+		return term != nil
+	case *expression.TimestampValue:
+		return term != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+func TestRedact(t *testing.T) {
+	build := func() *expression.Call {
+		return and(
+			eq(attr("user.email", ""), &expression.StringValue{Value: "a@example.com"}),
+			&expression.Call{Op: expression.OpIn, Args: []expression.Expression{
+				attr("user.id", expression.LevelSpan),
+				&expression.List{Values: []string{"41", "42"}, Type: expression.ValueTypeInt},
+			}},
+			&expression.Call{Op: expression.OpRegex, Args: []expression.Expression{
+				attr("db.statement", ""), &expression.AnyValue{Value: "SELECT .* FROM users"},
+			}},
+			&expression.Call{Op: expression.OpGt, Args: []expression.Expression{
+				&expression.FieldRef{Name: expression.SpanFieldDuration, Level: expression.LevelSpan},
+				&expression.DurationValue{Value: 2 * time.Second},
+			}},
+			eq(attr("http.route", ""), &expression.AnyValue{Value: "/cart"}),
+		)
+	}
+	filter := build()
+
+	assert.Equal(t, and(
+		eq(attr("user.email", ""), &expression.AnyValue{Value: Placeholder}),
+		&expression.Call{Op: expression.OpIn, Args: []expression.Expression{
+			attr("user.id", expression.LevelSpan), &expression.List{Values: []string{Placeholder}},
+		}},
+		&expression.Call{Op: expression.OpRegex, Args: []expression.Expression{
+			attr("db.statement", ""), &expression.AnyValue{Value: Placeholder},
+		}},
+		filter.Args[3],
+		filter.Args[4],
+	), Redact(filter, "user.*", "db.statement"), "only what is compared against a sensitive key")
+	assert.Equal(t, build(), filter, "the filter it was given is untouched")
+
+	everything := Redact(filter)
+	for _, arg := range everything.Args {
+		constant := arg.(*expression.Call).Args[1]
+		if list, ok := constant.(*expression.List); ok {
+			assert.Equal(t, []string{Placeholder}, list.Values)
+			continue
+		}
+		assert.Equal(t, &expression.AnyValue{Value: Placeholder}, constant, "with no keys, every constant")
+	}
+}
+
+func TestRedact_AnswersForAnyTree(t *testing.T) {
+	assert.Nil(t, Redact(nil))
+
+	cycle := &expression.Call{Op: expression.OpNot}
+	cycle.Args = []expression.Expression{cycle}
+	assert.NotPanics(t, func() { Redact(cycle) })
+
+	assert.NotPanics(t, func() {
+		Redact(&expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+			(*expression.AttributeRef)(nil), (*expression.StringValue)(nil), (*expression.List)(nil), nil,
+		}}, "user.*")
+	})
+}
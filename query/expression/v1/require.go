@@ -0,0 +1,152 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Require adds a mandatory clause to a filter, which is how a query interceptor confines a query —
+// to one tenant's spans, say — whatever the caller asked for. The clause is ANDed at the root, so
+// nothing the caller wrote can widen it: an `or` in the filter sits under the conjunction rather than
+// beside the clause. A filter that is already a conjunction gains the clause as one more argument
+// rather than a level of nesting, and an empty filter becomes the clause alone.
+//
+// The result is finalized, so it is what a backend can be handed directly and is refused exactly as
+// Finalize would refuse it — including for nesting beyond MaxNestingDepth, which requiring a clause
+// nested as deeply as the filter can reach. Neither argument is modified.
+func Require(filter, mandatory *Call, opts ...Option) (*Call, error) {
+	if mandatory == nil {
+		return nil, errors.New("mandatory clause is empty")
+	}
+	if filter != nil {
+		// Checked on its own first: a malformed conjunction of one would otherwise be mended by
+		// gaining the clause, and pass for a filter the caller never sent.
+		if err := ValidateFilter(filter, opts...); err != nil {
+			return nil, err
+		}
+	}
+	combined := mandatory
+	switch {
+	case filter == nil:
+	case filter.Op == OpAnd:
+		combined = &Call{Op: OpAnd, Args: append([]Expression{mandatory}, filter.Args...)}
+	default:
+		combined = &Call{Op: OpAnd, Args: []Expression{mandatory, filter}}
+	}
+	finalized, err := Finalize(combined, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot require the clause: %w", err)
+	}
+	return finalized, nil
+}
+
+// Satisfies reports whether a filter already implies a mandatory clause, which lets an interceptor
+// accept a filter that confines itself rather than rewrite it. The answer is the one Implies gives:
+// true when the clause is one of the filter's conjuncts, when every branch of a disjunction implies
+// it, or when the filter implies every conjunct of the clause or one branch of a disjunction of it;
+// false whenever no such argument is found, which includes every filter that is not well formed. So
+// `tenant = a or x` does not satisfy `tenant = a`, which is the bypass this exists to catch.
+//
+// Both are finalized before they are compared, so a clause and a filter that wrote the same
+// comparison in different orientations, or with a constant in different spellings of one value,
+// compare equal.
+func Satisfies(filter, mandatory *Call, opts ...Option) bool {
+	if filter == nil || mandatory == nil {
+		return false
+	}
+	f, err := Finalize(filter, opts...)
+	if err != nil {
+		return false
+	}
+	m, err := Finalize(mandatory, opts...)
+	if err != nil {
+		return false
+	}
+	return prover{fields: newOptions(opts).fields}.implies(f, m)
+}
+
+func allArgs(call *Call, fn func(*Call) bool) bool {
+	for _, arg := range call.Args {
+		nested, ok := arg.(*Call)
+		if !ok || !fn(nested) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyArg(call *Call, fn func(*Call) bool) bool {
+	for _, arg := range call.Args {
+		if nested, ok := arg.(*Call); ok && fn(nested) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameTerm reports whether two terms say the same thing. Instants are compared as instants, since an
+// RFC 3339 timestamp can spell one in any zone.
+func sameTerm(a, b Expression) bool {
+	if isMissing(a) || isMissing(b) {
+		return isMissing(a) && isMissing(b)
+	}
+	switch x := a.(type) {
+	case *Call:
+		y, ok := b.(*Call)
+		if !ok || x.Op != y.Op || len(x.Args) != len(y.Args) {
+			return false
+		}
+		for i := range x.Args {
+			if !sameTerm(x.Args[i], y.Args[i]) {
+				return false
+			}
+		}
+		return true
+	case *TimestampValue:
+		y, ok := b.(*TimestampValue)
+		return ok && x.Value.Equal(y.Value)
+	case *List:
+		y, ok := b.(*List)
+		if !ok || x.Type != y.Type || len(x.Values) != len(y.Values) {
+			return false
+		}
+		for i := range x.Values {
+			if x.Values[i] != y.Values[i] {
+				return false
+			}
+		}
+		return true
+	case *AttributeRef:
+		y, ok := b.(*AttributeRef)
+		return ok && *x == *y
+	case *FieldRef:
+		y, ok := b.(*FieldRef)
+		return ok && *x == *y
+	case *NestedRef:
+		y, ok := b.(*NestedRef)
+		return ok && *x == *y
+	case *AnyValue:
+		y, ok := b.(*AnyValue)
+		return ok && *x == *y
+	case *StringValue:
+		y, ok := b.(*StringValue)
+		return ok && *x == *y
+	case *IntValue:
+		y, ok := b.(*IntValue)
+		return ok && *x == *y
+	case *DoubleValue:
+		y, ok := b.(*DoubleValue)
+		return ok && *x == *y
+	case *BoolValue:
+		y, ok := b.(*BoolValue)
+		return ok && *x == *y
+	case *DurationValue:
+		y, ok := b.(*DurationValue)
+		return ok && *x == *y
+	default:
+		return false
+	}
+}
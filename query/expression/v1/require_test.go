@@ -0,0 +1,159 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tenant(name string) *Call {
+	return eq(&AttributeRef{Key: "tenant", Level: LevelResource}, &StringValue{Value: name})
+}
+
+func or(args ...Expression) *Call {
+	return &Call{Op: OpOr, Args: args}
+}
+
+func and(args ...Expression) *Call {
+	return &Call{Op: OpAnd, Args: args}
+}
+
+func TestRequire(t *testing.T) {
+	route := eq(attr("http.route"), &AnyValue{Value: "/cart"})
+	slow := &Call{Op: OpGt, Args: []Expression{&AnyValue{Value: "2s"}, spanField(SpanFieldDuration)}}
+
+	required, err := Require(or(route, slow), tenant("acme"))
+	require.NoError(t, err)
+	assert.Equal(t, and(tenant("acme"), or(route, &Call{Op: OpLt, Args: []Expression{
+		spanField(SpanFieldDuration), &DurationValue{Value: 2 * time.Second},
+	}})), required, "the caller's disjunction sits under the clause, and the result is finalized")
+
+	required, err = Require(and(route, slow), tenant("acme"))
+	require.NoError(t, err)
+	assert.Len(t, required.Args, 3, "a conjunction gains the clause rather than a level")
+	assert.Equal(t, tenant("acme"), required.Args[0])
+
+	required, err = Require(nil, tenant("acme"))
+	require.NoError(t, err)
+	assert.Equal(t, tenant("acme"), required, "an empty filter becomes the clause alone")
+
+	assert.Len(t, route.Args, 2, "neither argument is modified")
+}
+
+func TestRequire_Refuses(t *testing.T) {
+	_, err := Require(tenant("acme"), nil)
+	require.ErrorContains(t, err, "mandatory clause is empty")
+
+	_, err = Require(and(tenant("other")), tenant("acme"))
+	require.ErrorContains(t, err, `operator "and" takes at least two arguments, got 1`,
+		"a malformed filter is not mended by the clause")
+
+	_, err = Require(tenant("acme"), eq(spanField("nonesuch"), &AnyValue{Value: "1"}))
+	require.ErrorContains(t, err, "cannot require the clause")
+
+	_, err = Require(nestedTo(MaxNestingDepth), nestedTo(MaxNestingDepth))
+	require.ErrorIs(t, err, ErrTooDeeplyNested)
+}
+
+func TestSatisfies(t *testing.T) {
+	route := eq(attr("http.route"), &AnyValue{Value: "/cart"})
+	tests := []struct {
+		name      string
+		filter    *Call
+		mandatory *Call
+		expected  bool
+	}{
+		{name: "the clause itself", filter: tenant("acme"), mandatory: tenant("acme"), expected: true},
+		{name: "the clause as a conjunct", filter: and(route, tenant("acme")), mandatory: tenant("acme"), expected: true},
+		{
+			name:      "the clause written the other way round",
+			filter:    and(route, eq(&StringValue{Value: "acme"}, &AttributeRef{Key: "tenant", Level: LevelResource})),
+			mandatory: tenant("acme"),
+			expected:  true,
+		},
+		{
+			name:      "every branch of a disjunction confined",
+			filter:    or(and(tenant("acme"), route), tenant("acme")),
+			mandatory: tenant("acme"),
+			expected:  true,
+		},
+		{
+			name:      "a disjunction with a branch that escapes",
+			filter:    or(tenant("acme"), route),
+			mandatory: tenant("acme"),
+			expected:  false,
+		},
+		{name: "another tenant", filter: tenant("other"), mandatory: tenant("acme"), expected: false},
+		{
+			name:      "a clause of two conjuncts, both present",
+			filter:    and(tenant("acme"), route, tenant("acme-eu")),
+			mandatory: and(tenant("acme-eu"), tenant("acme")),
+			expected:  true,
+		},
+		{
+			name:      "a clause of two conjuncts, one missing",
+			filter:    and(tenant("acme"), route),
+			mandatory: and(tenant("acme-eu"), tenant("acme")),
+			expected:  false,
+		},
+		{
+			name:      "a clause offering a choice",
+			filter:    and(route, tenant("acme-eu")),
+			mandatory: or(tenant("acme"), tenant("acme-eu")),
+			expected:  true,
+		},
+		{
+			name:      "a negated clause, which proves nothing",
+			filter:    &Call{Op: OpNot, Args: []Expression{&Call{Op: OpNot, Args: []Expression{tenant("acme")}}}},
+			mandatory: tenant("acme"),
+			expected:  false,
+		},
+		{name: "a malformed filter", filter: and(tenant("acme")), mandatory: tenant("acme"), expected: false},
+		{name: "a malformed clause", filter: tenant("acme"), mandatory: and(tenant("acme")), expected: false},
+		{name: "no filter", filter: nil, mandatory: tenant("acme"), expected: false},
+		{name: "no clause", filter: tenant("acme"), mandatory: nil, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Satisfies(test.filter, test.mandatory))
+		})
+	}
+}
+
+// TestSatisfies_WhatRequireProduces pins the two together: whatever Require returns satisfies the
+// clause it was given, which is what lets an interceptor that runs twice leave a filter alone.
+func TestSatisfies_WhatRequireProduces(t *testing.T) {
+	for _, filter := range []*Call{nil, tenant("other"), or(tenant("other"), tenant("third"))} {
+		required, err := Require(filter, tenant("acme"))
+		require.NoError(t, err)
+		assert.True(t, Satisfies(required, tenant("acme")))
+	}
+}
+
+// TestSameTerm covers the comparison Satisfies ends in, for every term type.
+func TestSameTerm(t *testing.T) {
+	utc := &TimestampValue{Value: mustTime(t, "2026-08-16T18:00:00Z")}
+	offset := &TimestampValue{Value: mustTime(t, "2026-08-16T20:00:00+02:00")}
+	assert.True(t, sameTerm(utc, offset), "one instant in two zones")
+
+	for _, test := range allTerms {
+		assert.True(t, sameTerm(test.term, test.term), test.name)
+		assert.False(t, sameTerm(test.term, &unknownTerm{}), test.name)
+	}
+	assert.True(t, sameTerm(nil, (*Call)(nil)))
+	assert.False(t, sameTerm(&List{Values: []string{"a"}}, &List{Values: []string{"b"}}))
+	assert.False(t, sameTerm(&List{Values: []string{"a"}}, &List{Values: []string{"a", "b"}}))
+	assert.False(t, sameTerm(&IntValue{Value: 1}, &DoubleValue{Value: 1}))
+	assert.False(t, sameTerm(&unknownTerm{}, &unknownTerm{}))
+}
+
+func mustTime(t *testing.T, text string) time.Time {
+	value, err := time.Parse(time.RFC3339Nano, text)
+	require.NoError(t, err)
+	return value
+}
@@ -24,11 +24,23 @@ import (
 //
 // It also puts the reference first in every comparison, so each consumer downstream reads one
 // orientation rather than handling both.
-func ResolveConstants(filter *Call) (*Call, error) {
+//
+// A field is read at the type the RFC gives it, or the type a deployment registered it with when
+// WithFields is passed. WithAttributes lifts the one exception above for the attributes a schema
+// declares: a constant compared against one of those is read as the type it declares.
+func ResolveConstants(filter *Call, opts ...Option) (*Call, error) {
 	if filter == nil {
 		return nil, errors.New("filter is empty")
 	}
-	return resolveCall(filter, 1)
+	o := newOptions(opts)
+	return resolver{fields: o.fields, attributes: o.attributes}.resolveCall(filter, 1)
+}
+
+// resolver carries what a filter's constants are read against through the walk, as validator
+// does for validation.
+type resolver struct {
+	fields     *FieldRegistry
+	attributes *AttributeSchema
 }
 
 // resolveCall rebuilds a call with its arguments resolved. The arguments it does not rewrite are
@@ -36,7 +48,7 @@ func ResolveConstants(filter *Call) (*Call, error) {
 //
 // It bounds its own recursion rather than trusting that validation ran first, since resolution
 // answers for any tree it is given (see ResolveConstants).
-func resolveCall(call *Call, depth int) (*Call, error) {
+func (r resolver) resolveCall(call *Call, depth int) (*Call, error) {
 	if call == nil {
 		return nil, nil
 	}
@@ -50,7 +62,7 @@ func resolveCall(call *Call, depth int) (*Call, error) {
 			args[i] = arg
 			continue
 		}
-		resolved, err := resolveCall(nested, depth+1)
+		resolved, err := r.resolveCall(nested, depth+1)
 		if err != nil {
 			return nil, err
 		}
@@ -61,11 +73,11 @@ func resolveCall(call *Call, depth int) (*Call, error) {
 		var err error
 		switch {
 		case isComparison(op):
-			if err = resolveComparison(args); err == nil {
+			if err = r.resolveComparison(op, args); err == nil {
 				op, args = referenceFirst(op, args)
 			}
 		case op == OpIn || op == OpNotIn:
-			err = checkMembership(args)
+			err = r.checkMembership(args)
 		}
 		if err != nil {
 			return nil, err
@@ -74,17 +86,24 @@ func resolveCall(call *Call, depth int) (*Call, error) {
 	return &Call{Op: op, Args: args}, nil
 }
 
-// resolveComparison rewrites the unconstrained constant sitting opposite a built-in field. A
-// regular expression is not one of the comparisons this runs for, because its pattern stays a
-// pattern whatever the field holds, and nor is membership, whose List carries its own elements.
-func resolveComparison(args []Expression) error {
+// resolveComparison rewrites the unconstrained constant sitting opposite a built-in field, or
+// opposite an attribute the schema declares. A regular expression is not one of the comparisons
+// this runs for, because its pattern stays a pattern whatever the field holds, and nor is
+// membership, whose List carries its own elements.
+func (r resolver) resolveComparison(op Operator, args []Expression) error {
 	for i, arg := range args {
+		other := 1 - i
+		if ref, ok := arg.(*AttributeRef); ok && ref != nil {
+			if err := r.resolveAttribute(op, ref, args, other); err != nil {
+				return err
+			}
+			continue
+		}
 		ref, ok := arg.(*FieldRef)
 		if !ok || ref == nil {
 			continue
 		}
-		other := 1 - i
-		field, ok := LookupField(ref.Level, ref.Name)
+		field, ok := r.fields.Lookup(ref.Level, ref.Name)
 		if !ok {
 			// ValidateFilter refuses a field this API does not define, so there is nothing to
 			// resolve against and nothing useful to say about it here.
@@ -103,6 +122,34 @@ func resolveComparison(args []Expression) error {
 	return nil
 }
 
+// resolveAttribute reads the untyped constant at args[other] as the type the schema declares for
+// the attribute opposite it. Only an untyped constant is read: one the caller typed says what it
+// asks for, and the schema, being a hint, does not overrule it.
+//
+// Validation let an untyped constant be ordered against an attribute, because it did not know what
+// either held. Once the schema says the attribute is a boolean that is known, and refused here.
+func (r resolver) resolveAttribute(op Operator, ref *AttributeRef, args []Expression, other int) error {
+	t, ok := r.attributes.Lookup(ref.Key)
+	if !ok {
+		return nil
+	}
+	constant, ok := args[other].(*AnyValue)
+	if !ok || constant == nil {
+		return nil
+	}
+	value, err := typedValue(t, constant.Value)
+	if err != nil {
+		return fmt.Errorf("cannot compare attribute %q against %q, which the schema declares as %s: %w",
+			ref.Key, constant.Value, t, err)
+	}
+	if t == ValueTypeBool && isOrdered(op) {
+		return fmt.Errorf("operator %q has no ordering for attribute %q, which the schema declares as %s",
+			op, ref.Key, t)
+	}
+	args[other] = value
+	return nil
+}
+
 // referenceFirst puts the reference on the left of a comparison. A caller may write the constant
 // there instead, and swapping the operands asks the same question as long as an ordered operator
 // turns around with them.
@@ -135,7 +182,7 @@ func turnedAround(op Operator) Operator {
 //
 // A declared element type does not exempt the list. It says how to read the elements, so it has
 // to be a type the field could hold, and the elements still have to be readable as it.
-func checkMembership(args []Expression) error {
+func (r resolver) checkMembership(args []Expression) error {
 	list, ok := args[1].(*List)
 	if !ok || list == nil {
 		return nil
@@ -147,7 +194,7 @@ func checkMembership(args []Expression) error {
 	if !ok || ref == nil {
 		return nil
 	}
-	field, ok := LookupField(ref.Level, ref.Name)
+	field, ok := r.fields.Lookup(ref.Level, ref.Name)
 	if !ok {
 		return nil
 	}
@@ -264,6 +311,18 @@ func readConstant(t FieldType, raw string) (Expression, error) {
 	switch t {
 	case FieldTypeString:
 		return &StringValue{Value: raw}, nil
+	case FieldTypeInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &IntValue{Value: value}, nil
+	case FieldTypeDouble:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &DoubleValue{Value: value}, nil
 	case FieldTypeDuration:
 		value, err := time.ParseDuration(raw)
 		if err != nil {
@@ -301,6 +360,16 @@ func readWord(raw string, words []string) (Expression, error) {
 	return nil, fmt.Errorf("not one of %s", strings.Join(words, ", "))
 }
 
+// isOrdered reports whether a comparison asks about order rather than equality.
+func isOrdered(op Operator) bool {
+	switch op {
+	case OpGt, OpLt, OpGte, OpLte:
+		return true
+	default:
+		return false
+	}
+}
+
 // isComparison reports whether an operator compares its two operands by value, which is what
 // makes a built-in field's type the type of the constant beside it.
 func isComparison(op Operator) bool {
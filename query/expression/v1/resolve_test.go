@@ -47,6 +47,11 @@ func TestResolveConstants(t *testing.T) {
 			filter:   &Call{Op: OpLt, Args: []Expression{&FieldRef{Name: EventFieldTimeSinceStart, Level: LevelEvent}, &AnyValue{Value: "50us"}}},
 			expected: &Call{Op: OpLt, Args: []Expression{&FieldRef{Name: EventFieldTimeSinceStart, Level: LevelEvent}, &DurationValue{Value: 50 * time.Microsecond}}},
 		},
+		{
+			name:     "a count",
+			filter:   &Call{Op: OpGte, Args: []Expression{spanField(SpanFieldLinkCount), &AnyValue{Value: "2"}}},
+			expected: &Call{Op: OpGte, Args: []Expression{spanField(SpanFieldLinkCount), &IntValue{Value: 2}}},
+		},
 		{
 			name:     "an attribute, which declares nothing",
 			filter:   &Call{Op: OpGt, Args: []Expression{attr("http.response.size"), &AnyValue{Value: "500"}}},
@@ -139,6 +144,11 @@ func TestResolveConstants_RefusesAConstantThatWillNotParse(t *testing.T) {
 			filter:      &Call{Op: OpGt, Args: []Expression{spanField(SpanFieldDuration), &AnyValue{Value: "500"}}},
 			expectedErr: `cannot compare span.duration against "500"`,
 		},
+		{
+			name:        "a fraction, since a count is whole",
+			filter:      &Call{Op: OpGt, Args: []Expression{spanField(SpanFieldEventCount), &AnyValue{Value: "1.5"}}},
+			expectedErr: `cannot compare span.eventCount against "1.5"`,
+		},
 		{
 			name:        "a timestamp that is not RFC 3339",
 			filter:      &Call{Op: OpLt, Args: []Expression{spanField(SpanFieldEndTime), &AnyValue{Value: "yesterday"}}},
@@ -545,6 +555,13 @@ func TestReadElement(t *testing.T) {
 			element:   "2s",
 			expected:  &DurationValue{Value: 2 * time.Second},
 		},
+		{
+			name:      "a count, whose type the field supplies",
+			list:      &List{Values: []string{"0"}},
+			fieldType: FieldTypeInt,
+			element:   "0",
+			expected:  &IntValue{Value: 0},
+		},
 		{
 			name:      "a word the field's closed set holds",
 			list:      &List{Values: []string{"server"}},
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// AttributeSchema says what type the attributes a deployment knows about hold — that
+// http.response.status_code is an integer, say, as the OpenTelemetry semantic conventions define
+// it. Passed to ResolveConstants or Finalize with WithAttributes, it is what an untyped constant
+// compared against one of those attributes is read as.
+//
+// Without one, such a constant stays untyped and each backend matches it at whatever type it
+// stored, so `http.response.status_code = "500"` can match on one backend and not on another
+// (see AnyValue). Reading it against the schema turns it into the typed constant the caller could
+// have written, which travels the wire as one and means the same thing everywhere — and a value
+// the declared type cannot hold, `http.response.status_code > "abc"`, is refused at the query
+// boundary rather than matching nothing.
+//
+// A schema is a hint about what instrumentation writes, not a promise about what storage holds, so
+// it only ever fills in a type nobody stated. A constant the caller typed keeps its type, and a
+// list declares its own (see List). Where the schema is loaded from — the semantic conventions, a
+// deployment's own — is the caller's business.
+//
+// Like FieldRegistry, a schema is filled in when a deployment starts and read from then on.
+type AttributeSchema struct {
+	types map[string]ValueType
+}
+
+// NewAttributeSchema returns a schema that declares nothing yet.
+func NewAttributeSchema() *AttributeSchema {
+	return &AttributeSchema{types: map[string]ValueType{}}
+}
+
+// Declare records the type an attribute holds. It holds it at every level, since an attribute key
+// names one convention wherever it is written. Declaring a key again with another type is refused,
+// since the schema would then say two things about it.
+func (s *AttributeSchema) Declare(key string, t ValueType) error {
+	if key == "" {
+		return errors.New("an attribute declared in a schema has to have a key")
+	}
+	if !slices.Contains(valueTypes, t) {
+		return fmt.Errorf("attribute %q is declared with unknown type %q", key, t)
+	}
+	if declared, ok := s.types[key]; ok && declared != t {
+		return fmt.Errorf("attribute %q is already declared as %s", key, declared)
+	}
+	s.types[key] = t
+	return nil
+}
+
+// Lookup returns the type declared for an attribute. Its second result is false when the schema
+// says nothing about the key, and a nil schema says nothing about any.
+func (s *AttributeSchema) Lookup(key string) (ValueType, bool) {
+	if s == nil {
+		return "", false
+	}
+	t, ok := s.types[key]
+	return t, ok
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// semanticConventions is a schema as a deployment would load one, for a few attributes whose
+// types the OpenTelemetry semantic conventions define.
+func semanticConventions(t *testing.T) *AttributeSchema {
+	schema := NewAttributeSchema()
+	require.NoError(t, schema.Declare("http.response.status_code", ValueTypeInt))
+	require.NoError(t, schema.Declare("http.request.method", ValueTypeString))
+	require.NoError(t, schema.Declare("server.sampling.ratio", ValueTypeDouble))
+	require.NoError(t, schema.Declare("cache.hit", ValueTypeBool))
+	return schema
+}
+
+func TestAttributeSchema(t *testing.T) {
+	schema := semanticConventions(t)
+
+	declared, ok := schema.Lookup("http.response.status_code")
+	require.True(t, ok)
+	assert.Equal(t, ValueTypeInt, declared)
+
+	_, ok = schema.Lookup("http.route")
+	assert.False(t, ok)
+
+	var none *AttributeSchema
+	_, ok = none.Lookup("http.response.status_code")
+	assert.False(t, ok, "a nil schema declares nothing")
+
+	require.NoError(t, schema.Declare("http.response.status_code", ValueTypeInt), "declaring the same thing twice says one thing")
+	require.EqualError(t, schema.Declare("http.response.status_code", ValueTypeString),
+		`attribute "http.response.status_code" is already declared as int`)
+	require.EqualError(t, schema.Declare("", ValueTypeString), "an attribute declared in a schema has to have a key")
+	require.EqualError(t, schema.Declare("http.route", "text"), `attribute "http.route" is declared with unknown type "text"`)
+}
+
+// TestResolveConstants_WithAttributes pins what a schema changes: an untyped constant beside a
+// declared attribute is read as its type, at any level and on either side, and nothing else moves.
+func TestResolveConstants_WithAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *Call
+		expected *Call
+	}{
+		{
+			name:     "an integer attribute",
+			filter:   eq(attr("http.response.status_code"), &AnyValue{Value: "500"}),
+			expected: eq(attr("http.response.status_code"), &IntValue{Value: 500}),
+		},
+		{
+			name: "an attribute qualified by a level, with the constant first",
+			filter: &Call{Op: OpLt, Args: []Expression{
+				&AnyValue{Value: "0.5"}, &AttributeRef{Key: "server.sampling.ratio", Level: LevelResource},
+			}},
+			expected: &Call{Op: OpGt, Args: []Expression{
+				&AttributeRef{Key: "server.sampling.ratio", Level: LevelResource}, &DoubleValue{Value: 0.5},
+			}},
+		},
+		{
+			name:     "a boolean attribute",
+			filter:   &Call{Op: OpNe, Args: []Expression{attr("cache.hit"), &AnyValue{Value: "true"}}},
+			expected: &Call{Op: OpNe, Args: []Expression{attr("cache.hit"), &BoolValue{Value: true}}},
+		},
+		{
+			name:     "a string attribute",
+			filter:   eq(attr("http.request.method"), &AnyValue{Value: "GET"}),
+			expected: eq(attr("http.request.method"), &StringValue{Value: "GET"}),
+		},
+		{
+			name:     "an attribute the schema does not declare",
+			filter:   eq(attr("http.route"), &AnyValue{Value: "/cart"}),
+			expected: eq(attr("http.route"), &AnyValue{Value: "/cart"}),
+		},
+		{
+			name:     "a constant the caller typed, which the schema does not overrule",
+			filter:   eq(attr("http.response.status_code"), &StringValue{Value: "500"}),
+			expected: eq(attr("http.response.status_code"), &StringValue{Value: "500"}),
+		},
+		{
+			name: "a pattern, which stays a pattern",
+			filter: &Call{Op: OpRegex, Args: []Expression{
+				attr("http.request.method"), &AnyValue{Value: "GET|POST"},
+			}},
+			expected: &Call{Op: OpRegex, Args: []Expression{
+				attr("http.request.method"), &AnyValue{Value: "GET|POST"},
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolved, err := Finalize(test.filter, WithAttributes(semanticConventions(t)))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, resolved)
+
+			again, err := Finalize(resolved, WithAttributes(semanticConventions(t)))
+			require.NoError(t, err)
+			assert.Equal(t, resolved, again, "finalizing stays idempotent")
+		})
+	}
+}
+
+func TestResolveConstants_WithAttributes_RefusesWhatTheSchemaCannotHold(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      *Call
+		expectedErr string
+	}{
+		{
+			name:        "text against an integer attribute",
+			filter:      &Call{Op: OpGt, Args: []Expression{attr("http.response.status_code"), &AnyValue{Value: "abc"}}},
+			expectedErr: `cannot compare attribute "http.response.status_code" against "abc", which the schema declares as int`,
+		},
+		{
+			name:        "a word against a boolean attribute",
+			filter:      eq(attr("cache.hit"), &AnyValue{Value: "yes"}),
+			expectedErr: `cannot compare attribute "cache.hit" against "yes", which the schema declares as bool`,
+		},
+		{
+			name:        "an ordering of a boolean attribute",
+			filter:      &Call{Op: OpGte, Args: []Expression{attr("cache.hit"), &AnyValue{Value: "true"}}},
+			expectedErr: `operator "gte" has no ordering for attribute "cache.hit", which the schema declares as bool`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, ValidateFilter(test.filter, WithAttributes(semanticConventions(t))),
+				"validation does not consult the schema")
+			_, err := ResolveConstants(test.filter, WithAttributes(semanticConventions(t)))
+			require.ErrorContains(t, err, test.expectedErr)
+
+			_, err = ResolveConstants(test.filter)
+			require.NoError(t, err, "without a schema the constant is storage's to read")
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A template is a filter with placeholders where constants go, such as "errors in service $service
+// slower than $threshold", kept in a library of standard searches and bound to values when one is
+// run. Binding substitutes values into the tree rather than text into a query, and a parameter can
+// only ever be a constant, so no value a user supplies can become part of a filter's structure.
+//
+// A placeholder is an untyped constant written `$name`, or an element of a list written the same
+// way: the untyped constant is the one a template can hold in every encoding, including the JSON
+// form a saved search is kept in, and the parameter bound to it supplies the type. A name is a
+// letter or an underscore followed by letters, digits and underscores. `$$` writes a constant that
+// begins with a literal `$`, and any other text beginning with `$` is refused, so a mistyped
+// placeholder is not silently taken for a value.
+//
+//	{"op":"and","args":[
+//	  {"call":{"op":"eq","args":[{"field":{"name":"service","level":"resource"}},{"scalar":{"value":"$service"}}]}},
+//	  {"call":{"op":"gt","args":[{"field":{"name":"duration","level":"span"}},{"scalar":{"value":"$threshold"}}]}}]}
+
+// ValidateTemplate checks that a template is well formed before it is bound: every placeholder is
+// spelled as one, stands where a constant or a list may, and the filter around the placeholders is
+// one ValidateFilter accepts. Whether a value suits the place it is bound to is known only once it
+// is, and Bind answers that.
+//
+// It takes the same options as ValidateFilter, so a template is checked against the fields it will
+// be finalized against.
+func ValidateTemplate(template *Call, opts ...Option) error {
+	if template == nil {
+		return errors.New("template is empty")
+	}
+	standIn, err := templateWalker{standIn: true}.call(template, 1)
+	if err != nil {
+		return err
+	}
+	if err := ValidateFilter(standIn, opts...); err != nil {
+		return fmt.Errorf("template is not a valid filter: %w", err)
+	}
+	return nil
+}
+
+// Placeholders returns the names of a template's placeholders, each once and in sorted order, which
+// is what a form asking for the parameters would list. It expects a template ValidateTemplate has
+// accepted, and leaves out a malformed placeholder.
+func Placeholders(template *Call) []string {
+	var names []string
+	walker := templateWalker{standIn: true, visit: func(name string) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}}
+	_, _ = walker.call(template, 1)
+	slices.Sort(names)
+	return names
+}
+
+// Bind substitutes a value for each of a template's placeholders and finalizes the result, so what it
+// returns is ready for a search. The template is untouched, and can be bound again.
+//
+// A value is a constant, which replaces the placeholder as the node it is — an IntValue is compared
+// as an integer, and an AnyValue as whatever storage holds — or a List, which may stand where a list
+// does. In a list, a placeholder is replaced by the value's text, or by every element of a List.
+// A reference or a predicate is refused: a parameter supplies a value, never a part of the query.
+//
+// Every placeholder has to be bound, and every value has to be for a placeholder the template
+// has, so a misspelled parameter is an error rather than a value nothing reads.
+func Bind(template *Call, params map[string]Expression, opts ...Option) (*Call, error) {
+	if err := ValidateTemplate(template, opts...); err != nil {
+		return nil, fmt.Errorf("cannot bind the template: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if err := validateParam(name, params[name]); err != nil {
+			return nil, fmt.Errorf("cannot bind the template: %w", err)
+		}
+	}
+	used := map[string]bool{}
+	bound, err := templateWalker{params: params, visit: func(name string) { used[name] = true }}.call(template, 1)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bind the template: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if !used[name] {
+			return nil, fmt.Errorf("cannot bind the template: it has no placeholder $%s", name)
+		}
+	}
+	finalized, err := Finalize(bound, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bind the template: %w", err)
+	}
+	return finalized, nil
+}
+
+// validateParam refuses a value that is not one: anything but a constant or a list.
+func validateParam(name string, value Expression) error {
+	if !isParamName(name) {
+		return fmt.Errorf("parameter %q is not a placeholder name", name)
+	}
+	if _, ok := value.(*List); ok && !isMissing(value) {
+		return nil
+	}
+	if !isConstant(value) {
+		return fmt.Errorf("parameter $%s is %s, and a parameter supplies a constant or a list", name, termName(value))
+	}
+	return nil
+}
+
+// templateWalker rebuilds a template with its placeholders replaced by params. A standIn walker
+// replaces each instead with a stand-in that fits wherever a constant or a list may, which is what
+// lets ValidateFilter check the rest of the template; it is a flag of its own, rather than params
+// being nil, so that Bind with no params refuses every placeholder as unbound. visit, if set, is
+// told each placeholder it passes.
+type templateWalker struct {
+	standIn bool
+	params  map[string]Expression
+	visit   func(name string)
+}
+
+func (w templateWalker) call(call *Call, depth int) (*Call, error) {
+	if call == nil {
+		return nil, nil
+	}
+	if depth > MaxNestingDepth {
+		return nil, ErrTooDeeplyNested
+	}
+	args := make([]Expression, len(call.Args))
+	for i, arg := range call.Args {
+		var err error
+		switch term := arg.(type) {
+		case *Call:
+			args[i], err = w.call(term, depth+1)
+		case *AnyValue:
+			inList := i == 1 && (call.Op == OpIn || call.Op == OpNotIn)
+			args[i], err = w.constant(term, inList, call.Args[0])
+		case *List:
+			args[i], err = w.list(term)
+		default:
+			args[i] = arg
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Call{Op: call.Op, Args: args}, nil
+}
+
+// constant replaces an untyped constant that is a placeholder. inList says it stands where a list
+// does, opposite subject.
+func (w templateWalker) constant(value *AnyValue, inList bool, subject Expression) (Expression, error) {
+	if value == nil {
+		return value, nil
+	}
+	name, ok, err := placeholderOf(value.Value)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &AnyValue{Value: unescape(value.Value)}, nil
+	}
+	if w.visit != nil {
+		w.visit(name)
+	}
+	if w.standIn {
+		if inList {
+			return standInList(subject), nil
+		}
+		return &AnyValue{}, nil
+	}
+	param, ok := w.params[name]
+	if !ok {
+		return nil, fmt.Errorf("placeholder $%s is not bound", name)
+	}
+	return param, nil
+}
+
+// standInList is a list ValidateFilter accepts opposite subject: one declaring its type where the
+// subject is an attribute, which declares none.
+func standInList(subject Expression) *List {
+	list := &List{Values: []string{""}}
+	if _, ok := subject.(*FieldRef); !ok {
+		list.Type = ValueTypeString
+	}
+	return list
+}
+
+func (w templateWalker) list(list *List) (*List, error) {
+	if list == nil {
+		return list, nil
+	}
+	out := &List{Type: list.Type, Values: make([]string, 0, len(list.Values))}
+	for _, element := range list.Values {
+		name, ok, err := placeholderOf(element)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			out.Values = append(out.Values, unescape(element))
+			continue
+		}
+		if w.visit != nil {
+			w.visit(name)
+		}
+		if w.standIn {
+			out.Values = append(out.Values, element)
+			continue
+		}
+		param, ok := w.params[name]
+		if !ok {
+			return nil, fmt.Errorf("placeholder $%s is not bound", name)
+		}
+		values, err := elementsOf(name, param, list.Type)
+		if err != nil {
+			return nil, err
+		}
+		out.Values = append(out.Values, values...)
+	}
+	return out, nil
+}
+
+// elementsOf spells a parameter as list elements: a constant as its text, and a list as its elements.
+// A value that declares a type other than the list's would be read as something it is not, so it is
+// refused.
+func elementsOf(name string, param Expression, listType ValueType) ([]string, error) {
+	if list, ok := param.(*List); ok {
+		if list.Type != "" && list.Type != listType {
+			return nil, fmt.Errorf("parameter $%s is a list of %s, bound into a list of %s", name, list.Type, typeName(listType))
+		}
+		return list.Values, nil
+	}
+	text, valueType := scalarOf(param)
+	if valueType != "" && valueType != listType {
+		return nil, fmt.Errorf("parameter $%s is %s, bound into a list of %s", name, termName(param), typeName(listType))
+	}
+	return []string{text}, nil
+}
+
+func typeName(t ValueType) string {
+	if t == "" {
+		return "the field's type"
+	}
+	return string(t)
+}
+
+// placeholderOf reads the name of the placeholder a text is, if it is one.
+func placeholderOf(text string) (string, bool, error) {
+	if !strings.HasPrefix(text, "$") || strings.HasPrefix(text, "$$") {
+		return "", false, nil
+	}
+	name := text[1:]
+	if !isParamName(name) {
+		return "", false, fmt.Errorf("template has malformed placeholder %q; write $$ for a constant beginning with $", text)
+	}
+	return name, true, nil
+}
+
+func unescape(text string) string {
+	if strings.HasPrefix(text, "$$") {
+		return text[1:]
+	}
+	return text
+}
+
+func isParamName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
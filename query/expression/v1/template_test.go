@@ -0,0 +1,202 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expression
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowErrors is the template the package doc describes, as a library of saved searches holds it.
+const slowErrors = `{"op":"and","args":[
+	{"call":{"op":"eq","args":[{"field":{"name":"service","level":"resource"}},{"scalar":{"value":"$service"}}]}},
+	{"call":{"op":"eq","args":[{"field":{"name":"status","level":"span"}},{"scalar":{"value":"error"}}]}},
+	{"call":{"op":"gt","args":[{"field":{"name":"duration","level":"span"}},{"scalar":{"value":"$threshold"}}]}}]}`
+
+func readTemplate(t *testing.T, text string) *Call {
+	var template Call
+	require.NoError(t, json.Unmarshal([]byte(text), &template))
+	return &template
+}
+
+func TestBind(t *testing.T) {
+	template := readTemplate(t, slowErrors)
+	require.NoError(t, ValidateTemplate(template))
+	assert.Equal(t, []string{"service", "threshold"}, Placeholders(template))
+
+	bound, err := Bind(template, map[string]Expression{
+		"service":   &StringValue{Value: "checkout"},
+		"threshold": &DurationValue{Value: 2 * time.Second},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, and(
+		&Call{Op: OpEq, Args: []Expression{&FieldRef{Level: LevelResource, Name: ResourceFieldService}, &StringValue{Value: "checkout"}}},
+		&Call{Op: OpEq, Args: []Expression{&FieldRef{Level: LevelSpan, Name: SpanFieldStatus}, &StringValue{Value: "error"}}},
+		&Call{Op: OpGt, Args: []Expression{spanDuration(), &DurationValue{Value: 2 * time.Second}}},
+	), bound)
+
+	// An untyped value is resolved against its field, as any untyped constant is.
+	bound, err = Bind(template, map[string]Expression{
+		"service":   &AnyValue{Value: "cart"},
+		"threshold": &AnyValue{Value: "500ms"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &DurationValue{Value: 500 * time.Millisecond}, bound.Args[2].(*Call).Args[1])
+
+	assert.Equal(t, readTemplate(t, slowErrors), template, "binding leaves the template untouched")
+}
+
+func TestBind_Lists(t *testing.T) {
+	kinds := &FieldRef{Level: LevelSpan, Name: SpanFieldKind}
+	codes := &AttributeRef{Key: "http.status_code", Level: LevelSpan}
+	template := and(
+		&Call{Op: OpIn, Args: []Expression{kinds, &AnyValue{Value: "$kinds"}}},
+		&Call{Op: OpNotIn, Args: []Expression{codes, &List{Values: []string{"404", "$code", "$more"}, Type: ValueTypeInt}}},
+		&Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "price"}, &AnyValue{Value: "$$5"}}},
+	)
+	require.NoError(t, ValidateTemplate(template))
+	assert.Equal(t, []string{"code", "kinds", "more"}, Placeholders(template))
+
+	bound, err := Bind(template, map[string]Expression{
+		"kinds": &List{Values: []string{"server", "consumer"}},
+		"code":  &IntValue{Value: 500},
+		"more":  &List{Values: []string{"502", "503"}, Type: ValueTypeInt},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, and(
+		&Call{Op: OpIn, Args: []Expression{kinds, &List{Values: []string{"server", "consumer"}}}},
+		&Call{Op: OpNotIn, Args: []Expression{codes, &List{Values: []string{"404", "500", "502", "503"}, Type: ValueTypeInt}}},
+		&Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "price"}, &AnyValue{Value: "$5"}}},
+	), bound)
+}
+
+func TestValidateTemplate_Refuses(t *testing.T) {
+	duration := spanDuration()
+	tests := []struct {
+		name     string
+		template *Call
+		expected string
+	}{
+		{
+			name:     "no template",
+			expected: "template is empty",
+		},
+		{
+			name:     "a placeholder that is not a name",
+			template: &Call{Op: OpGt, Args: []Expression{duration, &AnyValue{Value: "$2s"}}},
+			expected: `template has malformed placeholder "$2s"; write $$ for a constant beginning with $`,
+		},
+		{
+			name:     "a placeholder in a list that is not a name",
+			template: &Call{Op: OpIn, Args: []Expression{&AttributeRef{Key: "k"}, &List{Values: []string{"$"}, Type: ValueTypeString}}},
+			expected: `template has malformed placeholder "$"`,
+		},
+		{
+			name:     "a placeholder where a reference goes",
+			template: &Call{Op: OpExists, Args: []Expression{&AnyValue{Value: "$attribute"}}},
+			expected: `template is not a valid filter: operator "exists" takes a reference, got an untyped constant`,
+		},
+		{
+			name:     "a placeholder where a predicate goes",
+			template: and(&Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "k"}}}, &AnyValue{Value: "$clause"}),
+			expected: `template is not a valid filter: operator "and" takes predicates as arguments, got an untyped constant`,
+		},
+		{
+			name:     "a template invalid around its placeholders",
+			template: &Call{Op: OpGt, Args: []Expression{&FieldRef{Level: LevelSpan, Name: "latency"}, &AnyValue{Value: "$threshold"}}},
+			expected: `template is not a valid filter: unknown built-in field "latency"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTemplate(test.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestBind_Refuses(t *testing.T) {
+	template := readTemplate(t, slowErrors)
+	service := &StringValue{Value: "checkout"}
+	threshold := &DurationValue{Value: time.Second}
+	tests := []struct {
+		name     string
+		params   map[string]Expression
+		expected string
+	}{
+		{
+			name:     "an unbound placeholder",
+			params:   map[string]Expression{"service": service},
+			expected: "cannot bind the template: placeholder $threshold is not bound",
+		},
+		{
+			name:     "a parameter the template has no placeholder for",
+			params:   map[string]Expression{"service": service, "threshold": threshold, "servcie": service},
+			expected: "cannot bind the template: it has no placeholder $servcie",
+		},
+		{
+			name: "a predicate as a parameter, which would change what the filter asks",
+			params: map[string]Expression{
+				"service":   or(&Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "k"}}}),
+				"threshold": threshold,
+			},
+			expected: "cannot bind the template: parameter $service is a predicate, and a parameter supplies a constant or a list",
+		},
+		{
+			name:     "a reference as a parameter",
+			params:   map[string]Expression{"service": &AttributeRef{Key: "k"}, "threshold": threshold},
+			expected: "parameter $service is an attribute reference",
+		},
+		{
+			name:     "a typed-nil parameter",
+			params:   map[string]Expression{"service": (*StringValue)(nil), "threshold": threshold},
+			expected: "parameter $service is an empty term",
+		},
+		{
+			name:     "a parameter of the wrong kind for its place",
+			params:   map[string]Expression{"service": service, "threshold": &BoolValue{Value: true}},
+			expected: `cannot bind the template: operator "gt" compares span.duration against a boolean constant`,
+		},
+		{
+			name:     "a parameter whose text does not read as its field",
+			params:   map[string]Expression{"service": service, "threshold": &AnyValue{Value: "soon"}},
+			expected: `cannot bind the template: cannot compare span.duration against "soon"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Bind(template, test.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestBind_NilParams(t *testing.T) {
+	scalar := &Call{Op: OpEq, Args: []Expression{&FieldRef{Level: LevelResource, Name: "service"}, &AnyValue{Value: "$service"}}}
+	_, err := Bind(scalar, nil)
+	require.EqualError(t, err, "cannot bind the template: placeholder $service is not bound")
+
+	list := &Call{Op: OpIn, Args: []Expression{&AttributeRef{Key: "code"}, &List{Values: []string{"$code"}, Type: ValueTypeInt}}}
+	_, err = Bind(list, nil)
+	require.EqualError(t, err, "cannot bind the template: placeholder $code is not bound")
+
+	bound, err := Bind(&Call{Op: OpExists, Args: []Expression{&AttributeRef{Key: "k"}}}, nil)
+	require.NoError(t, err, "a template without placeholders needs no params")
+	assert.NotNil(t, bound)
+}
+
+func TestBind_RefusesAValueOfAnotherTypeInAList(t *testing.T) {
+	template := &Call{Op: OpIn, Args: []Expression{&AttributeRef{Key: "code"}, &List{Values: []string{"$code"}, Type: ValueTypeInt}}}
+	_, err := Bind(template, map[string]Expression{"code": &StringValue{Value: "500"}})
+	require.EqualError(t, err, "cannot bind the template: parameter $code is a string constant, bound into a list of int")
+
+	_, err = Bind(template, map[string]Expression{"code": &List{Values: []string{"500"}, Type: ValueTypeString}})
+	require.EqualError(t, err, "cannot bind the template: parameter $code is a list of string, bound into a list of int")
+}
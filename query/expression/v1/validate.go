@@ -20,11 +20,20 @@ import (
 // is answered by ResolveConstants, which knows the field it is compared against — and which of
 // the valid things a given backend can serve, which is what a backend's declared capabilities
 // are for.
-func ValidateFilter(filter *Call) error {
+//
+// By default a built-in field is one RFC 0005 defines; WithFields checks against a deployment's
+// own registry instead, so validation accepts exactly the fields that backend serves.
+func ValidateFilter(filter *Call, opts ...Option) error {
 	if filter == nil {
 		return errors.New("filter is empty")
 	}
-	return validateCall(filter, nil, 1)
+	return validator{fields: newOptions(opts).fields}.validateCall(filter, nil, 1)
+}
+
+// validator carries what a filter is checked against through the walk. It is a value: nothing in
+// it changes while a filter is checked.
+type validator struct {
+	fields *FieldRegistry
 }
 
 // MaxNestingDepth is how deeply calls may nest, counting the filter itself as the first level. A
@@ -45,7 +54,7 @@ var ErrTooDeeplyNested = fmt.Errorf("filter nests calls more than %d deep", MaxN
 // validateCall checks one call. quantified carries the collection levels of the enclosing
 // OpSome calls, which is what lets a nested quantifier over an already-bound level be refused, and
 // depth is how many calls deep this one sits, counting itself.
-func validateCall(call *Call, quantified []Level, depth int) error {
+func (v validator) validateCall(call *Call, quantified []Level, depth int) error {
 	if call == nil {
 		return errors.New("filter has a missing predicate")
 	}
@@ -57,27 +66,27 @@ func validateCall(call *Call, quantified []Level, depth int) error {
 		if len(call.Args) < 2 {
 			return fmt.Errorf("operator %q takes at least two arguments, got %d", call.Op, len(call.Args))
 		}
-		return validatePredicateArgs(call, quantified, depth)
+		return v.validatePredicateArgs(call, quantified, depth)
 	case OpNot:
 		if err := wantArgs(call, 1); err != nil {
 			return err
 		}
-		return validatePredicateArgs(call, quantified, depth)
+		return v.validatePredicateArgs(call, quantified, depth)
 	case OpExists:
 		if err := wantArgs(call, 1); err != nil {
 			return err
 		}
-		return validateReference(call.Op, call.Args[0])
+		return v.validateReference(call.Op, call.Args[0])
 	case OpSome:
 		if err := wantArgs(call, 2); err != nil {
 			return err
 		}
-		return validateSome(call, quantified, depth)
+		return v.validateSome(call, quantified, depth)
 	case OpIn, OpNotIn:
 		if err := wantArgs(call, 2); err != nil {
 			return err
 		}
-		if err := validateSubject(call.Op, call.Args[0], quantified); err != nil {
+		if err := v.validateSubject(call.Op, call.Args[0], quantified); err != nil {
 			return err
 		}
 		list, ok := call.Args[1].(*List)
@@ -97,10 +106,10 @@ func validateCall(call *Call, quantified []Level, depth int) error {
 		if err := wantArgs(call, 2); err != nil {
 			return err
 		}
-		if err := validateSubject(call.Op, call.Args[0], quantified); err != nil {
+		if err := v.validateSubject(call.Op, call.Args[0], quantified); err != nil {
 			return err
 		}
-		if err := validateRegexSubject(call.Args[0]); err != nil {
+		if err := v.validateRegexSubject(call.Args[0]); err != nil {
 			return err
 		}
 		pattern, ok := patternText(call.Args[1])
@@ -112,12 +121,12 @@ func validateCall(call *Call, quantified []Level, depth int) error {
 		if err := wantArgs(call, 2); err != nil {
 			return err
 		}
-		return validateComparison(call, quantified)
+		return v.validateComparison(call, quantified)
 	case OpGt, OpLt, OpGte, OpLte:
 		if err := wantArgs(call, 2); err != nil {
 			return err
 		}
-		return validateOrderedComparison(call, quantified)
+		return v.validateOrderedComparison(call, quantified)
 	default:
 		return fmt.Errorf("unknown filter operator %q", call.Op)
 	}
@@ -134,13 +143,13 @@ func wantArgs(call *Call, n int) error {
 
 // validatePredicateArgs checks the arguments of a boolean combinator, each of which
 // must itself be a predicate rather than a bare reference or constant.
-func validatePredicateArgs(call *Call, quantified []Level, depth int) error {
+func (v validator) validatePredicateArgs(call *Call, quantified []Level, depth int) error {
 	for _, arg := range call.Args {
 		nested, ok := arg.(*Call)
 		if !ok {
 			return fmt.Errorf("operator %q takes predicates as arguments, got %s", call.Op, termName(arg))
 		}
-		if err := validateCall(nested, quantified, depth+1); err != nil {
+		if err := v.validateCall(nested, quantified, depth+1); err != nil {
 			return err
 		}
 	}
@@ -150,7 +159,7 @@ func validatePredicateArgs(call *Call, quantified []Level, depth int) error {
 // validateSome checks the existential quantifier: it binds one element of a span's
 // events or links, so its first argument names that collection and its second is the
 // predicate evaluated against the bound element.
-func validateSome(call *Call, quantified []Level, depth int) error {
+func (v validator) validateSome(call *Call, quantified []Level, depth int) error {
 	ref, ok := call.Args[0].(*NestedRef)
 	if !ok || ref == nil {
 		return fmt.Errorf("operator %q takes a collection reference as its first argument, got %s", call.Op, termName(call.Args[0]))
@@ -168,7 +177,7 @@ func validateSome(call *Call, quantified []Level, depth int) error {
 	if !ok {
 		return fmt.Errorf("operator %q takes a predicate as its second argument, got %s", call.Op, termName(call.Args[1]))
 	}
-	return validateCall(predicate, append(slices.Clone(quantified), ref.Level), depth+1)
+	return v.validateCall(predicate, append(slices.Clone(quantified), ref.Level), depth+1)
 }
 
 // validateComparison checks the two operands of a comparison. Each names a value on the span or
@@ -176,16 +185,16 @@ func validateSome(call *Call, quantified []Level, depth int) error {
 // is a comparison no backend can answer, so it is refused here rather than lowered. Whether either
 // operand is a reference does not come into it — `span.startTime < span.endTime` compares two
 // instants, and two attributes hold whatever storage wrote, which is compatible with anything.
-func validateComparison(call *Call, quantified []Level) error {
+func (v validator) validateComparison(call *Call, quantified []Level) error {
 	for _, arg := range call.Args {
-		if err := validateOperand(call.Op, arg, quantified); err != nil {
+		if err := v.validateOperand(call.Op, arg, quantified); err != nil {
 			return err
 		}
 	}
 	if err := validateTimeConstant(call.Op, call.Args); err != nil {
 		return err
 	}
-	left, right := domainOfOperand(call.Args[0]), domainOfOperand(call.Args[1])
+	left, right := v.domainOfOperand(call.Args[0]), v.domainOfOperand(call.Args[1])
 	if left != domainUnknown && right != domainUnknown && left != right {
 		return fmt.Errorf("operator %q compares %s against %s, which hold different kinds of value",
 			call.Op, describe(call.Args[0]), describe(call.Args[1]))
@@ -221,12 +230,12 @@ func errNoWireSpelling(op Operator, constant Expression, kind string) error {
 // validateOrderedComparison adds the one question ordering asks beyond a comparison: whether the
 // values have an order to be compared within. Text does, lexicographically, which is a real query
 // — `span.name > "m"` asks for the names that sort after it.
-func validateOrderedComparison(call *Call, quantified []Level) error {
-	if err := validateComparison(call, quantified); err != nil {
+func (v validator) validateOrderedComparison(call *Call, quantified []Level) error {
+	if err := v.validateComparison(call, quantified); err != nil {
 		return err
 	}
 	for _, arg := range call.Args {
-		if !orderable(arg) {
+		if !v.orderable(arg) {
 			return fmt.Errorf("operator %q has no ordering for %s", call.Op, describe(arg))
 		}
 	}
@@ -237,12 +246,12 @@ func validateOrderedComparison(call *Call, quantified []Level) error {
 // vocabulary has a result type, so there is nothing to say about what comparing one would mean.
 // An operator that takes a call result — a future extraction function, say — arrives with its
 // signature declared rather than through this door (§5.3).
-func validateOperand(op Operator, arg Expression, _ []Level) error {
+func (v validator) validateOperand(op Operator, arg Expression, _ []Level) error {
 	switch term := arg.(type) {
 	case *AttributeRef:
 		return validateAttributeRef(term)
 	case *FieldRef:
-		return validateFieldRef(term)
+		return v.validateFieldRef(term)
 	case *NestedRef:
 		return errCollectionOutOfPlace()
 	}
@@ -269,17 +278,17 @@ func validateElementType(op Operator, subject Expression, list *List) error {
 
 // validateSubject checks the operand an operator reads a value from rather than supplies one
 // to: the left-hand side of membership and of a regular expression.
-func validateSubject(op Operator, arg Expression, _ []Level) error {
-	return validateReference(op, arg)
+func (v validator) validateSubject(op Operator, arg Expression, _ []Level) error {
+	return v.validateReference(op, arg)
 }
 
 // validateReference checks an argument that has to name a value on the span.
-func validateReference(op Operator, arg Expression) error {
+func (v validator) validateReference(op Operator, arg Expression) error {
 	switch term := arg.(type) {
 	case *AttributeRef:
 		return validateAttributeRef(term)
 	case *FieldRef:
-		return validateFieldRef(term)
+		return v.validateFieldRef(term)
 	case *NestedRef:
 		return errCollectionOutOfPlace()
 	default:
@@ -300,7 +309,7 @@ func validateAttributeRef(ref *AttributeRef) error {
 	return nil
 }
 
-func validateFieldRef(ref *FieldRef) error {
+func (v validator) validateFieldRef(ref *FieldRef) error {
 	if ref == nil {
 		return errors.New("filter has a missing reference")
 	}
@@ -315,7 +324,7 @@ func validateFieldRef(ref *FieldRef) error {
 	if ref.Name == "" {
 		return errors.New("field reference has no name")
 	}
-	if _, ok := LookupField(ref.Level, ref.Name); !ok {
+	if _, ok := v.fields.Lookup(ref.Level, ref.Name); !ok {
 		return fmt.Errorf("unknown built-in field %q at the %q level; name an attribute to match a tag of that name instead",
 			ref.Name, ref.Level)
 	}
@@ -354,16 +363,19 @@ func isConstant(e Expression) bool {
 // validateRegexSubject refuses a subject a pattern has nothing to match against. A string field,
 // a word-valued field and an attribute all hold text; a duration or a timestamp does not, and
 // nothing in this API says what text a pattern would be matched against.
-func validateRegexSubject(subject Expression) error {
+func (v validator) validateRegexSubject(subject Expression) error {
 	ref, ok := subject.(*FieldRef)
 	if !ok || ref == nil {
 		return nil
 	}
-	field, _ := LookupField(ref.Level, ref.Name)
+	field, _ := v.fields.Lookup(ref.Level, ref.Name)
 	switch field.Type {
 	case FieldTypeDuration, FieldTypeTimestamp:
 		return fmt.Errorf("operator %q matches text, and %s.%s holds a %s",
 			OpRegex, ref.Level, ref.Name, field.Type)
+	case FieldTypeInt, FieldTypeDouble:
+		return fmt.Errorf("operator %q matches text, and %s.%s holds a number",
+			OpRegex, ref.Level, ref.Name)
 	}
 	return nil
 }
@@ -406,11 +418,11 @@ func domainOf(e Expression) domain {
 // domainOfOperand reads the kind of value either side of a comparison holds. A built-in field
 // holds what its declared type says; an attribute holds whatever storage wrote there, which is
 // not this API's to know.
-func domainOfOperand(e Expression) domain {
+func (v validator) domainOfOperand(e Expression) domain {
 	if ref, ok := e.(*FieldRef); ok && ref != nil {
 		// A field this API does not define is refused before an ordering is asked about, so the
 		// zero Field's empty type is only ever reached by a caller checking one term directly.
-		field, _ := LookupField(ref.Level, ref.Name)
+		field, _ := v.fields.Lookup(ref.Level, ref.Name)
 		return domainOfFieldType(field.Type)
 	}
 	if _, ok := e.(*AttributeRef); ok {
@@ -438,6 +450,8 @@ func domainOfValueType(t ValueType) domain {
 // closed set of words holds text, which is what makes a list of strings the right list for it.
 func domainOfFieldType(t FieldType) domain {
 	switch t {
+	case FieldTypeInt, FieldTypeDouble:
+		return domainNumber
 	case FieldTypeDuration:
 		return domainDuration
 	case FieldTypeTimestamp:
@@ -451,11 +465,17 @@ func domainOfFieldType(t FieldType) domain {
 
 // orderable reports whether an operand has an order to be compared within. Two do not: a boolean,
 // and a field holding one of a closed set of words, because the kinds that sort after "server" is
-// not a question about span kinds.
-func orderable(e Expression) bool {
+// not a question about span kinds. A count or a flag word orders as the number it is, which is
+// what `span.droppedEventsCount > 0` asks.
+func (v validator) orderable(e Expression) bool {
 	if ref, ok := e.(*FieldRef); ok && ref != nil {
-		field, _ := LookupField(ref.Level, ref.Name)
-		return field.Type != FieldTypeSpanKind && field.Type != FieldTypeSpanStatus
+		field, _ := v.fields.Lookup(ref.Level, ref.Name)
+		switch field.Type {
+		case FieldTypeSpanKind, FieldTypeSpanStatus:
+			return false
+		default:
+			return true
+		}
 	}
 	return domainOf(e) != domainBool
 }
@@ -40,6 +40,10 @@ func TestValidateFilter_Accepts(t *testing.T) {
 			name:   "a timestamp field against an instant",
 			filter: &Call{Op: OpLt, Args: []Expression{&FieldRef{Name: SpanFieldStartTime, Level: LevelSpan}, &TimestampValue{Value: time.Unix(0, 0).UTC()}}},
 		},
+		{
+			name:   "a count ordered against a number",
+			filter: &Call{Op: OpGt, Args: []Expression{&FieldRef{Name: SpanFieldDroppedEventsCount, Level: LevelSpan}, &IntValue{Value: 0}}},
+		},
 		{
 			name:   "level-qualified attribute",
 			filter: eq(&AttributeRef{Key: "k8s.pod.name", Level: LevelResource}, &StringValue{Value: "cart-0"}),
@@ -462,6 +466,18 @@ func TestValidateFilter_Rejects(t *testing.T) {
 				&FieldRef{Name: SpanFieldStartTime, Level: LevelSpan}, &StringValue{Value: "2026-.*"},
 			}},
 		},
+		{
+			name:        "a regular expression over a count",
+			expectedErr: `operator "regex" matches text, and span.droppedEventsCount holds a number`,
+			filter: &Call{Op: OpRegex, Args: []Expression{
+				&FieldRef{Name: SpanFieldDroppedEventsCount, Level: LevelSpan}, &StringValue{Value: "1.*"},
+			}},
+		},
+		{
+			name:        "a count against text",
+			expectedErr: `operator "eq" compares span.eventCount against a string constant, which hold different kinds of value`,
+			filter:      eq(&FieldRef{Name: SpanFieldEventCount, Level: LevelSpan}, &StringValue{Value: "3"}),
+		},
 		{
 			name:        "a regular expression over a numeric pattern",
 			expectedErr: `operator "regex" takes a constant string as its pattern, got an integer constant`,
@@ -18,6 +18,9 @@ import (
 // it would otherwise be schema-valid and refused by the validator, or accepted by the validator and
 // unrepresentable on the wire.
 //
+// The JSON codec writes the messages' members down a third time, and the last tests here hold it to
+// the document as well.
+//
 // They read the published document rather than the annotations, because the document is what a
 // client generates from, and CI regenerates it from the annotations and diffs it. It is read as
 // JSON, which is the same document in a form the standard library parses: a YAML parser is not a
@@ -103,6 +106,26 @@ func TestPublishedValueTypesMatchTheDomain(t *testing.T) {
 	assert.ElementsMatch(t, declared, publishedEnum(t, "jaeger.expression.v1.List", "type"))
 }
 
+// TestPublishedValueTypesCoverFieldTypes pins that a list compared against a field can declare the
+// type its elements are read at, for every field type the wire has a spelling for. A numeric field
+// added here with no published type of its kind would leave a caller unable to write the list
+// beside it that the validator accepts. A duration and a timestamp have no wire type (§5.4), and a
+// list of them declares none.
+func TestPublishedValueTypesCoverFieldTypes(t *testing.T) {
+	published := map[domain]bool{}
+	for _, valueType := range publishedEnum(t, "jaeger.expression.v1.List", "type") {
+		published[domainOfValueType(ValueType(valueType))] = true
+	}
+	for _, fieldType := range fieldTypes {
+		switch fieldType {
+		case FieldTypeDuration, FieldTypeTimestamp:
+			continue
+		}
+		assert.True(t, published[domainOfFieldType(fieldType)],
+			"no published list type holds what a %s field does", fieldType)
+	}
+}
+
 // TestPublishedListIsNotEmpty pins the one cardinality rule the schema can state. The validator
 // refuses an empty list too, because a protobuf or gRPC caller is not governed by this document.
 func TestPublishedListIsNotEmpty(t *testing.T) {
@@ -118,3 +141,75 @@ func TestFieldsUseDeclaredLevels(t *testing.T) {
 		assert.Contains(t, declared, string(field.Level), "field %s", field.Name)
 	}
 }
+
+// TestPublishedExpressionMatchesTheCodec compares the oneOf the document publishes against the
+// members the JSON codec reads an Expression from, so a term added to the proto and forgotten in
+// the codec, or the reverse, cannot pass.
+func TestPublishedExpressionMatchesTheCodec(t *testing.T) {
+	definition, ok := schemas(t)["jaeger.expression.v1.Expression"].(map[string]any)
+	require.True(t, ok, "the document defines jaeger.expression.v1.Expression")
+	branches, ok := definition["oneOf"].([]any)
+	require.True(t, ok, "an Expression is a oneOf")
+
+	var required []string
+	for _, branch := range branches {
+		names, ok := branch.(map[string]any)["required"].([]any)
+		require.True(t, ok && len(names) == 1, "each branch requires one member, got %#v", branch)
+		required = append(required, names[0].(string))
+	}
+	assert.ElementsMatch(t, members, required)
+}
+
+// TestPublishedMessagesMatchTheCodec compares the members of every message in the filter against
+// the ones the codec reads, and checks that it writes each member the document requires.
+func TestPublishedMessagesMatchTheCodec(t *testing.T) {
+	for message, names := range messageMembers {
+		definition, ok := schemas(t)["jaeger.expression.v1."+message].(map[string]any)
+		require.True(t, ok, "the document defines %s", message)
+		properties, ok := definition["properties"].(map[string]any)
+		require.True(t, ok, "%s publishes properties", message)
+
+		var published []string
+		for name := range properties {
+			published = append(published, name)
+		}
+		assert.ElementsMatch(t, published, names, "the members of %s", message)
+	}
+
+	filter := &Call{Op: OpAnd, Args: []Expression{
+		&Call{Op: OpSome, Args: []Expression{&NestedRef{Level: LevelLink}, &Call{Op: OpExists, Args: []Expression{&FieldRef{Level: LevelLink, Name: LinkFieldTraceState}}}}},
+		&Call{Op: OpIn, Args: []Expression{&AttributeRef{}, &List{}}},
+		&Call{Op: OpEq, Args: []Expression{&AttributeRef{Key: "a"}, &AnyValue{}}},
+	}}
+	text, err := json.Marshal(filter)
+	require.NoError(t, err)
+	var written map[string]any
+	require.NoError(t, json.Unmarshal(text, &written))
+	requireWrittenMembers(t, "Call", written)
+}
+
+// requireWrittenMembers walks what the codec wrote and checks each object against the members its
+// message requires, even where they hold an empty value.
+func requireWrittenMembers(t *testing.T, message string, written map[string]any) {
+	definition := schemas(t)["jaeger.expression.v1."+message].(map[string]any)
+	required, _ := definition["required"].([]any)
+	for _, name := range required {
+		assert.Contains(t, written, name, "%s is written with %s", message, name)
+	}
+	if message == "Call" {
+		for _, arg := range written["args"].([]any) {
+			requireWrittenMembers(t, "Expression", arg.(map[string]any))
+		}
+		return
+	}
+	if message != "Expression" {
+		return
+	}
+	names := map[string]string{
+		memberAttr: "AttributeReference", memberField: "FieldReference", memberNested: "NestedReference",
+		memberScalar: "Scalar", memberList: "List", memberCall: "Call",
+	}
+	for member, value := range written {
+		requireWrittenMembers(t, names[member], value.(map[string]any))
+	}
+}
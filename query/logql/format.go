@@ -0,0 +1,333 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logql renders a structured filter of Jaeger RFC 0005 (see the expression package) as a
+// LogQL query, the query language of Grafana Loki, so that a trace search can link to the logs the
+// same services wrote for the same traces.
+//
+// A log record is not a span: it carries the resource and the instrumentation scope that wrote it,
+// and the trace and span it was written in, but none of the span's own attributes, name, kind,
+// status or timing. So the part of a filter a log query can say is the part about those, and it is
+// said the way Loki stores OTLP logs:
+//
+//   - A resource attribute, and the resource.service field, is a stream label, named after the key
+//     with every character that cannot be in a label replaced by `_` (`service.name` is
+//     `service_name`).
+//   - The span.traceID and span.spanID fields, and the scope.name and scope.version fields, are the
+//     structured metadata `trace_id`, `span_id`, `scope_name` and `scope_version`, which a label filter
+//     after the stream selector tests.
+//
+// Loki holds every label as text and compares it with =, !=, =~ and !~ only, and a stream or record
+// without a label holds it as the empty string. The rendering accounts for both: a pattern, which
+// Loki anchors at both ends, is let begin and end anywhere; a list is an alternation; and a test that
+// a filter makes false on a missing attribute, such as ne, is paired with one that the label is not
+// empty.
+//
+// Line filters (`|=`, `|~`, `!=`, `!~`) are deliberately left out. They test the text of a log line,
+// and no part of a filter is about that text: a filter has no field for a log record's body, and a
+// span's name is not written into the lines its logs hold, so a line filter for span.name would
+// select the logs that happen to mention the name rather than those written in the span. A filter
+// on span.name is refused, as every field a log record does not carry is.
+package logql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/render"
+)
+
+// structuredMetadata names the structured metadata each field is stored as.
+var structuredMetadata = map[expression.Level]map[string]string{
+	expression.LevelSpan: {
+		expression.SpanFieldTraceID: "trace_id",
+		expression.SpanFieldSpanID:  "span_id",
+	},
+	expression.LevelScope: {
+		expression.ScopeFieldName:    "scope_name",
+		expression.ScopeFieldVersion: "scope_version",
+	},
+}
+
+// Format renders a finalized filter as a LogQL log query: a stream selector followed by any label
+// filters, as in `{service_name="checkout"} | trace_id="4bf92f3577b34da6a3ce929d0e0e4736"`.
+//
+// The filter has to be a conjunction, or a single condition, of conditions on what a log record
+// carries, and it renders everything or nothing: a query missing a condition selects other logs, so a
+// filter with any part LogQL cannot say is refused with a render.InexpressibleError listing every
+// such part. A stream selector needs at least one label a stream must have, so a filter that names
+// no resource is refused too.
+func Format(filter *expression.Call) (string, error) {
+	if filter == nil {
+		return "", &render.InexpressibleError{Language: "LogQL", Parts: []render.Inexpressible{{Reason: noStream}}}
+	}
+	f := &formatter{}
+	conditions := []expression.Expression{filter}
+	paths := []string{""}
+	if filter.Op == expression.OpAnd {
+		conditions, paths = filter.Args, make([]string, len(filter.Args))
+		for i := range filter.Args {
+			paths[i] = fmt.Sprintf("args[%d]", i)
+		}
+	}
+	for i, condition := range conditions {
+		f.condition(condition, paths[i])
+	}
+	if len(f.parts) == 0 && !f.selective {
+		f.refuse("", noStream)
+	}
+	if len(f.parts) > 0 {
+		return "", &render.InexpressibleError{Language: "LogQL", Parts: f.parts}
+	}
+	stream := make([]string, len(f.stream))
+	for i, m := range f.stream {
+		stream[i] = m.String()
+	}
+	query := "{" + strings.Join(stream, ", ") + "}"
+	for _, m := range f.filters {
+		query += " | " + m.String()
+	}
+	return query, nil
+}
+
+const noStream = "a LogQL query selects streams by a label they must hold, and the filter tests no resource attribute"
+
+type formatter struct {
+	stream  []matcher
+	filters []matcher
+	// selective is whether a matcher in stream fails on the streams without its label, which a
+	// stream selector needs one of.
+	selective bool
+	parts     []render.Inexpressible
+}
+
+func (f *formatter) refuse(path, format string, args ...any) {
+	f.parts = append(f.parts, render.Inexpressible{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+// condition renders one conjunct as matchers on a label.
+func (f *formatter) condition(e expression.Expression, path string) {
+	call, ok := e.(*expression.Call)
+	if !ok || call == nil {
+		f.refuse(path, "a predicate is expected")
+		return
+	}
+	negated := call.Op == expression.OpNot
+	if negated {
+		var inner *expression.Call
+		if len(call.Args) == 1 {
+			inner, _ = call.Args[0].(*expression.Call)
+		}
+		if inner == nil {
+			f.refuse(path, "a negation of anything but one comparison")
+			return
+		}
+		call, path = inner, render.ArgPath(path, 0)
+	}
+	switch call.Op {
+	case expression.OpEq, expression.OpNe, expression.OpRegex, expression.OpIn, expression.OpNotIn, expression.OpExists:
+	default:
+		f.refuse(path, "operator %q has no equivalent on a label, which is compared as text for equality or a pattern", call.Op)
+		return
+	}
+	if len(call.Args) == 0 {
+		f.refuse(path, "operator %q without a reference", call.Op)
+		return
+	}
+	label, inStream, ok := f.label(call.Args[0], render.ArgPath(path, 0))
+	if !ok {
+		return
+	}
+	matchers, ok := f.matchers(call, path, label, negated)
+	if !ok {
+		return
+	}
+	if !inStream {
+		f.filters = append(f.filters, matchers...)
+		return
+	}
+	f.stream = append(f.stream, matchers...)
+	for _, m := range matchers {
+		f.selective = f.selective || m.selective()
+	}
+}
+
+// label names the label a reference is stored as, and whether that is a stream label rather than
+// structured metadata.
+func (f *formatter) label(e expression.Expression, path string) (string, bool, bool) {
+	switch ref := e.(type) {
+	case *expression.AttributeRef:
+		if ref == nil {
+			break
+		}
+		if ref.Level == "" {
+			f.refuse(path, "attribute %q may be a span attribute, and a log record carries none", ref.Key)
+			return "", false, false
+		}
+		if ref.Level != expression.LevelResource {
+			f.refuse(path, "a log record carries no %s attributes", ref.Level)
+			return "", false, false
+		}
+		return labelName(ref.Key), true, true
+	case *expression.FieldRef:
+		if ref == nil {
+			break
+		}
+		if ref.Level == expression.LevelResource && ref.Name == expression.ResourceFieldService {
+			return "service_name", true, true
+		}
+		if name, ok := structuredMetadata[ref.Level][ref.Name]; ok {
+			return name, false, true
+		}
+		f.refuse(path, "a log record does not carry field %s.%s", ref.Level, ref.Name)
+		return "", false, false
+	}
+	f.refuse(path, "a reference is expected")
+	return "", false, false
+}
+
+var notInLabel = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// labelName is the label Loki stores an attribute as: its key, with every character that cannot be
+// in a label replaced by `_`, and a leading `_` if the key begins with a digit.
+func labelName(key string) string {
+	name := notInLabel.ReplaceAllString(key, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// matcher is one test of a label, as LogQL writes it in a stream selector and in a label filter.
+type matcher struct {
+	label, op, value string
+}
+
+func (m matcher) String() string {
+	return m.label + m.op + strconv.Quote(m.value)
+}
+
+// selective reports whether a matcher fails on a stream without its label, which is one holding the
+// label as the empty string.
+func (m matcher) selective() bool {
+	switch m.op {
+	case "=":
+		return m.value != ""
+	case "=~":
+		re, err := regexp.Compile("^(?:" + m.value + ")$")
+		return err == nil && !re.MatchString("")
+	}
+	return false
+}
+
+// matchers renders a comparison, or the negation of one, as the label matchers that select the same
+// records.
+func (f *formatter) matchers(call *expression.Call, path, label string, negated bool) ([]matcher, bool) {
+	present := matcher{label, "=~", ".+"}
+	switch call.Op {
+	case expression.OpExists:
+		if negated {
+			return []matcher{{label, "=", ""}}, true
+		}
+		return []matcher{present}, true
+	case expression.OpIn, expression.OpNotIn:
+		pattern, ok := f.alternation(call, path)
+		if !ok {
+			return nil, false
+		}
+		switch {
+		case call.Op == expression.OpIn && !negated, call.Op == expression.OpNotIn && negated:
+			return []matcher{{label, "=~", pattern}}, true
+		case negated:
+			return []matcher{{label, "!~", pattern}}, true
+		default:
+			// not_in is false on a missing attribute, where the negation of in is true.
+			return []matcher{{label, "!~", pattern}, present}, true
+		}
+	}
+	if len(call.Args) != 2 {
+		f.refuse(path, "operator %q with %d arguments", call.Op, len(call.Args))
+		return nil, false
+	}
+	text, ok := f.text(call.Args[1], render.ArgPath(path, 1))
+	if !ok {
+		return nil, false
+	}
+	switch {
+	case call.Op == expression.OpRegex && !negated:
+		return []matcher{{label, "=~", render.Anywhere(text)}}, true
+	case call.Op == expression.OpRegex:
+		return []matcher{{label, "!~", render.Anywhere(text)}}, true
+	case call.Op == expression.OpEq && !negated, call.Op == expression.OpNe && negated:
+		return []matcher{{label, "=", text}}, true
+	case call.Op == expression.OpEq:
+		return []matcher{{label, "!=", text}}, true
+	default:
+		// ne is false on a missing attribute, where the negation of eq is true.
+		return []matcher{{label, "!=", text}, present}, true
+	}
+}
+
+// alternation renders a list as the pattern that matches exactly its elements.
+func (f *formatter) alternation(call *expression.Call, path string) (string, bool) {
+	var list *expression.List
+	if len(call.Args) == 2 {
+		list, _ = call.Args[1].(*expression.List)
+	}
+	if list == nil {
+		f.refuse(path, "a membership test without a list")
+		return "", false
+	}
+	alternatives := make([]string, len(list.Values))
+	for i, element := range list.Values {
+		// Every field a log record carries holds a string.
+		value, err := expression.ReadElement(list, expression.FieldTypeString, element)
+		if err != nil {
+			f.refuse(render.ArgPath(path, 1), "element %q: %v", element, err)
+			return "", false
+		}
+		text, ok := f.text(value, render.ArgPath(path, 1))
+		if !ok {
+			return "", false
+		}
+		alternatives[i] = regexp.QuoteMeta(text)
+	}
+	return strings.Join(alternatives, "|"), true
+}
+
+// text is the text Loki holds a value as. A double has no one text — 0.5 may have been written
+// .5 or 5e-1 — and a duration or a timestamp is not held by any label a record carries.
+func (f *formatter) text(e expression.Expression, path string) (string, bool) {
+	switch value := e.(type) {
+	case *expression.StringValue:
+		if value != nil {
+			return value.Value, true
+		}
+	case *expression.AnyValue:
+		if value != nil {
+			return value.Value, true
+		}
+	case *expression.IntValue:
+		if value != nil {
+			return strconv.FormatInt(value.Value, 10), true
+		}
+	case *expression.BoolValue:
+		if value != nil {
+			return strconv.FormatBool(value.Value), true
+		}
+	case *expression.DoubleValue:
+		f.refuse(path, "a label holds a number as text, and a double is written more than one way")
+		return "", false
+	case *expression.DurationValue:
+		f.refuse(path, "no label a log record carries holds a duration")
+		return "", false
+	case *expression.TimestampValue:
+		f.refuse(path, "no label a log record carries holds a timestamp")
+		return "", false
+	}
+	f.refuse(path, "a value is expected")
+	return "", false
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package logql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/render"
+)
+
+func call(op expression.Operator, args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: op, Args: args}
+}
+
+var (
+	service = &expression.FieldRef{Level: expression.LevelResource, Name: expression.ResourceFieldService}
+	traceID = &expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldTraceID}
+)
+
+func resource(key string) *expression.AttributeRef {
+	return &expression.AttributeRef{Key: key, Level: expression.LevelResource}
+}
+
+func text(value string) *expression.StringValue {
+	return &expression.StringValue{Value: value}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected string
+	}{
+		{
+			name:     "a service",
+			filter:   call(expression.OpEq, service, text("checkout")),
+			expected: `{service_name="checkout"}`,
+		},
+		{
+			name: "a service and a trace",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpEq, traceID, text("4bf92f3577b34da6a3ce929d0e0e4736")),
+			),
+			expected: `{service_name="checkout"} | trace_id="4bf92f3577b34da6a3ce929d0e0e4736"`,
+		},
+		{
+			name: "resource attributes, named as labels",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, resource("k8s.namespace.name"), &expression.AnyValue{Value: "prod"}),
+				call(expression.OpEq, resource("3rd-party"), &expression.BoolValue{Value: true}),
+			),
+			expected: `{k8s_namespace_name="prod", _3rd_party="true"}`,
+		},
+		{
+			name: "a pattern, let begin and end anywhere",
+			filter: call(expression.OpAnd,
+				call(expression.OpRegex, service, text("check")),
+				call(expression.OpRegex, &expression.FieldRef{Level: expression.LevelScope, Name: expression.ScopeFieldName}, text(".*otel.*")),
+			),
+			expected: `{service_name=~".*(?:check).*"} | scope_name=~".*otel.*"`,
+		},
+		{
+			name: "a list, as an alternation",
+			filter: call(expression.OpIn, service,
+				&expression.List{Values: []string{"checkout", "cart.v2"}}),
+			expected: `{service_name=~"checkout|cart\\.v2"}`,
+		},
+		{
+			name: "ne, which a missing attribute fails",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpNe, resource("host.name"), text("a")),
+			),
+			expected: `{service_name="checkout", host_name!="a", host_name=~".+"}`,
+		},
+		{
+			name: "the negation of eq, which a missing attribute satisfies",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpNot, call(expression.OpEq, resource("host.name"), text("a"))),
+			),
+			expected: `{service_name="checkout", host_name!="a"}`,
+		},
+		{
+			name: "existence and its negation",
+			filter: call(expression.OpAnd,
+				call(expression.OpExists, resource("host.name")),
+				call(expression.OpNot, call(expression.OpExists, resource("cloud.region"))),
+			),
+			expected: `{host_name=~".+", cloud_region=""}`,
+		},
+		{
+			name: "not_in and the negation of in",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpNotIn, resource("env"), &expression.List{Values: []string{"dev"}, Type: expression.ValueTypeString}),
+				call(expression.OpNot, call(expression.OpIn, resource("zone"), &expression.List{Values: []string{"a"}, Type: expression.ValueTypeString})),
+			),
+			expected: `{service_name="checkout", env!~"dev", env=~".+", zone!~"a"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := Format(test.filter)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, query)
+		})
+	}
+}
+
+func TestFormat_ReportsWhatHasNoEquivalent(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected []render.Inexpressible
+	}{
+		{
+			name: "what a log record does not carry",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpEq, &expression.AttributeRef{Key: "http.method"}, text("GET")),
+				call(expression.OpEq, &expression.AttributeRef{Key: "http.route", Level: expression.LevelSpan}, text("/")),
+				call(expression.OpEq, &expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldName}, text("GET")),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[1].args[0]", Reason: `attribute "http.method" may be a span attribute, and a log record carries none`},
+				{Path: "args[2].args[0]", Reason: "a log record carries no span attributes"},
+				{Path: "args[3].args[0]", Reason: "a log record does not carry field span.name"},
+			},
+		},
+		{
+			name: "a pattern on the span name, which no line filter stands for",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, service, text("checkout")),
+				call(expression.OpRegex, &expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldName}, text("GET")),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[1].args[0]", Reason: "a log record does not carry field span.name"},
+			},
+		},
+		{
+			name: "an ordered comparison and a disjunction",
+			filter: call(expression.OpAnd,
+				call(expression.OpGt, resource("replicas"), &expression.IntValue{Value: 2}),
+				call(expression.OpOr,
+					call(expression.OpEq, service, text("a")),
+					call(expression.OpEq, service, text("b")),
+				),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[0]", Reason: `operator "gt" has no equivalent on a label, which is compared as text for equality or a pattern`},
+				{Path: "args[1]", Reason: `operator "or" has no equivalent on a label, which is compared as text for equality or a pattern`},
+			},
+		},
+		{
+			name: "values no label holds",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, resource("ratio"), &expression.DoubleValue{Value: 0.5}),
+				call(expression.OpEq, resource("uptime"), &expression.DurationValue{Value: time.Hour}),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[0].args[1]", Reason: "a label holds a number as text, and a double is written more than one way"},
+				{Path: "args[1].args[1]", Reason: "no label a log record carries holds a duration"},
+			},
+		},
+		{
+			name:     "no stream a query could select",
+			filter:   call(expression.OpEq, traceID, text("4bf92f3577b34da6a3ce929d0e0e4736")),
+			expected: []render.Inexpressible{{Reason: noStream}},
+		},
+		{
+			name:     "a stream selector that would match a stream without the label",
+			filter:   call(expression.OpNot, call(expression.OpEq, service, text("checkout"))),
+			expected: []render.Inexpressible{{Reason: noStream}},
+		},
+		{
+			name:     "no filter",
+			expected: []render.Inexpressible{{Reason: noStream}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Format(test.filter)
+			var inexpressible *render.InexpressibleError
+			require.True(t, errors.As(err, &inexpressible), "got %v", err)
+			assert.Equal(t, test.expected, inexpressible.Parts)
+		})
+	}
+}
+
+func TestFormat_Error(t *testing.T) {
+	_, err := Format(nil)
+	assert.EqualError(t, err, "logql: the filter has no LogQL equivalent: "+noStream)
+}
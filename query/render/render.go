@@ -0,0 +1,73 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package render holds what rendering a structured filter of Jaeger RFC 0005 (see the expression
+// package) into another query language takes whatever the language: the error that refuses the
+// parts of a filter the language cannot say, the paths that locate them, and the reading of a
+// filter's pattern, which matches anywhere, in a language that anchors its patterns at both ends, as
+// TraceQL and LogQL both do.
+package render
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// InexpressibleError lists the parts of a filter a query language has no equivalent for, each
+// located by its path from the root in the field names of the proto3 JSON form (`args[1].args[0]`).
+type InexpressibleError struct {
+	// Language is the name of the language, as its own documentation spells it (`TraceQL`).
+	Language string
+	Parts    []Inexpressible
+}
+
+// Inexpressible is one part of a filter with no equivalent in the language it was rendered into.
+type Inexpressible struct {
+	Path   string
+	Reason string
+}
+
+func (e *InexpressibleError) Error() string {
+	parts := make([]string, len(e.Parts))
+	for i, part := range e.Parts {
+		parts[i] = part.Reason
+		if part.Path != "" {
+			parts[i] = part.Path + ": " + part.Reason
+		}
+	}
+	return fmt.Sprintf("%s: the filter has no %s equivalent: %s",
+		strings.ToLower(e.Language), e.Language, strings.Join(parts, "; "))
+}
+
+// ArgPath is the path of the i-th argument of the call at path, the root's being `args[i]`.
+func ArgPath(path string, i int) string {
+	if path == "" {
+		return fmt.Sprintf("args[%d]", i)
+	}
+	return fmt.Sprintf("%s.args[%d]", path, i)
+}
+
+// Anywhere renders a filter's pattern, which matches anywhere, as one anchored at both ends, by
+// letting it begin and end anywhere. A pattern that already does is left as it is, so that it reads
+// back as itself.
+func Anywhere(pattern string) string {
+	if parsed, err := syntax.Parse(pattern, syntax.Perl); err == nil && UnanchoredEnds(parsed) {
+		return pattern
+	}
+	return ".*(?:" + pattern + ").*"
+}
+
+// UnanchoredEnds reports whether a pattern begins and ends with `.*`, which is when matching it
+// against the whole value and matching it anywhere in the value agree.
+func UnanchoredEnds(re *syntax.Regexp) bool {
+	if isAnyString(re) {
+		return true
+	}
+	return re.Op == syntax.OpConcat && len(re.Sub) >= 2 &&
+		isAnyString(re.Sub[0]) && isAnyString(re.Sub[len(re.Sub)-1])
+}
+
+func isAnyString(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpStar && (re.Sub[0].Op == syntax.OpAnyCharNotNL || re.Sub[0].Op == syntax.OpAnyChar)
+}
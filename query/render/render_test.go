@@ -0,0 +1,58 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"regexp/syntax"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInexpressibleError(t *testing.T) {
+	err := &InexpressibleError{Language: "TraceQL", Parts: []Inexpressible{
+		{Path: "args[0]", Reason: "field span.flags has no TraceQL intrinsic"},
+		{Reason: "the filter nests calls more than 20 deep"},
+	}}
+	assert.Equal(t, "traceql: the filter has no TraceQL equivalent: args[0]: field span.flags has no TraceQL intrinsic; the filter nests calls more than 20 deep", err.Error())
+}
+
+func TestArgPath(t *testing.T) {
+	assert.Equal(t, "args[1]", ArgPath("", 1))
+	assert.Equal(t, "args[1].args[0]", ArgPath("args[1]", 0))
+}
+
+func TestAnywhere(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "GET", expected: ".*(?:GET).*"},
+		{pattern: "a|b", expected: ".*(?:a|b).*"},
+		{pattern: ".*GET.*", expected: ".*GET.*"},
+		{pattern: "(?s).*GET.*", expected: "(?s).*GET.*"},
+		{pattern: ".*", expected: ".*"},
+		{pattern: ".*GET", expected: ".*(?:.*GET).*"},
+		{pattern: "(", expected: ".*(?:().*"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Anywhere(test.pattern), test.pattern)
+	}
+}
+
+func TestUnanchoredEnds(t *testing.T) {
+	for pattern, expected := range map[string]bool{
+		".*":        true,
+		".*a.*":     true,
+		".*a":       false,
+		"a.*":       false,
+		".+a.*":     false,
+		"(?s).*a.*": true,
+	} {
+		parsed, err := syntax.Parse(pattern, syntax.Perl)
+		require.NoError(t, err)
+		assert.Equal(t, expected, UnanchoredEnds(parsed), pattern)
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package traceql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/render"
+)
+
+// Format renders a finalized filter as the TraceQL span selector that matches the same spans, which
+// is what Parse reads back. A nil filter matches every span and renders as `{}`.
+//
+// It renders everything or nothing: a selector missing part of a filter matches other spans, so a
+// filter with any part TraceQL cannot say is refused with a render.InexpressibleError listing every
+// such part rather than rendered without it. The parts are these:
+//
+//   - A built-in field with no TraceQL intrinsic, such as span.startTime or a dropped count.
+//   - A timestamp, or a double that is NaN or infinite, which TraceQL has no literal for.
+//   - An untyped constant. TraceQL types every value it compares, and which type a backend would
+//     have matched is not known. Finalizing with a schema of attribute types gives one a type (see
+//     expression.AttributeSchema).
+//   - A quantifier over a predicate with more than one comparison. An event or link reference in
+//     TraceQL matches any element of the collection, so two of them need not be the same element.
+//
+// A filter that was not finalized may render inexactly, since finalizing is what gives a constant
+// compared against a field its type.
+func Format(filter *expression.Call) (string, error) {
+	if filter == nil {
+		return "{}", nil
+	}
+	f := &formatter{}
+	text := f.call(filter, "", 1, false)
+	if len(f.parts) > 0 {
+		return "", &render.InexpressibleError{Language: "TraceQL", Parts: f.parts}
+	}
+	return "{ " + text + " }", nil
+}
+
+type formatter struct {
+	parts []render.Inexpressible
+}
+
+func (f *formatter) refuse(path, format string, args ...any) string {
+	f.parts = append(f.parts, render.Inexpressible{Path: path, Reason: fmt.Sprintf(format, args...)})
+	return ""
+}
+
+// operators maps each comparison onto TraceQL's spelling of it, as comparisons maps the other way.
+var operators = map[expression.Operator]string{
+	expression.OpEq: "=", expression.OpNe: "!=",
+	expression.OpGt: ">", expression.OpGte: ">=", expression.OpLt: "<", expression.OpLte: "<=",
+	expression.OpRegex: "=~",
+}
+
+// call renders one call. nested is true where the call is an operand of a boolean combinator, which
+// is where a combinator has to be parenthesized to keep its grouping.
+func (f *formatter) call(call *expression.Call, path string, depth int, nested bool) string {
+	if call == nil {
+		return f.refuse(path, "an empty predicate")
+	}
+	if depth > expression.MaxNestingDepth {
+		return f.refuse(path, "the filter nests calls more than %d deep", expression.MaxNestingDepth)
+	}
+	switch call.Op {
+	case expression.OpAnd, expression.OpOr:
+		joiner := " && "
+		if call.Op == expression.OpOr {
+			joiner = " || "
+		}
+		parts := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			parts[i] = f.predicate(arg, render.ArgPath(path, i), depth, true)
+		}
+		return group(strings.Join(parts, joiner), nested)
+	case expression.OpNot:
+		if len(call.Args) != 1 {
+			return f.refuse(path, "a negation of %d predicates", len(call.Args))
+		}
+		return "!(" + f.predicate(call.Args[0], render.ArgPath(path, 0), depth, false) + ")"
+	case expression.OpExists:
+		if len(call.Args) != 1 {
+			return f.refuse(path, "an existence test of %d references", len(call.Args))
+		}
+		return f.reference(call.Args[0], render.ArgPath(path, 0)) + " != nil"
+	case expression.OpIn, expression.OpNotIn:
+		return group(f.membership(call, path), nested)
+	case expression.OpSome:
+		return f.some(call, path, depth, nested)
+	}
+	op, ok := operators[call.Op]
+	if !ok || len(call.Args) != 2 {
+		return f.refuse(path, "operator %q with %d arguments", call.Op, len(call.Args))
+	}
+	left := f.operand(call.Args[0], render.ArgPath(path, 0), call.Args[1])
+	if call.Op == expression.OpRegex {
+		return left + " =~ " + f.pattern(call.Args[1], render.ArgPath(path, 1))
+	}
+	return left + " " + op + " " + f.operand(call.Args[1], render.ArgPath(path, 1), call.Args[0])
+}
+
+func (f *formatter) predicate(arg expression.Expression, path string, depth int, nested bool) string {
+	call, ok := arg.(*expression.Call)
+	if !ok {
+		return f.refuse(path, "a predicate is expected, got a %T", arg)
+	}
+	return f.call(call, path, depth+1, nested)
+}
+
+func group(text string, nested bool) string {
+	if nested {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// membership spells a list as the comparisons it stands for: membership is equality with one of the
+// elements, and its negation inequality with each.
+func (f *formatter) membership(call *expression.Call, path string) string {
+	list, ok := call.Args[1].(*expression.List)
+	if len(call.Args) != 2 || !ok || list == nil {
+		return f.refuse(path, "a membership test without a list")
+	}
+	subject := f.reference(call.Args[0], render.ArgPath(path, 0))
+	var fieldType expression.FieldType
+	if ref, ok := call.Args[0].(*expression.FieldRef); ok && ref != nil {
+		field, _ := expression.LookupField(ref.Level, ref.Name)
+		fieldType = field.Type
+	}
+	op, joiner := " = ", " || "
+	if call.Op == expression.OpNotIn {
+		op, joiner = " != ", " && "
+	}
+	parts := make([]string, len(list.Values))
+	for i, element := range list.Values {
+		value, err := expression.ReadElement(list, fieldType, element)
+		if err != nil {
+			return f.refuse(render.ArgPath(path, 1), "element %q: %v", element, err)
+		}
+		parts[i] = subject + op + f.operand(value, render.ArgPath(path, 1), call.Args[0])
+	}
+	return strings.Join(parts, joiner)
+}
+
+// some renders a quantifier whose predicate is one comparison: with one reference to the element
+// there is only one element to be the same as, so TraceQL's reading of `event.x` as "any event"
+// agrees with it.
+func (f *formatter) some(call *expression.Call, path string, depth int, nested bool) string {
+	if len(call.Args) != 2 {
+		return f.refuse(path, "a quantifier of %d arguments", len(call.Args))
+	}
+	predicate, ok := call.Args[1].(*expression.Call)
+	if !ok || predicate == nil {
+		return f.refuse(render.ArgPath(path, 1), "a quantifier without a predicate")
+	}
+	switch predicate.Op {
+	case expression.OpAnd, expression.OpOr, expression.OpNot, expression.OpSome:
+		return f.refuse(path, "a quantifier over %q correlates several conditions on one element, and TraceQL matches each against any element", predicate.Op)
+	}
+	return f.call(predicate, render.ArgPath(path, 1), depth+1, nested)
+}
+
+// fieldSpellings is the TraceQL name of each field with one, the inverse of intrinsics. A field
+// spelled two ways there is spelled the short way here.
+var fieldSpellings = func() map[expression.Level]map[string]string {
+	spellings := map[expression.Level]map[string]string{
+		expression.LevelResource: {expression.ResourceFieldService: "resource.service.name"},
+	}
+	for name, field := range intrinsics {
+		if spellings[field.Level] == nil {
+			spellings[field.Level] = map[string]string{}
+		}
+		if current, ok := spellings[field.Level][field.Name]; !ok || len(name) < len(current) {
+			spellings[field.Level][field.Name] = name
+		}
+	}
+	return spellings
+}()
+
+// scopeNames is the TraceQL scope of each level, the inverse of scopes.
+var scopeNames = func() map[expression.Level]string {
+	names := map[expression.Level]string{}
+	for scope, level := range scopes {
+		names[level] = scope
+	}
+	return names
+}()
+
+func (f *formatter) reference(e expression.Expression, path string) string {
+	switch ref := e.(type) {
+	case *expression.AttributeRef:
+		if ref == nil {
+			break
+		}
+		key := ref.Key
+		if !isPlainKey(key) {
+			key = strconv.Quote(key)
+		}
+		return scopeNames[ref.Level] + "." + key
+	case *expression.FieldRef:
+		if ref == nil {
+			break
+		}
+		if name, ok := fieldSpellings[ref.Level][ref.Name]; ok {
+			return name
+		}
+		return f.refuse(path, "field %s.%s has no TraceQL intrinsic", ref.Level, ref.Name)
+	}
+	return f.refuse(path, "a reference is expected")
+}
+
+// isPlainKey reports whether an attribute key reads back as itself after its scope, which is when it
+// needs no quotes.
+func isPlainKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !isIdentPart(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// operand renders either side of a comparison. other is the term opposite it, which decides how a
+// string is written: the words span.kind and span.status hold are bare in TraceQL.
+func (f *formatter) operand(e expression.Expression, path string, other expression.Expression) string {
+	switch value := e.(type) {
+	case *expression.AttributeRef, *expression.FieldRef:
+		return f.reference(e, path)
+	case *expression.StringValue:
+		if value != nil && holdsWords(other) {
+			return value.Value
+		}
+		if value != nil {
+			return strconv.Quote(value.Value)
+		}
+	case *expression.IntValue:
+		if value != nil {
+			return strconv.FormatInt(value.Value, 10)
+		}
+	case *expression.DoubleValue:
+		if value != nil && (math.IsNaN(value.Value) || math.IsInf(value.Value, 0)) {
+			return f.refuse(path, "TraceQL has no literal for the double %v", value.Value)
+		}
+		if value != nil {
+			text := strconv.FormatFloat(value.Value, 'f', -1, 64)
+			if !strings.Contains(text, ".") {
+				text += ".0"
+			}
+			return text
+		}
+	case *expression.BoolValue:
+		if value != nil {
+			return strconv.FormatBool(value.Value)
+		}
+	case *expression.DurationValue:
+		if value != nil {
+			return value.Value.String()
+		}
+	case *expression.TimestampValue:
+		return f.refuse(path, "TraceQL has no timestamp literal")
+	case *expression.AnyValue:
+		return f.refuse(path, "an untyped constant; TraceQL types every value it compares")
+	}
+	return f.refuse(path, "a value is expected")
+}
+
+func holdsWords(e expression.Expression) bool {
+	ref, ok := e.(*expression.FieldRef)
+	if !ok || ref == nil {
+		return false
+	}
+	field, _ := expression.LookupField(ref.Level, ref.Name)
+	return field.Type == expression.FieldTypeSpanKind || field.Type == expression.FieldTypeSpanStatus
+}
+
+// pattern renders a filter's pattern, which matches anywhere, as TraceQL's, which matches the whole
+// value (see render.Anywhere).
+func (f *formatter) pattern(e expression.Expression, path string) string {
+	var text string
+	switch value := e.(type) {
+	case *expression.StringValue:
+		if value == nil {
+			return f.refuse(path, "a pattern is expected")
+		}
+		text = value.Value
+	case *expression.AnyValue:
+		if value == nil {
+			return f.refuse(path, "a pattern is expected")
+		}
+		text = value.Value
+	default:
+		return f.refuse(path, "a pattern is expected")
+	}
+	return strconv.Quote(render.Anywhere(text))
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package traceql
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/render"
+)
+
+func TestFormat(t *testing.T) {
+	service := &expression.FieldRef{Level: expression.LevelResource, Name: expression.ResourceFieldService}
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected string
+	}{
+		{
+			name: "a conjunction",
+			filter: call(expression.OpAnd,
+				call(expression.OpGte, &expression.AttributeRef{Key: "http.status_code", Level: expression.LevelSpan}, &expression.IntValue{Value: 500}),
+				call(expression.OpEq, service, &expression.StringValue{Value: "api"}),
+			),
+			expected: `{ span.http.status_code >= 500 && resource.service.name = "api" }`,
+		},
+		{
+			name:     "a duration",
+			filter:   call(expression.OpGt, spanField(expression.SpanFieldDuration), &expression.DurationValue{Value: 90 * time.Second}),
+			expected: `{ duration > 1m30s }`,
+		},
+		{
+			name:     "a status, written bare",
+			filter:   call(expression.OpEq, spanField(expression.SpanFieldStatus), &expression.StringValue{Value: "error"}),
+			expected: `{ status = error }`,
+		},
+		{
+			name:     "an intrinsic with only a scoped spelling",
+			filter:   call(expression.OpEq, spanField(expression.SpanFieldTraceID), &expression.StringValue{Value: "4bf92f35"}),
+			expected: `{ trace:id = "4bf92f35" }`,
+		},
+		{
+			name:     "an unqualified attribute",
+			filter:   call(expression.OpEq, &expression.AttributeRef{Key: "http.method"}, &expression.StringValue{Value: "GET"}),
+			expected: `{ .http.method = "GET" }`,
+		},
+		{
+			name:     "an attribute name that has to be quoted",
+			filter:   call(expression.OpEq, &expression.AttributeRef{Key: "my key", Level: expression.LevelScope}, &expression.BoolValue{Value: true}),
+			expected: `{ instrumentation."my key" = true }`,
+		},
+		{
+			name:     "a double that happens to be whole",
+			filter:   call(expression.OpLt, &expression.AttributeRef{Key: "ratio", Level: expression.LevelSpan}, &expression.DoubleValue{Value: 2}),
+			expected: `{ span.ratio < 2.0 }`,
+		},
+		{
+			name:     "a pattern, let begin and end anywhere",
+			filter:   call(expression.OpRegex, spanField(expression.SpanFieldName), &expression.StringValue{Value: "GET|POST"}),
+			expected: `{ name =~ ".*(?:GET|POST).*" }`,
+		},
+		{
+			name:     "a pattern that already begins and ends anywhere",
+			filter:   call(expression.OpRegex, spanField(expression.SpanFieldName), &expression.StringValue{Value: ".*GET.*"}),
+			expected: `{ name =~ ".*GET.*" }`,
+		},
+		{
+			name:     "an existence test",
+			filter:   call(expression.OpExists, &expression.AttributeRef{Key: "error", Level: expression.LevelSpan}),
+			expected: `{ span.error != nil }`,
+		},
+		{
+			name: "a membership test, spelled as the comparisons it stands for",
+			filter: call(expression.OpIn, spanField(expression.SpanFieldKind),
+				&expression.List{Values: []string{"server", "consumer"}}),
+			expected: `{ kind = server || kind = consumer }`,
+		},
+		{
+			name: "a negated membership test inside a conjunction",
+			filter: call(expression.OpAnd,
+				call(expression.OpNotIn, &expression.AttributeRef{Key: "code", Level: expression.LevelSpan},
+					&expression.List{Values: []string{"1", "2"}, Type: expression.ValueTypeInt}),
+				call(expression.OpNot, call(expression.OpEq, service, &expression.StringValue{Value: "api"})),
+			),
+			expected: `{ (span.code != 1 && span.code != 2) && !(resource.service.name = "api") }`,
+		},
+		{
+			name: "a quantifier over one comparison",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpEq, &expression.FieldRef{Level: expression.LevelEvent, Name: expression.EventFieldName}, &expression.StringValue{Value: "exception"})),
+			expected: `{ event:name = "exception" }`,
+		},
+		{
+			name:     "no filter",
+			expected: `{}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := Format(test.filter)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, text)
+		})
+	}
+}
+
+func TestFormat_ParsesBack(t *testing.T) {
+	for _, query := range []string{
+		`{ span.http.status_code >= 500 && resource.service.name = "api" }`,
+		`{ (status = error || duration > 2s) && !(.http.method = "GET") }`,
+		`{ span."my key" = true && instrumentation.ratio < 0.5 }`,
+		"{ name =~ `.*GET /api/.*` && span:parentID = nil }",
+		`{ link:traceID = "4bf92f35" || event:timeSinceStart >= 50µs }`,
+		`{ kind != server && span.retries <= -1 }`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			filter, err := Parse(query)
+			require.NoError(t, err)
+			text, err := Format(filter)
+			require.NoError(t, err)
+			again, err := Parse(text)
+			require.NoError(t, err, text)
+			assert.Equal(t, filter, again, text)
+		})
+	}
+}
+
+func TestFormat_ReportsWhatHasNoEquivalent(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected []render.Inexpressible
+	}{
+		{
+			name:     "a field with no intrinsic",
+			filter:   call(expression.OpGt, spanField(expression.SpanFieldDroppedEventsCount), &expression.IntValue{Value: 0}),
+			expected: []render.Inexpressible{{Path: "args[0]", Reason: "field span.droppedEventsCount has no TraceQL intrinsic"}},
+		},
+		{
+			name: "a timestamp and an untyped constant, both reported",
+			filter: call(expression.OpOr,
+				call(expression.OpGt, spanField(expression.SpanFieldDuration), &expression.DurationValue{Value: time.Second}),
+				call(expression.OpAnd,
+					call(expression.OpEq, &expression.AttributeRef{Key: "region"}, &expression.AnyValue{Value: "eu"}),
+					call(expression.OpLt, &expression.AttributeRef{Key: "at", Level: expression.LevelSpan},
+						&expression.TimestampValue{Value: time.Unix(0, 0)}),
+				),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[1].args[0].args[1]", Reason: "an untyped constant; TraceQL types every value it compares"},
+				{Path: "args[1].args[1].args[1]", Reason: "TraceQL has no timestamp literal"},
+			},
+		},
+		{
+			name: "doubles that are not finite",
+			filter: call(expression.OpAnd,
+				call(expression.OpGt, &expression.AttributeRef{Key: "ratio", Level: expression.LevelSpan}, &expression.DoubleValue{Value: math.NaN()}),
+				call(expression.OpLt, &expression.AttributeRef{Key: "ratio", Level: expression.LevelSpan}, &expression.DoubleValue{Value: math.Inf(1)}),
+				call(expression.OpIn, &expression.AttributeRef{Key: "ratio", Level: expression.LevelSpan},
+					&expression.List{Values: []string{"0.5", "-Inf"}, Type: expression.ValueTypeDouble}),
+			),
+			expected: []render.Inexpressible{
+				{Path: "args[0].args[1]", Reason: "TraceQL has no literal for the double NaN"},
+				{Path: "args[1].args[1]", Reason: "TraceQL has no literal for the double +Inf"},
+				{Path: "args[2].args[1]", Reason: "TraceQL has no literal for the double -Inf"},
+			},
+		},
+		{
+			name: "a quantifier correlating two conditions",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpAnd,
+					call(expression.OpEq, &expression.FieldRef{Level: expression.LevelEvent, Name: expression.EventFieldName}, &expression.StringValue{Value: "exception"}),
+					call(expression.OpExists, &expression.AttributeRef{Key: "exception.type", Level: expression.LevelEvent}),
+				)),
+			expected: []render.Inexpressible{{Reason: `a quantifier over "and" correlates several conditions on one element, and TraceQL matches each against any element`}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Format(test.filter)
+			var inexpressible *render.InexpressibleError
+			require.True(t, errors.As(err, &inexpressible), "got %v", err)
+			assert.Equal(t, test.expected, inexpressible.Parts)
+		})
+	}
+}
+
+func TestFormat_Error(t *testing.T) {
+	_, err := Format(call(expression.OpGt, spanField(expression.SpanFieldDroppedEventsCount), &expression.IntValue{Value: 0}))
+	assert.EqualError(t, err, "traceql: the filter has no TraceQL equivalent: args[0]: field span.droppedEventsCount has no TraceQL intrinsic")
+}
+
+// TestFormat_ParsesBackEquivalent covers the filters that parse back into an equivalent filter
+// rather than themselves, because TraceQL has no construct of their own.
+func TestFormat_ParsesBackEquivalent(t *testing.T) {
+	kind := spanField(expression.SpanFieldKind)
+	eventName := &expression.FieldRef{Level: expression.LevelEvent, Name: expression.EventFieldName}
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected *expression.Call
+	}{
+		{
+			name:   "membership, as a disjunction",
+			filter: call(expression.OpIn, kind, &expression.List{Values: []string{"server", "consumer"}}),
+			expected: call(expression.OpOr,
+				call(expression.OpEq, kind, &expression.StringValue{Value: "server"}),
+				call(expression.OpEq, kind, &expression.StringValue{Value: "consumer"}),
+			),
+		},
+		{
+			name:   "negated membership, as a conjunction",
+			filter: call(expression.OpNotIn, kind, &expression.List{Values: []string{"server", "consumer"}}),
+			expected: call(expression.OpAnd,
+				call(expression.OpNe, kind, &expression.StringValue{Value: "server"}),
+				call(expression.OpNe, kind, &expression.StringValue{Value: "consumer"}),
+			),
+		},
+		{
+			name: "a quantifier, as its comparison",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpEq, eventName, &expression.StringValue{Value: "exception"})),
+			expected: call(expression.OpEq, eventName, &expression.StringValue{Value: "exception"}),
+		},
+		{
+			name:     "a pattern, as the one let begin and end anywhere",
+			filter:   call(expression.OpRegex, spanField(expression.SpanFieldName), &expression.StringValue{Value: "GET"}),
+			expected: call(expression.OpRegex, spanField(expression.SpanFieldName), &expression.StringValue{Value: ".*(?:GET).*"}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := Format(test.filter)
+			require.NoError(t, err)
+			again, err := Parse(text)
+			require.NoError(t, err, text)
+			assert.Equal(t, test.expected, again, text)
+		})
+	}
+}
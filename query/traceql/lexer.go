@@ -0,0 +1,218 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package traceql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenOpenBrace
+	tokenCloseBrace
+	tokenOpenParen
+	tokenCloseParen
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOperator // a comparison or a structural operator; text says which
+	tokenString
+	tokenInt
+	tokenFloat
+	tokenDuration
+	tokenIdent
+	tokenOther // a character TraceQL uses for something this package does not read
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+
+	// The parsed literal, for the kinds that carry one.
+	str      string
+	integer  int64
+	float    float64
+	duration time.Duration
+}
+
+// lex splits a query into tokens. It knows every operator TraceQL has, including the ones with no
+// structured-filter equivalent, so that the parser can name a construct it refuses rather than
+// stumbling over a character it does not recognize.
+func lex(query string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(query); {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case r == '"' || r == '`':
+			tok, n, err := lexString(query, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += n
+			continue
+		case isDigit(r) || (r == '-' && i+1 < len(query) && isDigit(rune(query[i+1]))):
+			tok, n, err := lexNumber(query, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += n
+			continue
+		case isIdentStart(r):
+			tok, n, err := lexIdent(query, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += n
+			continue
+		}
+		kind, text := lexPunctuation(query[i:])
+		tokens = append(tokens, token{kind: kind, text: text, offset: i})
+		i += len(text)
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(query)}), nil
+}
+
+// punctuation is every symbol TraceQL spells with punctuation, longest first so that a prefix does
+// not shadow the operator it begins.
+var punctuation = []struct {
+	text string
+	kind tokenKind
+}{
+	{"!>>", tokenOperator}, {"!<<", tokenOperator}, {"&>>", tokenOperator}, {"&<<", tokenOperator},
+	{"&&", tokenAnd}, {"||", tokenOr},
+	{"!=", tokenOperator}, {"!~", tokenOperator}, {"=~", tokenOperator},
+	{">=", tokenOperator}, {"<=", tokenOperator}, {">>", tokenOperator}, {"<<", tokenOperator},
+	{"&~", tokenOperator}, {"!>", tokenOperator}, {"!<", tokenOperator}, {"&>", tokenOperator}, {"&<", tokenOperator},
+	{"{", tokenOpenBrace}, {"}", tokenCloseBrace}, {"(", tokenOpenParen}, {")", tokenCloseParen},
+	{"=", tokenOperator}, {">", tokenOperator}, {"<", tokenOperator}, {"~", tokenOperator},
+	{"!", tokenNot},
+}
+
+func lexPunctuation(rest string) (tokenKind, string) {
+	for _, p := range punctuation {
+		if strings.HasPrefix(rest, p.text) {
+			return p.kind, p.text
+		}
+	}
+	_, size := utf8.DecodeRuneInString(rest)
+	return tokenOther, rest[:size]
+}
+
+// lexString reads a double-quoted string, which takes Go escapes, or a backquoted one, which takes
+// none and is what a regular expression full of backslashes is written in.
+func lexString(query string, start int) (token, int, error) {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			text := query[start : i+1]
+			value := text[1 : len(text)-1]
+			if quote == '"' {
+				unquoted, err := strconv.Unquote(text)
+				if err != nil {
+					return token{}, 0, fmt.Errorf("traceql: malformed string %s at offset %d", text, start)
+				}
+				value = unquoted
+			}
+			return token{kind: tokenString, text: text, offset: start, str: value}, len(text), nil
+		}
+	}
+	return token{}, 0, fmt.Errorf("traceql: unterminated string at offset %d", start)
+}
+
+// lexNumber reads an integer, a float, or a duration, which is a number followed by its unit.
+func lexNumber(query string, start int) (token, int, error) {
+	i := start
+	if query[i] == '-' {
+		i++
+	}
+	for i < len(query) && (isDigit(rune(query[i])) || query[i] == '.') {
+		i++
+	}
+	number := query[start:i]
+	for i < len(query) && (isLetter(rune(query[i])) || isDigit(rune(query[i])) || query[i] == '.') {
+		i++
+	}
+	// µ is the one unit that is not ASCII.
+	if strings.HasPrefix(query[i:], "µs") {
+		i += len("µs")
+	}
+	text := query[start:i]
+	tok := token{text: text, offset: start}
+	if text != number {
+		value, err := time.ParseDuration(text)
+		if err != nil {
+			return token{}, 0, fmt.Errorf("traceql: malformed duration %q at offset %d", text, start)
+		}
+		tok.kind, tok.duration = tokenDuration, value
+		return tok, len(text), nil
+	}
+	if value, err := strconv.ParseInt(text, 10, 64); err == nil {
+		tok.kind, tok.integer = tokenInt, value
+		return tok, len(text), nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, 0, fmt.Errorf("traceql: malformed number %q at offset %d", text, start)
+	}
+	tok.kind, tok.float = tokenFloat, value
+	return tok, len(text), nil
+}
+
+// lexIdent reads a name: an intrinsic (`duration`, `span:kind`), a scoped attribute
+// (`span.http.route`, `.http.route`), or a bare word standing for a value (`error`, `server`). An
+// attribute name that is not a plain identifier is quoted after its scope, as in `span."my key"`.
+func lexIdent(query string, start int) (token, int, error) {
+	i := start
+	for i < len(query) {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if !isIdentPart(r) {
+			break
+		}
+		i += size
+	}
+	text := query[start:i]
+	if strings.HasSuffix(text, ".") && i < len(query) && query[i] == '"' {
+		quoted, n, err := lexString(query, i)
+		if err != nil {
+			return token{}, 0, err
+		}
+		return token{kind: tokenIdent, text: text + quoted.str, offset: start}, i - start + n, nil
+	}
+	return token{kind: tokenIdent, text: text, offset: start}, len(text), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isLetter(r rune) bool {
+	return unicode.IsLetter(r)
+}
+
+func isIdentStart(r rune) bool {
+	return isLetter(r) || r == '_' || r == '.'
+}
+
+func isIdentPart(r rune) bool {
+	return isLetter(r) || isDigit(r) || r == '_' || r == '.' || r == ':' || r == '-' || r == '/'
+}
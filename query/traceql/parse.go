@@ -0,0 +1,468 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package traceql translates TraceQL, the query language of Grafana Tempo, into the structured filter
+// of Jaeger RFC 0005 (see the expression package), so that a query an engineer already writes for
+// Tempo can be sent as the filter of a Jaeger trace search.
+//
+// What translates is the span selector: one spanset, `{ ... }`, whose conditions compare a span's
+// attributes and intrinsics, combined with `&&`, `||`, `!` and parentheses. A structured filter is a
+// predicate on one span, so that is the part of TraceQL with an exact equivalent. Everything else —
+// structural operators between spansets, pipelines, aggregates, arithmetic, trace-level intrinsics —
+// asks a question about more than one span or about a computed value, and is refused with an
+// UnsupportedError naming the construct, rather than translated into something that answers a
+// different question.
+//
+// The translation is exact where it is accepted, and three TraceQL rules are why a few queries that
+// read as translatable are refused:
+//
+//   - A TraceQL regular expression is anchored at both ends, while one in a structured filter matches
+//     anywhere and may not anchor itself (RFC 0005 §5.3). The two agree only on a pattern that begins
+//     and ends with `.*`, so that is the only pattern accepted.
+//   - `.key` searches span and resource attributes, as an unqualified attribute does (§5.1), and
+//     `instrumentation.key` is the scope level.
+//   - `resource.service.name` is read as the resource.service field, which is the same value, and is
+//     what a backend indexes.
+//
+// Format goes the other way, rendering a filter as the selector that matches the same spans, from the
+// same tables, so that a selector it renders parses back into a filter that matches the same spans.
+// It parses back into the very filter it was rendered from only where TraceQL has the filter's own
+// construct. Membership is spelled as the comparisons it stands for, joined by `||` for in and `&&`
+// for not_in, and parses back as those; a quantifier over one comparison is spelled as the
+// comparison, and parses back without the quantifier; and a pattern is let begin and end anywhere,
+// and parses back as the pattern it was let become.
+package traceql
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+	"github.com/jaegertracing/jaeger-idl/query/render"
+)
+
+// UnsupportedError reports a TraceQL construct with no equivalent in a structured filter.
+type UnsupportedError struct {
+	// Construct names what was refused, as TraceQL spells or describes it.
+	Construct string
+	// Offset is the byte offset in the query where it begins.
+	Offset int
+	// Reason says why it has no equivalent.
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("traceql: unsupported construct %s at offset %d: %s", e.Construct, e.Offset, e.Reason)
+}
+
+// Parse translates a TraceQL span selector into a finalized filter. Every constant TraceQL writes
+// carries its type, so the filter needs nothing a deployment registers to be read.
+//
+// A selector that matches every span, `{}`, is returned as a nil filter, which is how a search with
+// no filter is written.
+func Parse(query string) (*expression.Call, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	filter, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		return nil, nil
+	}
+	finalized, err := expression.Finalize(filter)
+	if err != nil {
+		return nil, fmt.Errorf("traceql: %w", err)
+	}
+	return finalized, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, unexpected(tok, what)
+	}
+	return tok, nil
+}
+
+func unexpected(tok token, what string) error {
+	if tok.kind == tokenEOF {
+		return fmt.Errorf("traceql: expected %s at offset %d, got the end of the query", what, tok.offset)
+	}
+	return fmt.Errorf("traceql: expected %s at offset %d, got %q", what, tok.offset, tok.text)
+}
+
+// spansetOperators names what each operator between two spansets asks, which is what the error for
+// one says.
+var spansetOperators = map[string]string{
+	">>": "the descendant operator", "<<": "the ancestor operator",
+	">": "the child operator", "<": "the parent operator", "~": "the sibling operator",
+	"!>>": "the not-descendant operator", "!<<": "the not-ancestor operator",
+	"!>": "the not-child operator", "!<": "the not-parent operator",
+	"&>>": "the union descendant operator", "&<<": "the union ancestor operator",
+	"&>": "the union child operator", "&<": "the union parent operator", "&~": "the union sibling operator",
+}
+
+// query reads one spanset, or several joined by `||`: a trace with a span matching either has a span
+// matching their disjunction, so the union of spansets is a predicate on one span. A nil result
+// matches every span.
+func (p *parser) query() (*expression.Call, error) {
+	var spansets []*expression.Call
+	matchesAll := false
+	for {
+		spanset, err := p.spanset()
+		if err != nil {
+			return nil, err
+		}
+		if spanset == nil {
+			matchesAll = true
+		} else {
+			spansets = append(spansets, spanset)
+		}
+		tok := p.next()
+		switch tok.kind {
+		case tokenEOF:
+			if matchesAll {
+				return nil, nil
+			}
+			if len(spansets) == 1 {
+				return spansets[0], nil
+			}
+			args := make([]expression.Expression, len(spansets))
+			for i, spanset := range spansets {
+				args[i] = spanset
+			}
+			return &expression.Call{Op: expression.OpOr, Args: args}, nil
+		case tokenOr:
+			continue
+		case tokenAnd:
+			return nil, &UnsupportedError{Construct: "&&", Offset: tok.offset,
+				Reason: "between two spansets it asks for two spans, and a filter is a predicate on one"}
+		case tokenOther:
+			if tok.text == "|" {
+				return nil, &UnsupportedError{Construct: "the pipeline |", Offset: tok.offset,
+					Reason: "a filter selects spans, and has no stage that aggregates or selects from them"}
+			}
+		case tokenOperator:
+			if name, ok := spansetOperators[tok.text]; ok {
+				return nil, &UnsupportedError{Construct: name + " " + tok.text, Offset: tok.offset,
+					Reason: "it relates spans to one another, and a filter is a predicate on one span"}
+			}
+		}
+		return nil, unexpected(tok, "the end of the query or ||")
+	}
+}
+
+// spanset reads `{ ... }`. An empty one matches every span and reads as nil.
+func (p *parser) spanset() (*expression.Call, error) {
+	if _, err := p.expect(tokenOpenBrace, "{"); err != nil {
+		return nil, err
+	}
+	if p.peek().kind == tokenCloseBrace {
+		p.next()
+		return nil, nil
+	}
+	filter, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenCloseBrace, "}"); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func (p *parser) or() (*expression.Call, error) {
+	return p.combination(tokenOr, expression.OpOr, p.and)
+}
+
+func (p *parser) and() (*expression.Call, error) {
+	return p.combination(tokenAnd, expression.OpAnd, p.unary)
+}
+
+// combination reads operands joined by one boolean operator into a single call, so `a && b && c`
+// is one conjunction of three rather than two nested ones.
+func (p *parser) combination(kind tokenKind, op expression.Operator, operand func() (*expression.Call, error)) (*expression.Call, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	args := []expression.Expression{first}
+	for p.peek().kind == kind {
+		p.next()
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, next)
+	}
+	if len(args) == 1 {
+		return first, nil
+	}
+	return &expression.Call{Op: op, Args: args}, nil
+}
+
+func (p *parser) unary() (*expression.Call, error) {
+	switch p.peek().kind {
+	case tokenNot:
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return not(operand), nil
+	case tokenOpenParen:
+		p.next()
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenCloseParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	default:
+		return p.comparison()
+	}
+}
+
+// comparisons maps TraceQL's comparison operators onto the filter's. `!~` has no operator of its own
+// and is the negation of `=~`.
+var comparisons = map[string]expression.Operator{
+	"=": expression.OpEq, "!=": expression.OpNe,
+	">": expression.OpGt, ">=": expression.OpGte, "<": expression.OpLt, "<=": expression.OpLte,
+	"=~": expression.OpRegex, "!~": expression.OpRegex,
+}
+
+func (p *parser) comparison() (*expression.Call, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.next()
+	op, ok := comparisons[tok.text]
+	if tok.kind != tokenOperator || !ok {
+		if tok.kind == tokenOther && strings.Contains("+-*/%^", tok.text) {
+			return nil, &UnsupportedError{Construct: "arithmetic " + tok.text, Offset: tok.offset,
+				Reason: "a filter compares values the span holds, and computes none"}
+		}
+		return nil, unexpected(tok, "a comparison operator")
+	}
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if isNil(left) || isNil(right) {
+		return existence(tok, left, right)
+	}
+	if op == expression.OpRegex {
+		pattern, err := anywherePattern(tok, right)
+		if err != nil {
+			return nil, err
+		}
+		match := &expression.Call{Op: op, Args: []expression.Expression{left, pattern}}
+		if tok.text == "!~" {
+			return not(match), nil
+		}
+		return match, nil
+	}
+	return &expression.Call{Op: op, Args: []expression.Expression{left, right}}, nil
+}
+
+// existence reads a comparison against nil, which is how TraceQL asks whether a value is present.
+func existence(tok token, left, right expression.Expression) (*expression.Call, error) {
+	subject := left
+	if isNil(left) {
+		subject = right
+	}
+	if isNil(subject) {
+		return nil, fmt.Errorf("traceql: nil compared against nil at offset %d", tok.offset)
+	}
+	exists := &expression.Call{Op: expression.OpExists, Args: []expression.Expression{subject}}
+	switch tok.text {
+	case "!=":
+		return exists, nil
+	case "=":
+		return not(exists), nil
+	default:
+		return nil, fmt.Errorf("traceql: nil is compared with = or != only, got %q at offset %d", tok.text, tok.offset)
+	}
+}
+
+// anywherePattern checks that an anchored TraceQL pattern asks what an unanchored one would: a
+// pattern that may begin and end anywhere says the same thing under either reading.
+func anywherePattern(tok token, operand expression.Expression) (expression.Expression, error) {
+	text, ok := operand.(*expression.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("traceql: %s takes a string pattern at offset %d", tok.text, tok.offset)
+	}
+	parsed, err := syntax.Parse(text.Value, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("traceql: malformed pattern %q at offset %d: %w", text.Value, tok.offset, err)
+	}
+	if !render.UnanchoredEnds(parsed) {
+		return nil, &UnsupportedError{Construct: fmt.Sprintf("the anchored pattern %q", text.Value), Offset: tok.offset,
+			Reason: "TraceQL matches a pattern against the whole value and a filter matches it anywhere; write .*pattern.* to match anywhere"}
+	}
+	return text, nil
+}
+
+func not(call *expression.Call) *expression.Call {
+	return &expression.Call{Op: expression.OpNot, Args: []expression.Expression{call}}
+}
+
+// nilValue stands for TraceQL's nil until the comparison it appears in is read as an existence test.
+// It is never left in a filter.
+var nilValue = &expression.AnyValue{Value: "nil"}
+
+func isNil(e expression.Expression) bool {
+	return e == nilValue
+}
+
+func (p *parser) operand() (expression.Expression, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return &expression.StringValue{Value: tok.str}, nil
+	case tokenInt:
+		return &expression.IntValue{Value: tok.integer}, nil
+	case tokenFloat:
+		return &expression.DoubleValue{Value: tok.float}, nil
+	case tokenDuration:
+		return &expression.DurationValue{Value: tok.duration}, nil
+	case tokenIdent:
+		return identifier(tok)
+	case tokenOpenParen:
+		return nil, &UnsupportedError{Construct: "a parenthesized value", Offset: tok.offset,
+			Reason: "a filter compares values the span holds, and computes none"}
+	default:
+		return nil, unexpected(tok, "an attribute, an intrinsic or a value")
+	}
+}
+
+// scopes maps an attribute's scope onto the level it names.
+var scopes = map[string]expression.Level{
+	"span":            expression.LevelSpan,
+	"resource":        expression.LevelResource,
+	"instrumentation": expression.LevelScope,
+	"event":           expression.LevelEvent,
+	"link":            expression.LevelLink,
+}
+
+// identifier reads a name: a scoped or unscoped attribute, an intrinsic, or a word standing for a
+// value.
+func identifier(tok token) (expression.Expression, error) {
+	name := tok.text
+	if key, ok := strings.CutPrefix(name, "."); ok {
+		if key == "" {
+			return nil, fmt.Errorf("traceql: attribute with no name at offset %d", tok.offset)
+		}
+		return &expression.AttributeRef{Key: key}, nil
+	}
+	if scope, key, ok := strings.Cut(name, "."); ok {
+		if scope == "trace" {
+			return nil, &UnsupportedError{Construct: name, Offset: tok.offset,
+				Reason: "a trace holds no attributes of its own"}
+		}
+		level, ok := scopes[scope]
+		if !ok {
+			return nil, fmt.Errorf("traceql: unknown attribute scope %q at offset %d", scope, tok.offset)
+		}
+		if key == "" {
+			return nil, fmt.Errorf("traceql: attribute with no name at offset %d", tok.offset)
+		}
+		if level == expression.LevelResource && key == "service.name" {
+			return &expression.FieldRef{Level: level, Name: expression.ResourceFieldService}, nil
+		}
+		return &expression.AttributeRef{Key: key, Level: level}, nil
+	}
+	if field, ok := intrinsics[name]; ok {
+		return &expression.FieldRef{Level: field.Level, Name: field.Name}, nil
+	}
+	if reason, ok := unsupportedIntrinsics[name]; ok {
+		return nil, &UnsupportedError{Construct: "the intrinsic " + name, Offset: tok.offset, Reason: reason}
+	}
+	switch name {
+	case "true", "false":
+		return &expression.BoolValue{Value: name == "true"}, nil
+	case "nil":
+		return nilValue, nil
+	}
+	if isWord(name) {
+		return &expression.StringValue{Value: name}, nil
+	}
+	if strings.Contains(name, ":") {
+		return nil, fmt.Errorf("traceql: unknown intrinsic %q at offset %d", name, tok.offset)
+	}
+	return nil, fmt.Errorf("traceql: unknown name %q at offset %d; an attribute is written with its scope, as span.%s or .%s",
+		name, tok.offset, name, name)
+}
+
+// intrinsics maps the intrinsics with a built-in field equivalent onto that field, in both their bare
+// and their scoped spelling.
+var intrinsics = map[string]struct {
+	Level expression.Level
+	Name  string
+}{
+	"duration":                {expression.LevelSpan, expression.SpanFieldDuration},
+	"name":                    {expression.LevelSpan, expression.SpanFieldName},
+	"status":                  {expression.LevelSpan, expression.SpanFieldStatus},
+	"statusMessage":           {expression.LevelSpan, expression.SpanFieldStatusMessage},
+	"kind":                    {expression.LevelSpan, expression.SpanFieldKind},
+	"span:duration":           {expression.LevelSpan, expression.SpanFieldDuration},
+	"span:name":               {expression.LevelSpan, expression.SpanFieldName},
+	"span:status":             {expression.LevelSpan, expression.SpanFieldStatus},
+	"span:statusMessage":      {expression.LevelSpan, expression.SpanFieldStatusMessage},
+	"span:kind":               {expression.LevelSpan, expression.SpanFieldKind},
+	"span:id":                 {expression.LevelSpan, expression.SpanFieldSpanID},
+	"span:parentID":           {expression.LevelSpan, expression.SpanFieldParentSpanID},
+	"trace:id":                {expression.LevelSpan, expression.SpanFieldTraceID},
+	"event:name":              {expression.LevelEvent, expression.EventFieldName},
+	"event:timeSinceStart":    {expression.LevelEvent, expression.EventFieldTimeSinceStart},
+	"link:traceID":            {expression.LevelLink, expression.LinkFieldTraceID},
+	"link:spanID":             {expression.LevelLink, expression.LinkFieldSpanID},
+	"instrumentation:name":    {expression.LevelScope, expression.ScopeFieldName},
+	"instrumentation:version": {expression.LevelScope, expression.ScopeFieldVersion},
+}
+
+// unsupportedIntrinsics names the intrinsics without an equivalent, and why.
+var unsupportedIntrinsics = map[string]string{
+	"rootName":          "it describes the trace's root span rather than the span being matched",
+	"rootServiceName":   "it describes the trace's root span rather than the span being matched",
+	"traceDuration":     "it describes the whole trace rather than one span",
+	"trace:rootName":    "it describes the trace's root span rather than the span being matched",
+	"trace:rootService": "it describes the trace's root span rather than the span being matched",
+	"trace:duration":    "it describes the whole trace rather than one span",
+	"span:childCount":   "it counts other spans, and a filter is a predicate on one",
+	"nestedSetLeft":     "it is a position in Tempo's own storage layout",
+	"nestedSetRight":    "it is a position in Tempo's own storage layout",
+	"nestedSetParent":   "it is a position in Tempo's own storage layout",
+}
+
+// isWord reports whether a bare name is one of the words span.kind and span.status hold, which
+// TraceQL writes without quotes.
+func isWord(name string) bool {
+	return slices.Contains(expression.SpanKinds(), name) || slices.Contains(expression.SpanStatuses(), name)
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package traceql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+func call(op expression.Operator, args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: op, Args: args}
+}
+
+func spanField(name string) *expression.FieldRef {
+	return &expression.FieldRef{Level: expression.LevelSpan, Name: name}
+}
+
+func TestParse(t *testing.T) {
+	service := &expression.FieldRef{Level: expression.LevelResource, Name: expression.ResourceFieldService}
+	tests := []struct {
+		name     string
+		query    string
+		expected *expression.Call
+	}{
+		{
+			name:  "the example from the request that motivated this package",
+			query: `{ span.http.status_code >= 500 && resource.service.name = "api" }`,
+			expected: call(expression.OpAnd,
+				call(expression.OpGte, &expression.AttributeRef{Key: "http.status_code", Level: expression.LevelSpan}, &expression.IntValue{Value: 500}),
+				call(expression.OpEq, service, &expression.StringValue{Value: "api"}),
+			),
+		},
+		{
+			name:     "a duration",
+			query:    `{ duration > 2s }`,
+			expected: call(expression.OpGt, spanField(expression.SpanFieldDuration), &expression.DurationValue{Value: 2 * time.Second}),
+		},
+		{
+			name:     "a duration written with a constant first and a compound unit",
+			query:    `{ 1m30s <= span:duration }`,
+			expected: call(expression.OpGte, spanField(expression.SpanFieldDuration), &expression.DurationValue{Value: 90 * time.Second}),
+		},
+		{
+			name:     "a status",
+			query:    `{ status = error }`,
+			expected: call(expression.OpEq, spanField(expression.SpanFieldStatus), &expression.StringValue{Value: "error"}),
+		},
+		{
+			name:     "a kind, written as a scoped intrinsic",
+			query:    `{ span:kind != server }`,
+			expected: call(expression.OpNe, spanField(expression.SpanFieldKind), &expression.StringValue{Value: "server"}),
+		},
+		{
+			name:     "an unscoped attribute, which searches span and resource",
+			query:    `{ .http.method = "GET" }`,
+			expected: call(expression.OpEq, &expression.AttributeRef{Key: "http.method"}, &expression.StringValue{Value: "GET"}),
+		},
+		{
+			name:     "a quoted attribute name",
+			query:    `{ span."my key" = true }`,
+			expected: call(expression.OpEq, &expression.AttributeRef{Key: "my key", Level: expression.LevelSpan}, &expression.BoolValue{Value: true}),
+		},
+		{
+			name:     "a floating-point value against a scope attribute",
+			query:    `{ instrumentation.ratio < 0.5 }`,
+			expected: call(expression.OpLt, &expression.AttributeRef{Key: "ratio", Level: expression.LevelScope}, &expression.DoubleValue{Value: 0.5}),
+		},
+		{
+			name:  "a pattern that matches anywhere",
+			query: "{ name =~ `.*GET /api/.*` }",
+			expected: call(expression.OpRegex, spanField(expression.SpanFieldName),
+				&expression.StringValue{Value: ".*GET /api/.*"}),
+		},
+		{
+			name:  "a negated pattern",
+			query: `{ resource.k8s.pod.name !~ ".*canary.*" }`,
+			expected: call(expression.OpNot, call(expression.OpRegex,
+				&expression.AttributeRef{Key: "k8s.pod.name", Level: expression.LevelResource},
+				&expression.StringValue{Value: ".*canary.*"})),
+		},
+		{
+			name:     "presence",
+			query:    `{ span.db.statement != nil }`,
+			expected: call(expression.OpExists, &expression.AttributeRef{Key: "db.statement", Level: expression.LevelSpan}),
+		},
+		{
+			name:  "absence",
+			query: `{ nil = event.exception.type }`,
+			expected: call(expression.OpNot,
+				call(expression.OpExists, &expression.AttributeRef{Key: "exception.type", Level: expression.LevelEvent})),
+		},
+		{
+			name:  "precedence, grouping and negation",
+			query: `{ !(kind = client || kind = producer) && status = error || duration > 10s }`,
+			expected: call(expression.OpOr,
+				call(expression.OpAnd,
+					call(expression.OpNot, call(expression.OpOr,
+						call(expression.OpEq, spanField(expression.SpanFieldKind), &expression.StringValue{Value: "client"}),
+						call(expression.OpEq, spanField(expression.SpanFieldKind), &expression.StringValue{Value: "producer"}),
+					)),
+					call(expression.OpEq, spanField(expression.SpanFieldStatus), &expression.StringValue{Value: "error"}),
+				),
+				call(expression.OpGt, spanField(expression.SpanFieldDuration), &expression.DurationValue{Value: 10 * time.Second}),
+			),
+		},
+		{
+			name:  "a union of spansets, which is a disjunction",
+			query: `{ status = error } || { span:id = "00f067aa0ba902b7" }`,
+			expected: call(expression.OpOr,
+				call(expression.OpEq, spanField(expression.SpanFieldStatus), &expression.StringValue{Value: "error"}),
+				call(expression.OpEq, spanField(expression.SpanFieldSpanID), &expression.StringValue{Value: "00f067aa0ba902b7"}),
+			),
+		},
+		{
+			name:  "intrinsics of the other levels",
+			query: `{ event:name = "exception" && link:traceID = "abc" && instrumentation:version = "1.0" && trace:id = "def" }`,
+			expected: call(expression.OpAnd,
+				call(expression.OpEq, &expression.FieldRef{Level: expression.LevelEvent, Name: expression.EventFieldName}, &expression.StringValue{Value: "exception"}),
+				call(expression.OpEq, &expression.FieldRef{Level: expression.LevelLink, Name: expression.LinkFieldTraceID}, &expression.StringValue{Value: "abc"}),
+				call(expression.OpEq, &expression.FieldRef{Level: expression.LevelScope, Name: expression.ScopeFieldVersion}, &expression.StringValue{Value: "1.0"}),
+				call(expression.OpEq, spanField(expression.SpanFieldTraceID), &expression.StringValue{Value: "def"}),
+			),
+		},
+		{
+			name:     "a negative number",
+			query:    `{ span.balance < -5 }`,
+			expected: call(expression.OpLt, &expression.AttributeRef{Key: "balance", Level: expression.LevelSpan}, &expression.IntValue{Value: -5}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, filter)
+		})
+	}
+}
+
+func TestParse_MatchesEverySpan(t *testing.T) {
+	for _, query := range []string{`{}`, `{ }`, `{ status = error } || {}`} {
+		filter, err := Parse(query)
+		require.NoError(t, err, query)
+		assert.Nil(t, filter, query)
+	}
+}
+
+// TestParse_RefusesWhatHasNoEquivalent pins that every construct without an exact equivalent is
+// refused by name, which is what tells a user what to rewrite.
+func TestParse_RefusesWhatHasNoEquivalent(t *testing.T) {
+	tests := []struct {
+		query     string
+		construct string
+		offset    int
+	}{
+		{query: `{ status = error } && { kind = server }`, construct: "&&", offset: 19},
+		{query: `{ kind = server } >> { status = error }`, construct: "the descendant operator >>", offset: 18},
+		{query: `{ kind = server } > { status = error }`, construct: "the child operator >", offset: 18},
+		{query: `{ kind = server } ~ { status = error }`, construct: "the sibling operator ~", offset: 18},
+		{query: `{ kind = server } !<< { status = error }`, construct: "the not-ancestor operator !<<", offset: 18},
+		{query: `{ kind = server } &>> { status = error }`, construct: "the union descendant operator &>>", offset: 18},
+		{query: `{ status = error } | count() > 2`, construct: "the pipeline |", offset: 19},
+		{query: `{ traceDuration > 2s }`, construct: "the intrinsic traceDuration", offset: 2},
+		{query: `{ rootServiceName = "api" }`, construct: "the intrinsic rootServiceName", offset: 2},
+		{query: `{ trace:rootName = "GET" }`, construct: "the intrinsic trace:rootName", offset: 2},
+		{query: `{ span:childCount > 2 }`, construct: "the intrinsic span:childCount", offset: 2},
+		{query: `{ trace.foo = 1 }`, construct: "trace.foo", offset: 2},
+		{query: `{ span.a + 1 > 2 }`, construct: "arithmetic +", offset: 9},
+		{query: `{ span.a > (1) }`, construct: "a parenthesized value", offset: 11},
+		{query: `{ name =~ "GET.*" }`, construct: `the anchored pattern "GET.*"`, offset: 7},
+		{query: `{ name =~ ".*GET" }`, construct: `the anchored pattern ".*GET"`, offset: 7},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := Parse(test.query)
+			var unsupported *UnsupportedError
+			require.ErrorAs(t, err, &unsupported)
+			assert.Equal(t, test.construct, unsupported.Construct)
+			assert.Equal(t, test.offset, unsupported.Offset)
+			assert.NotEmpty(t, unsupported.Reason)
+		})
+	}
+}
+
+func TestParse_RefusesMalformedQueries(t *testing.T) {
+	tests := []struct {
+		query       string
+		expectedErr string
+	}{
+		{query: ``, expectedErr: "traceql: expected { at offset 0, got the end of the query"},
+		{query: `status = error`, expectedErr: `traceql: expected { at offset 0, got "status"`},
+		{query: `{ status = error`, expectedErr: "traceql: expected } at offset 16, got the end of the query"},
+		{query: `{ status error }`, expectedErr: `traceql: expected a comparison operator at offset 9, got "error"`},
+		{query: `{ (status = error }`, expectedErr: `traceql: expected ) at offset 18, got "}"`},
+		{query: `{ status = }`, expectedErr: `traceql: expected an attribute, an intrinsic or a value at offset 11, got "}"`},
+		{query: `{ span.x = "unterminated }`, expectedErr: "traceql: unterminated string at offset 11"},
+		{query: `{ span.x = "\q" }`, expectedErr: `traceql: malformed string "\q" at offset 11`},
+		{query: `{ duration > 2parsecs }`, expectedErr: `traceql: malformed duration "2parsecs" at offset 13`},
+		{query: `{ span.x = 1.2.3 }`, expectedErr: `traceql: malformed number "1.2.3" at offset 11`},
+		{query: `{ pod.x = 1 }`, expectedErr: `traceql: unknown attribute scope "pod" at offset 2`},
+		{query: `{ span. = 1 }`, expectedErr: "traceql: attribute with no name at offset 2"},
+		{query: `{ . = 1 }`, expectedErr: "traceql: attribute with no name at offset 2"},
+		{query: `{ span:nonesuch = 1 }`, expectedErr: `traceql: unknown intrinsic "span:nonesuch" at offset 2`},
+		{query: `{ foo = 1 }`, expectedErr: `traceql: unknown name "foo" at offset 2; an attribute is written with its scope, as span.foo or .foo`},
+		{query: `{ span.x > nil }`, expectedErr: `traceql: nil is compared with = or != only, got ">" at offset 9`},
+		{query: `{ nil = nil }`, expectedErr: "traceql: nil compared against nil at offset 6"},
+		{query: `{ name =~ 5 }`, expectedErr: "traceql: =~ takes a string pattern at offset 7"},
+		{query: `{ name =~ ".*(.*" }`, expectedErr: `traceql: malformed pattern ".*(.*" at offset 7`},
+		{query: `{ status = error } {}`, expectedErr: `traceql: expected the end of the query or || at offset 19, got "{"`},
+		{query: `{ duration > "fast" }`, expectedErr: `traceql: operator "gt" compares span.duration against a string constant`},
+		{query: `{ kind = sideways }`, expectedErr: `traceql: unknown name "sideways"`},
+		{query: `{ span.elapsed > 2s }`, expectedErr: "the wire has no duration type"},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			_, err := Parse(test.query)
+			require.ErrorContains(t, err, test.expectedErr)
+			var unsupported *UnsupportedError
+			assert.NotErrorAs(t, err, &unsupported, "a malformed query is not an unsupported one")
+		})
+	}
+}
+
+func TestUnsupportedError(t *testing.T) {
+	err := &UnsupportedError{Construct: "the pipeline |", Offset: 3, Reason: "no stages"}
+	assert.Equal(t, "traceql: unsupported construct the pipeline | at offset 3: no stages", err.Error())
+}
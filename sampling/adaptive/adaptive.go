@@ -0,0 +1,296 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package adaptive calculates the sampling probabilities of operations from the traces observed of
+// them, so that each is sampled at a target number of traces per second, as Jaeger's adaptive
+// sampling does.
+//
+// The throughput of an operation is the number of sampled traces the collectors received of it,
+// counted in buckets over time. Sampled traces are what can be counted, and what the target
+// limits: an operation sampled with probability p at s traces per second is sampled at about
+// t traces per second with probability p·t/s. Each calculation moves every operation's probability
+// that way, from the probability it was given last, and the probabilities of a service are then
+// served in its strategy, to be calculated from again once its clients have sampled with them.
+//
+// The calculation is a pure function of the probabilities given last, the buckets and a Config: it
+// reads no clock and keeps no state, so that the collectors that share a calculation, and a tool
+// that plans capacity from recorded throughput, arrive at the same probabilities from the same
+// input.
+package adaptive
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// Config is how probabilities are calculated. DefaultConfig returns Jaeger's.
+type Config struct {
+	// TargetSamplesPerSecond is the number of traces a second each operation is sampled at.
+	TargetSamplesPerSecond float64
+	// DeltaTolerance is how far, as a fraction of the target, an operation's throughput may be
+	// from it before its probability is changed. It keeps a probability from being changed at
+	// every calculation by the noise of a throughput that is near enough.
+	DeltaTolerance float64
+	// InitialSamplingProbability is the probability an operation that has none yet is sampled
+	// with, and the one a strategy gives operations it does not name.
+	InitialSamplingProbability float64
+	// MinSamplingProbability is the lowest probability an operation is given, so that one with
+	// a burst of traffic keeps being sampled when it is over.
+	MinSamplingProbability float64
+	// MaxIncrease is the most, as a fraction of it, a probability is raised by a calculation. A
+	// probability is lowered at once, to shed a burst of traffic, but raised in steps, so that a
+	// lull does not raise it to where the traffic that follows is sampled at many times the
+	// target. Zero leaves increases unbounded.
+	MaxIncrease float64
+	// MinSamplesPerSecond is the number of traces a second an operation is sampled at, at least,
+	// whatever the target and steps. It is served as the per-operation lower bound of the
+	// strategy too, which a client keeps to by a rate limiter of its own when the probability
+	// alone samples fewer.
+	MinSamplesPerSecond float64
+	// MaxSamplesPerSecond is the number of traces a second an operation is sampled at, at most,
+	// and the per-operation upper bound of the strategy. Zero leaves it unbounded.
+	MaxSamplesPerSecond float64
+}
+
+// DefaultConfig returns the Config of Jaeger's adaptive sampling: a trace a second of each
+// operation, within 30%, a probability raised by at most half at a time, and a trace a minute at
+// least.
+func DefaultConfig() Config {
+	return Config{
+		TargetSamplesPerSecond:     1,
+		DeltaTolerance:             0.3,
+		InitialSamplingProbability: 0.001,
+		MinSamplingProbability:     1e-5,
+		MaxIncrease:                0.5,
+		MinSamplesPerSecond:        1.0 / 60,
+	}
+}
+
+// Throughput is the number of sampled traces of an operation observed in a bucket.
+type Throughput struct {
+	Service   string
+	Operation string
+	// Count is the number of sampled traces observed, those whose root span is the operation's.
+	Count int64
+	// Probabilities are the probabilities the traces were sampled with, as the sampler.param tag
+	// of their root spans reports them. A client that has not yet taken up the probability
+	// calculated last reports the one before.
+	Probabilities []float64
+}
+
+// Bucket is the throughput observed over an interval of time.
+type Bucket struct {
+	// Interval is how long the throughput was observed over.
+	Interval time.Duration
+	// Throughput is the throughput of each operation observed. An operation that appears more
+	// than once is counted as the sum of its appearances.
+	Throughput []Throughput
+}
+
+// Probabilities are the sampling probabilities of operations, by service and operation.
+type Probabilities map[string]map[string]float64
+
+// Calculator calculates sampling probabilities by a Config.
+type Calculator struct {
+	config Config
+}
+
+// NewCalculator returns a Calculator by a Config, which it checks.
+func NewCalculator(config Config) (*Calculator, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &Calculator{config: config}, nil
+}
+
+func (c Config) validate() error {
+	if !(c.TargetSamplesPerSecond > 0) {
+		return fmt.Errorf("target samples per second %v is not positive", c.TargetSamplesPerSecond)
+	}
+	for _, probability := range []struct {
+		name  string
+		value float64
+	}{
+		{"initial sampling probability", c.InitialSamplingProbability},
+		{"min sampling probability", c.MinSamplingProbability},
+	} {
+		if !(probability.value > 0 && probability.value <= 1) {
+			return fmt.Errorf("%s %v is not in (0, 1]", probability.name, probability.value)
+		}
+	}
+	if c.InitialSamplingProbability < c.MinSamplingProbability {
+		return fmt.Errorf("initial sampling probability %v is below the min sampling probability %v",
+			c.InitialSamplingProbability, c.MinSamplingProbability)
+	}
+	for _, nonNegative := range []struct {
+		name  string
+		value float64
+	}{
+		{"delta tolerance", c.DeltaTolerance},
+		{"max increase", c.MaxIncrease},
+		{"min samples per second", c.MinSamplesPerSecond},
+		{"max samples per second", c.MaxSamplesPerSecond},
+	} {
+		if !(nonNegative.value >= 0) || math.IsInf(nonNegative.value, 1) {
+			return fmt.Errorf("%s %v is not a non-negative number", nonNegative.name, nonNegative.value)
+		}
+	}
+	if c.TargetSamplesPerSecond < c.MinSamplesPerSecond {
+		return fmt.Errorf("target samples per second %v is below the min samples per second %v",
+			c.TargetSamplesPerSecond, c.MinSamplesPerSecond)
+	}
+	if c.MaxSamplesPerSecond > 0 && c.TargetSamplesPerSecond > c.MaxSamplesPerSecond {
+		return fmt.Errorf("target samples per second %v is above the max samples per second %v",
+			c.TargetSamplesPerSecond, c.MaxSamplesPerSecond)
+	}
+	return nil
+}
+
+// Calculate returns the probabilities of the operations observed in the buckets, from those
+// calculated last, which are not changed. The buckets are newest first.
+//
+// The throughput of an operation is the average of its throughput in each bucket, weighted so that
+// the newest count most: they are the ones sampled with the probability calculated last, while the
+// older ones smooth a spike out. An operation's probability is then
+//
+//   - the one calculated last, when the newest bucket it was observed in does not report it, since
+//     the throughput is not yet that of its clients sampling with it;
+//   - the one calculated last, when the throughput is within the delta tolerance of the target;
+//   - otherwise the one that samples the throughput at the target, raised by at most the max
+//     increase,
+//
+// and, unless it is kept for the first reason, at least the one that samples the throughput at the
+// min samples per second, at most the one that samples it at the max, and within
+// [MinSamplingProbability, 1].
+//
+// An operation observed for the first time was sampled with the initial probability, and is
+// calculated from it. An operation not observed in any bucket is left out, and is sampled with the
+// initial probability again, as an operation the strategy does not name.
+func (c *Calculator) Calculate(previous Probabilities, buckets []Bucket) Probabilities {
+	// A bucket of no time observed nothing, and is not counted as having observed none.
+	buckets = slices.DeleteFunc(slices.Clone(buckets), func(b Bucket) bool { return b.Interval <= 0 })
+	weights := bucketWeights(len(buckets))
+	throughput := make(map[operation]*estimate)
+	for i, bucket := range buckets {
+		seconds := bucket.Interval.Seconds()
+		for _, observed := range bucket.Throughput {
+			key := operation{service: observed.Service, name: observed.Operation}
+			e := throughput[key]
+			if e == nil {
+				e = &estimate{newest: i}
+				throughput[key] = e
+			}
+			e.samplesPerSecond += weights[i] * float64(observed.Count) / seconds
+			if e.newest == i {
+				e.probabilities = append(e.probabilities, observed.Probabilities...)
+			}
+		}
+	}
+	probabilities := make(Probabilities)
+	for key, e := range throughput {
+		probability, ok := previous[key.service][key.name]
+		if !ok {
+			probability = c.config.InitialSamplingProbability
+		}
+		if probabilities[key.service] == nil {
+			probabilities[key.service] = make(map[string]float64)
+		}
+		probabilities[key.service][key.name] = c.calculate(probability, e)
+	}
+	return probabilities
+}
+
+// operation names an operation of a service.
+type operation struct {
+	service string
+	name    string
+}
+
+// estimate is what the buckets tell of an operation.
+type estimate struct {
+	// samplesPerSecond is the weighted average of its throughput.
+	samplesPerSecond float64
+	// newest is the index of the newest bucket it was observed in, and probabilities are the
+	// probabilities that bucket reports.
+	newest        int
+	probabilities []float64
+}
+
+// calculate returns the probability of an operation from the one calculated last.
+func (c *Calculator) calculate(probability float64, e *estimate) float64 {
+	if !slices.ContainsFunc(e.probabilities, func(p float64) bool { return sameProbability(p, probability) }) {
+		return probability
+	}
+	target, current := c.config.TargetSamplesPerSecond, e.samplesPerSecond
+	next := probability
+	if math.Abs(current-target) > c.config.DeltaTolerance*target {
+		if current > 0 {
+			next = probability * target / current
+		} else {
+			next = math.Inf(1)
+		}
+		if c.config.MaxIncrease > 0 {
+			next = math.Min(next, probability*(1+c.config.MaxIncrease))
+		}
+	}
+	// The bounds are kept to whatever the tolerance and steps, a throughput of none aside, which
+	// no probability can be known to raise to the min.
+	if current > 0 {
+		next = math.Max(next, probability*c.config.MinSamplesPerSecond/current)
+		if c.config.MaxSamplesPerSecond > 0 {
+			next = math.Min(next, probability*c.config.MaxSamplesPerSecond/current)
+		}
+	}
+	return math.Max(c.config.MinSamplingProbability, math.Min(1, next))
+}
+
+// sameProbability returns whether a probability a span reports is one calculated, which it may
+// differ from in the last digits, having been written as text in a tag and read back.
+func sameProbability(reported, calculated float64) bool {
+	return math.Abs(reported-calculated) <= 1e-9*calculated
+}
+
+// bucketWeights returns the weights of n buckets, newest first, which add up to 1. The weight of
+// the i-th is proportional to (n-i)⁴, Jaeger's, which counts the newest buckets most, and the
+// older ones, sampled with probabilities given before the last, little.
+func bucketWeights(n int) []float64 {
+	weights := make([]float64, n)
+	var sum float64
+	for i := range weights {
+		weights[i] = math.Pow(float64(n-i), 4)
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
+
+// Strategy returns the strategy that samples the operations of a service with their probabilities:
+// by probability, with the initial probability for an operation it does not name, and the min and
+// max samples per second as the per-operation bounds. Its operations are in the order of their
+// names.
+func (c *Calculator) Strategy(probabilities map[string]float64) *api_v2.SamplingStrategyResponse {
+	operations := make([]*api_v2.OperationSamplingStrategy, 0, len(probabilities))
+	for _, name := range slices.Sorted(maps.Keys(probabilities)) {
+		operations = append(operations, &api_v2.OperationSamplingStrategy{
+			Operation:             name,
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: probabilities[name]},
+		})
+	}
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: c.config.InitialSamplingProbability},
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       c.config.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: c.config.MinSamplesPerSecond,
+			DefaultUpperBoundTracesPerSecond: c.config.MaxSamplesPerSecond,
+			PerOperationStrategies:           operations,
+		},
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptive
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// bucket returns a bucket of a minute in which a count of traces of operation "op" of service "svc"
+// was observed, sampled with a probability.
+func bucket(probability float64, count int64) Bucket {
+	return Bucket{
+		Interval: time.Minute,
+		Throughput: []Throughput{
+			{Service: "svc", Operation: "op", Count: count, Probabilities: []float64{probability}},
+		},
+	}
+}
+
+func calculator(t *testing.T, modify func(*Config)) *Calculator {
+	config := DefaultConfig()
+	if modify != nil {
+		modify(&config)
+	}
+	c, err := NewCalculator(config)
+	require.NoError(t, err)
+	return c
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Config)
+		previous float64
+		buckets  []Bucket
+		expected float64
+	}{
+		{
+			name:     "lowered at once to the target",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 240)},
+			expected: 0.025,
+		},
+		{
+			name:     "raised by at most the max increase",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 15)},
+			expected: 0.15,
+		},
+		{
+			name:     "raised at once without a max increase",
+			modify:   func(c *Config) { c.MaxIncrease = 0 },
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 15)},
+			expected: 0.4,
+		},
+		{
+			name:     "kept within the delta tolerance",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 75)},
+			expected: 0.1,
+		},
+		{
+			name:     "kept until clients sample with it",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.2, 240)},
+			expected: 0.1,
+		},
+		{
+			name:     "a reported probability read back from text",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1+1e-12, 240)},
+			expected: 0.025,
+		},
+		{
+			name:     "no lower than the min sampling probability",
+			previous: 1e-5,
+			buckets:  []Bucket{bucket(1e-5, 6000)},
+			expected: 1e-5,
+		},
+		{
+			name:     "no higher than 1",
+			modify:   func(c *Config) { c.MaxIncrease = 0 },
+			previous: 0.5,
+			buckets:  []Bucket{bucket(0.5, 6)},
+			expected: 1,
+		},
+		{
+			name:     "raised to the min samples per second past the max increase",
+			modify:   func(c *Config) { c.MinSamplesPerSecond = 0.5 },
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 6)},
+			expected: 0.5,
+		},
+		{
+			name:     "lowered to the max samples per second within the tolerance",
+			modify:   func(c *Config) { c.MaxSamplesPerSecond = 1.1 },
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 75)},
+			expected: 0.1 * 1.1 / 1.25,
+		},
+		{
+			name:     "a throughput of none raised by the max increase",
+			previous: 0.1,
+			buckets:  []Bucket{bucket(0.1, 0)},
+			expected: 0.15,
+		},
+		{
+			name:     "a new operation from the initial probability",
+			previous: math.NaN(),
+			buckets:  []Bucket{bucket(0.001, 120)},
+			expected: 0.0005,
+		},
+		{
+			name:     "the newest bucket counts most",
+			previous: 0.1,
+			// The weights of two buckets are 16/17 and 1/17: 160 traces a minute.
+			buckets:  []Bucket{bucket(0.1, 170), {Interval: time.Minute}},
+			expected: 0.0375,
+		},
+		{
+			name:     "an operation missing from the newest buckets",
+			previous: 0.1,
+			// 30 traces a second in the third of three buckets, weighted 1/98: about 0.3 a second.
+			buckets:  []Bucket{{Interval: time.Minute}, {Interval: time.Minute}, bucket(0.1, 1800)},
+			expected: 0.15,
+		},
+		{
+			name:     "a bucket of no interval is skipped",
+			previous: 0.1,
+			buckets:  []Bucket{{Interval: 0, Throughput: bucket(0.2, 1).Throughput}, bucket(0.1, 240)},
+			expected: 0.025,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := Probabilities{}
+			if !math.IsNaN(test.previous) {
+				previous["svc"] = map[string]float64{"op": test.previous}
+			}
+			probabilities := calculator(t, test.modify).Calculate(previous, test.buckets)
+			require.Contains(t, probabilities, "svc")
+			assert.InDelta(t, test.expected, probabilities["svc"]["op"], 1e-12)
+		})
+	}
+}
+
+func TestCalculate_Operations(t *testing.T) {
+	previous := Probabilities{
+		"svc":  {"op": 0.1, "gone": 0.2},
+		"gone": {"op": 0.3},
+	}
+	buckets := []Bucket{{
+		Interval: time.Minute,
+		Throughput: []Throughput{
+			{Service: "svc", Operation: "op", Count: 120, Probabilities: []float64{0.05}},
+			{Service: "svc", Operation: "op", Count: 120, Probabilities: []float64{0.1}},
+			{Service: "other", Operation: "op", Count: 60, Probabilities: []float64{0.001}},
+		},
+	}}
+	c := calculator(t, nil)
+	probabilities := c.Calculate(previous, buckets)
+	assert.Equal(t, Probabilities{
+		"svc":   {"op": 0.025},
+		"other": {"op": 0.001},
+	}, probabilities, "appearances summed, unobserved operations left out")
+	assert.Equal(t, Probabilities{"svc": {"op": 0.1, "gone": 0.2}, "gone": {"op": 0.3}}, previous, "not changed")
+	assert.Equal(t, probabilities, c.Calculate(previous, buckets), "deterministic")
+
+	assert.Empty(t, c.Calculate(previous, nil))
+}
+
+func TestCalculate_Converges(t *testing.T) {
+	c := calculator(t, nil)
+	// An operation of 1000 requests a second, sampled with whatever was calculated last, from a
+	// tenth of the probability that samples it at the target.
+	probabilities := Probabilities{"svc": {"op": 0.0001}}
+	var buckets []Bucket
+	for range 20 {
+		probability := probabilities["svc"]["op"]
+		buckets = append([]Bucket{bucket(probability, int64(math.Round(1000*60*probability)))}, buckets...)
+		probabilities = c.Calculate(probabilities, buckets[:min(len(buckets), 5)])
+	}
+	assert.InDelta(t, 0.001, probabilities["svc"]["op"], 0.0003)
+}
+
+func TestBucketWeights(t *testing.T) {
+	assert.Empty(t, bucketWeights(0))
+	assert.Equal(t, []float64{1}, bucketWeights(1))
+	assert.InDeltaSlice(t, []float64{81.0 / 98, 16.0 / 98, 1.0 / 98}, bucketWeights(3), 1e-12)
+}
+
+func TestNewCalculator(t *testing.T) {
+	tests := []struct {
+		modify   func(*Config)
+		expected string
+	}{
+		{func(c *Config) { c.TargetSamplesPerSecond = 0 }, "target samples per second 0 is not positive"},
+		{func(c *Config) { c.TargetSamplesPerSecond = math.NaN() }, "target samples per second NaN is not positive"},
+		{func(c *Config) { c.InitialSamplingProbability = 0 }, "initial sampling probability 0 is not in (0, 1]"},
+		{func(c *Config) { c.MinSamplingProbability = 1.5 }, "min sampling probability 1.5 is not in (0, 1]"},
+		{
+			func(c *Config) { c.MinSamplingProbability = 0.01 },
+			"initial sampling probability 0.001 is below the min sampling probability 0.01",
+		},
+		{func(c *Config) { c.DeltaTolerance = -1 }, "delta tolerance -1 is not a non-negative number"},
+		{func(c *Config) { c.MaxIncrease = math.Inf(1) }, "max increase +Inf is not a non-negative number"},
+		{func(c *Config) { c.MinSamplesPerSecond = 2 }, "target samples per second 1 is below the min samples per second 2"},
+		{func(c *Config) { c.MaxSamplesPerSecond = 0.5 }, "target samples per second 1 is above the max samples per second 0.5"},
+	}
+	for _, test := range tests {
+		config := DefaultConfig()
+		test.modify(&config)
+		_, err := NewCalculator(config)
+		assert.EqualError(t, err, test.expected)
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	c := calculator(t, func(c *Config) { c.MaxSamplesPerSecond = 10 })
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.001},
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.001,
+			DefaultLowerBoundTracesPerSecond: 1.0 / 60,
+			DefaultUpperBoundTracesPerSecond: 10,
+			PerOperationStrategies: []*api_v2.OperationSamplingStrategy{
+				{Operation: "a", ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.5}},
+				{Operation: "b", ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.25}},
+			},
+		},
+	}, c.Strategy(map[string]float64{"b": 0.25, "a": 0.5}))
+	assert.Empty(t, c.Strategy(nil).OperationSampling.PerOperationStrategies)
+}
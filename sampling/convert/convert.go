@@ -0,0 +1,127 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package convert converts sampling strategies between the api_v2 types of sampling.proto and the
+// Thrift types of sampling.thrift, and writes and reads the JSON forms Jaeger clients are given
+// them in.
+//
+// The two sets of types say the same things, and a strategy converted one way and back is the one
+// it was, with two exceptions the Thrift types cannot hold:
+//
+//   - a rate limit is an int32 in sampling.proto and an int16 in sampling.thrift; one too large for
+//     16 bits is clamped to the largest it holds, rather than wrapped around to a limit that is
+//     negative or far below the one meant;
+//   - the probability of an operation is required in sampling.thrift; an operation without one is
+//     given the default probability of its strategies, with which a client samples an operation
+//     that has none.
+//
+// sampling.thrift's optional upper bound is absent where sampling.proto's is 0, which says the
+// same: that an operation has none.
+package convert
+
+import (
+	"math"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/sampling"
+)
+
+// ToThrift returns the Thrift form of a strategy, or nil for nil.
+func ToThrift(response *api_v2.SamplingStrategyResponse) *sampling.SamplingStrategyResponse {
+	if response == nil {
+		return nil
+	}
+	result := &sampling.SamplingStrategyResponse{
+		StrategyType:          typeToThrift(response.StrategyType),
+		ProbabilisticSampling: probabilisticToThrift(response.ProbabilisticSampling),
+	}
+	if r := response.RateLimitingSampling; r != nil {
+		result.RateLimitingSampling = &sampling.RateLimitingSamplingStrategy{
+			MaxTracesPerSecond: int16(max(min(r.MaxTracesPerSecond, math.MaxInt16), math.MinInt16)),
+		}
+	}
+	if o := response.OperationSampling; o != nil {
+		operations := &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       o.DefaultSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: o.DefaultLowerBoundTracesPerSecond,
+			PerOperationStrategies:           make([]*sampling.OperationSamplingStrategy, 0, len(o.PerOperationStrategies)),
+		}
+		if o.DefaultUpperBoundTracesPerSecond != 0 {
+			upper := o.DefaultUpperBoundTracesPerSecond
+			operations.DefaultUpperBoundTracesPerSecond = &upper
+		}
+		for _, op := range o.PerOperationStrategies {
+			if op == nil {
+				continue
+			}
+			probabilistic := probabilisticToThrift(op.ProbabilisticSampling)
+			if probabilistic == nil {
+				probabilistic = &sampling.ProbabilisticSamplingStrategy{SamplingRate: o.DefaultSamplingProbability}
+			}
+			operations.PerOperationStrategies = append(operations.PerOperationStrategies, &sampling.OperationSamplingStrategy{
+				Operation:             op.Operation,
+				ProbabilisticSampling: probabilistic,
+			})
+		}
+		result.OperationSampling = operations
+	}
+	return result
+}
+
+// FromThrift returns the api_v2 form of a Thrift strategy, or nil for nil.
+func FromThrift(response *sampling.SamplingStrategyResponse) *api_v2.SamplingStrategyResponse {
+	if response == nil {
+		return nil
+	}
+	result := &api_v2.SamplingStrategyResponse{
+		StrategyType:          typeFromThrift(response.StrategyType),
+		ProbabilisticSampling: probabilisticFromThrift(response.ProbabilisticSampling),
+	}
+	if r := response.RateLimitingSampling; r != nil {
+		result.RateLimitingSampling = &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: int32(r.MaxTracesPerSecond)}
+	}
+	if o := response.OperationSampling; o != nil {
+		operations := &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       o.DefaultSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: o.DefaultLowerBoundTracesPerSecond,
+			DefaultUpperBoundTracesPerSecond: o.GetDefaultUpperBoundTracesPerSecond(),
+		}
+		for _, op := range o.PerOperationStrategies {
+			if op == nil {
+				continue
+			}
+			operations.PerOperationStrategies = append(operations.PerOperationStrategies, &api_v2.OperationSamplingStrategy{
+				Operation:             op.Operation,
+				ProbabilisticSampling: probabilisticFromThrift(op.ProbabilisticSampling),
+			})
+		}
+		result.OperationSampling = operations
+	}
+	return result
+}
+
+// typeToThrift returns the Thrift strategy type of an api_v2 one. The enums have the same values,
+// and a type neither knows is kept as the number it is, for the client to refuse.
+func typeToThrift(t api_v2.SamplingStrategyType) sampling.SamplingStrategyType {
+	return sampling.SamplingStrategyType(t)
+}
+
+// typeFromThrift returns the api_v2 strategy type of a Thrift one. A number too large for the
+// api_v2 enum, which is 32 bits, is no type either knows, and is clamped to one that is not either.
+func typeFromThrift(t sampling.SamplingStrategyType) api_v2.SamplingStrategyType {
+	return api_v2.SamplingStrategyType(max(min(int64(t), math.MaxInt32), math.MinInt32))
+}
+
+func probabilisticToThrift(p *api_v2.ProbabilisticSamplingStrategy) *sampling.ProbabilisticSamplingStrategy {
+	if p == nil {
+		return nil
+	}
+	return &sampling.ProbabilisticSamplingStrategy{SamplingRate: p.SamplingRate}
+}
+
+func probabilisticFromThrift(p *sampling.ProbabilisticSamplingStrategy) *api_v2.ProbabilisticSamplingStrategy {
+	if p == nil {
+		return nil
+	}
+	return &api_v2.ProbabilisticSamplingStrategy{SamplingRate: p.SamplingRate}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/sampling"
+)
+
+func probabilistic() *api_v2.SamplingStrategyResponse {
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0.5},
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.5,
+			DefaultLowerBoundTracesPerSecond: 0.25,
+			DefaultUpperBoundTracesPerSecond: 20,
+			PerOperationStrategies: []*api_v2.OperationSamplingStrategy{
+				{Operation: "/health", ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0}},
+				{Operation: "/orders", ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 1}},
+			},
+		},
+	}
+}
+
+func thriftProbabilistic() *sampling.SamplingStrategyResponse {
+	upper := 20.0
+	return &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.5},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.5,
+			DefaultLowerBoundTracesPerSecond: 0.25,
+			DefaultUpperBoundTracesPerSecond: &upper,
+			PerOperationStrategies: []*sampling.OperationSamplingStrategy{
+				{Operation: "/health", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0}},
+				{Operation: "/orders", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 1}},
+			},
+		},
+	}
+}
+
+func rateLimiting(limit int32) *api_v2.SamplingStrategyResponse {
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: limit},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		proto  *api_v2.SamplingStrategyResponse
+		thrift *sampling.SamplingStrategyResponse
+	}{
+		{name: "nil"},
+		{name: "probabilistic", proto: probabilistic(), thrift: thriftProbabilistic()},
+		{
+			name:  "rate limiting",
+			proto: rateLimiting(-5),
+			thrift: &sampling.SamplingStrategyResponse{
+				StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: -5},
+			},
+		},
+		{
+			name: "no upper bound",
+			proto: &api_v2.SamplingStrategyResponse{
+				OperationSampling: &api_v2.PerOperationSamplingStrategies{DefaultSamplingProbability: 0.1},
+			},
+			thrift: &sampling.SamplingStrategyResponse{
+				OperationSampling: &sampling.PerOperationSamplingStrategies{
+					DefaultSamplingProbability: 0.1,
+					PerOperationStrategies:     []*sampling.OperationSamplingStrategy{},
+				},
+			},
+		},
+		{
+			name:   "an unknown type",
+			proto:  &api_v2.SamplingStrategyResponse{StrategyType: 7},
+			thrift: &sampling.SamplingStrategyResponse{StrategyType: 7},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.thrift, ToThrift(test.proto))
+			assert.Equal(t, test.proto, FromThrift(ToThrift(test.proto)))
+		})
+	}
+}
+
+func TestToThrift_Lossy(t *testing.T) {
+	assert.Equal(t, int16(math.MaxInt16), ToThrift(rateLimiting(100000)).RateLimitingSampling.MaxTracesPerSecond)
+	assert.Equal(t, int16(math.MinInt16), ToThrift(rateLimiting(-100000)).RateLimitingSampling.MaxTracesPerSecond)
+
+	response := probabilistic()
+	response.OperationSampling.PerOperationStrategies[0].ProbabilisticSampling = nil
+	response.OperationSampling.PerOperationStrategies = append(response.OperationSampling.PerOperationStrategies, nil)
+	operations := ToThrift(response).OperationSampling.PerOperationStrategies
+	assert.Equal(t, []*sampling.OperationSamplingStrategy{
+		{Operation: "/health", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.5}},
+		{Operation: "/orders", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 1}},
+	}, operations, "the default probability for an operation without one, and no nil operation")
+}
+
+func TestFromThrift_Lossy(t *testing.T) {
+	zero := 0.0
+	response := thriftProbabilistic()
+	response.OperationSampling.DefaultUpperBoundTracesPerSecond = &zero
+	response.OperationSampling.PerOperationStrategies = append(response.OperationSampling.PerOperationStrategies, nil)
+	expected := probabilistic()
+	expected.OperationSampling.DefaultUpperBoundTracesPerSecond = 0
+	assert.Equal(t, expected, FromThrift(response))
+
+	assert.Equal(t, api_v2.SamplingStrategyType(math.MaxInt32),
+		FromThrift(&sampling.SamplingStrategyResponse{StrategyType: math.MaxInt64}).StrategyType)
+	assert.Equal(t, api_v2.SamplingStrategyType(math.MinInt32),
+		FromThrift(&sampling.SamplingStrategyResponse{StrategyType: math.MinInt64}).StrategyType)
+}
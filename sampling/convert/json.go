@@ -0,0 +1,199 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/sampling"
+)
+
+// Format is a JSON form of a strategy.
+type Format int
+
+const (
+	// FormatProto is the proto3 JSON form of the api_v2 SamplingStrategyResponse, as a gRPC
+	// gateway writes it and the clients that poll POST /api/v2/samplingStrategy read it: members
+	// in camelCase, the strategy type by its name, and every member written, unpopulated ones
+	// too, so that the type of a probabilistic strategy, the first of the enum, is not left out.
+	FormatProto Format = iota
+	// FormatThrift is the form of the Thrift SamplingStrategyResponse the agent's GET /sampling
+	// endpoint writes, which the older clients that poll it read: members in camelCase, the
+	// strategy type by its number, as the Thrift code they were generated with writes and reads
+	// it, the rate limit in 16 bits, and the members the Thrift struct leaves unset left out.
+	FormatThrift
+	// FormatThriftSnakeCase is FormatThrift with its members in snake_case, strategy_type,
+	// probabilistic_sampling, sampling_rate and so on, which some older clients read instead.
+	FormatThriftSnakeCase
+)
+
+// MarshalJSON returns the JSON of a strategy in a form, or null for nil.
+func MarshalJSON(response *api_v2.SamplingStrategyResponse, format Format) ([]byte, error) {
+	switch format {
+	case FormatProto:
+		if response == nil {
+			return []byte("null"), nil
+		}
+		var body bytes.Buffer
+		marshaler := jsonpb.Marshaler{EmitDefaults: true}
+		if err := marshaler.Marshal(&body, response); err != nil {
+			return nil, err
+		}
+		return body.Bytes(), nil
+	case FormatThrift:
+		return json.Marshal(thriftObject(ToThrift(response), func(name string) string { return name }))
+	case FormatThriftSnakeCase:
+		return json.Marshal(thriftObject(ToThrift(response), snakeCase))
+	default:
+		return nil, fmt.Errorf("unknown JSON format %d", format)
+	}
+}
+
+// UnmarshalJSON reads a strategy from its JSON in any of the forms MarshalJSON writes, and any mix
+// of them: a member in camelCase or snake_case, a strategy type by its name or number, and a member
+// left out or null. A member it does not know is skipped, as a client skips one of a newer server.
+func UnmarshalJSON(data []byte) (*api_v2.SamplingStrategyResponse, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid sampling strategy: %w", err)
+	}
+	camel, err := json.Marshal(camelCaseMembers(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling strategy: %w", err)
+	}
+	response := &api_v2.SamplingStrategyResponse{}
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(camel), response); err != nil {
+		return nil, fmt.Errorf("invalid sampling strategy: %w", err)
+	}
+	return response, nil
+}
+
+// thriftObject returns the JSON object of a Thrift strategy, with its members in the order of the
+// Thrift struct's fields, and named by a function of their camelCase names.
+func thriftObject(response *sampling.SamplingStrategyResponse, name func(string) string) object {
+	if response == nil {
+		return nil
+	}
+	result := object{{name("strategyType"), int64(response.StrategyType)}}
+	if p := response.ProbabilisticSampling; p != nil {
+		result = append(result, member{name("probabilisticSampling"), object{{name("samplingRate"), p.SamplingRate}}})
+	}
+	if r := response.RateLimitingSampling; r != nil {
+		result = append(result, member{name("rateLimitingSampling"), object{{name("maxTracesPerSecond"), r.MaxTracesPerSecond}}})
+	}
+	if o := response.OperationSampling; o != nil {
+		operations := make([]object, 0, len(o.PerOperationStrategies))
+		for _, op := range o.PerOperationStrategies {
+			operations = append(operations, object{
+				{name("operation"), op.Operation},
+				{name("probabilisticSampling"), object{{name("samplingRate"), op.ProbabilisticSampling.SamplingRate}}},
+			})
+		}
+		perOperation := object{
+			{name("defaultSamplingProbability"), o.DefaultSamplingProbability},
+			{name("defaultLowerBoundTracesPerSecond"), o.DefaultLowerBoundTracesPerSecond},
+			{name("perOperationStrategies"), operations},
+		}
+		if o.DefaultUpperBoundTracesPerSecond != nil {
+			perOperation = append(perOperation, member{name("defaultUpperBoundTracesPerSecond"), *o.DefaultUpperBoundTracesPerSecond})
+		}
+		result = append(result, member{name("operationSampling"), perOperation})
+	}
+	return result
+}
+
+// object is a JSON object whose members are written in order, which a map's are not.
+type object []member
+
+type member struct {
+	name  string
+	value any
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(m.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// snakeCase returns the snake_case form of a camelCase name.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if 'A' <= r && r <= 'Z' {
+			b.WriteByte('_')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// camelCaseMembers returns a decoded JSON value with the names of its objects' members, at any
+// depth, in camelCase. A name that is not in snake_case is its own camelCase form.
+func camelCaseMembers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for name, member := range v {
+			if !strings.Contains(name, "_") {
+				result[name] = camelCaseMembers(member)
+			}
+		}
+		// A member named in both cases is the camelCase one, whichever is read first.
+		for name, member := range v {
+			if _, ok := result[camelCase(name)]; !ok {
+				result[camelCase(name)] = camelCaseMembers(member)
+			}
+		}
+		return result
+	case []any:
+		for i := range v {
+			v[i] = camelCaseMembers(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// camelCase returns the camelCase form of a snake_case name.
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
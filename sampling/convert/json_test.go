@@ -0,0 +1,134 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *api_v2.SamplingStrategyResponse
+		format   Format
+		expected string
+	}{
+		{
+			name:     "proto",
+			response: probabilistic(),
+			format:   FormatProto,
+			expected: `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.5},"rateLimitingSampling":null,` +
+				`"operationSampling":{"defaultSamplingProbability":0.5,"defaultLowerBoundTracesPerSecond":0.25,` +
+				`"perOperationStrategies":[{"operation":"/health","probabilisticSampling":{"samplingRate":0}},` +
+				`{"operation":"/orders","probabilisticSampling":{"samplingRate":1}}],"defaultUpperBoundTracesPerSecond":20}}`,
+		},
+		{
+			name:     "thrift",
+			response: probabilistic(),
+			format:   FormatThrift,
+			expected: `{"strategyType":0,"probabilisticSampling":{"samplingRate":0.5},` +
+				`"operationSampling":{"defaultSamplingProbability":0.5,"defaultLowerBoundTracesPerSecond":0.25,` +
+				`"perOperationStrategies":[{"operation":"/health","probabilisticSampling":{"samplingRate":0}},` +
+				`{"operation":"/orders","probabilisticSampling":{"samplingRate":1}}],"defaultUpperBoundTracesPerSecond":20}}`,
+		},
+		{
+			name:     "thrift snake case",
+			response: probabilistic(),
+			format:   FormatThriftSnakeCase,
+			expected: `{"strategy_type":0,"probabilistic_sampling":{"sampling_rate":0.5},` +
+				`"operation_sampling":{"default_sampling_probability":0.5,"default_lower_bound_traces_per_second":0.25,` +
+				`"per_operation_strategies":[{"operation":"/health","probabilistic_sampling":{"sampling_rate":0}},` +
+				`{"operation":"/orders","probabilistic_sampling":{"sampling_rate":1}}],"default_upper_bound_traces_per_second":20}}`,
+		},
+		{
+			name:     "proto rate limiting",
+			response: rateLimiting(100000),
+			format:   FormatProto,
+			expected: `{"strategyType":"RATE_LIMITING","probabilisticSampling":null,"rateLimitingSampling":{"maxTracesPerSecond":100000},"operationSampling":null}`,
+		},
+		{
+			name:     "thrift rate limiting clamped",
+			response: rateLimiting(100000),
+			format:   FormatThrift,
+			expected: `{"strategyType":1,"rateLimitingSampling":{"maxTracesPerSecond":32767}}`,
+		},
+		{
+			name:     "thrift without operations or an upper bound",
+			response: &api_v2.SamplingStrategyResponse{OperationSampling: &api_v2.PerOperationSamplingStrategies{}},
+			format:   FormatThrift,
+			expected: `{"strategyType":0,"operationSampling":{"defaultSamplingProbability":0,"defaultLowerBoundTracesPerSecond":0,"perOperationStrategies":[]}}`,
+		},
+		{name: "proto nil", format: FormatProto, expected: `null`},
+		{name: "thrift nil", format: FormatThrift, expected: `null`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := MarshalJSON(test.response, test.format)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(data))
+		})
+	}
+}
+
+func TestMarshalJSON_Error(t *testing.T) {
+	_, err := MarshalJSON(probabilistic(), Format(7))
+	require.EqualError(t, err, "unknown JSON format 7")
+
+	response := &api_v2.SamplingStrategyResponse{ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: math.NaN()}}
+	_, err = MarshalJSON(response, FormatThrift)
+	require.Error(t, err)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for _, format := range []Format{FormatProto, FormatThrift, FormatThriftSnakeCase} {
+		data, err := MarshalJSON(probabilistic(), format)
+		require.NoError(t, err)
+		response, err := UnmarshalJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, probabilistic(), response, "format %d", format)
+
+		data, err = MarshalJSON(rateLimiting(100), format)
+		require.NoError(t, err)
+		response, err = UnmarshalJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, rateLimiting(100), response, "format %d", format)
+	}
+}
+
+func TestUnmarshalJSON_Mixed(t *testing.T) {
+	response, err := UnmarshalJSON([]byte(`{
+		"strategy_type": "RATE_LIMITING",
+		"strategyType": 0,
+		"rateLimitingSampling": {"max_traces_per_second": 5},
+		"extra": {"some_member": [1, {"a_b": 2}]}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_PROBABILISTIC,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+	}, response, "the camelCase member over the snake_case one")
+
+	for _, data := range []string{`{`, `{"strategyType": "SOMETIMES"}`, `{"rateLimitingSampling": {"maxTracesPerSecond": "many"}}`} {
+		_, err := UnmarshalJSON([]byte(data))
+		assert.ErrorContains(t, err, "invalid sampling strategy: ", data)
+	}
+}
+
+func TestCase(t *testing.T) {
+	for camel, snake := range map[string]string{
+		"strategyType":                     "strategy_type",
+		"defaultLowerBoundTracesPerSecond": "default_lower_bound_traces_per_second",
+		"operation":                        "operation",
+	} {
+		assert.Equal(t, snake, snakeCase(camel))
+		assert.Equal(t, camel, camelCase(snake))
+	}
+	assert.Equal(t, "trailing", camelCase("trailing_"))
+}
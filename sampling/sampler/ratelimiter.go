@@ -0,0 +1,62 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package sampler
+
+import (
+	"time"
+)
+
+// rateLimiter is a token bucket: it holds a balance of credits, up to a maximum, which it earns at
+// a rate of credits a second and which each item it lets through spends. It is Jaeger's, so that
+// a service sampled by it samples as one of a Jaeger SDK does. It is not safe for concurrent use.
+type rateLimiter struct {
+	creditsPerSecond float64
+	balance          float64
+	maxBalance       float64
+	lastTick         time.Time
+	now              func() time.Time
+}
+
+// newRateLimiter returns a rate limiter with a full balance, so that the first items after it is
+// made are let through, as they are by an SDK that has just started. The maximum balance, here and
+// in update, is positive.
+func newRateLimiter(creditsPerSecond, maxBalance float64, now func() time.Time) *rateLimiter {
+	return &rateLimiter{
+		creditsPerSecond: creditsPerSecond,
+		balance:          maxBalance,
+		maxBalance:       maxBalance,
+		lastTick:         now(),
+		now:              now,
+	}
+}
+
+// checkCredit returns whether the balance holds the cost of an item, and spends it if so.
+func (r *rateLimiter) checkCredit(cost float64) bool {
+	r.updateBalance()
+	if r.balance < cost {
+		return false
+	}
+	r.balance -= cost
+	return true
+}
+
+// update changes the rate and maximum balance. The balance is scaled to the new maximum, so that
+// a limiter that was full, or empty, still is: an update neither grants a burst nor takes away
+// the credit earned.
+func (r *rateLimiter) update(creditsPerSecond, maxBalance float64) {
+	r.updateBalance()
+	r.creditsPerSecond = creditsPerSecond
+	r.balance = maxBalance * r.balance / r.maxBalance
+	r.maxBalance = maxBalance
+}
+
+// updateBalance adds the credits earned since it last did. A clock that went back earns nothing
+// until it is past where it was, rather than taking credits away, or granting again those earned.
+func (r *rateLimiter) updateBalance() {
+	now := r.now()
+	if elapsed := now.Sub(r.lastTick); elapsed > 0 {
+		r.balance = min(r.balance+elapsed.Seconds()*r.creditsPerSecond, r.maxBalance)
+		r.lastTick = now
+	}
+}
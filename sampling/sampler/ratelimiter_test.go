@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package sampler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a clock that moves only when a test advances it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// spend returns how many of n items a rate limiter lets through.
+func spend(r *rateLimiter, n int) int {
+	passed := 0
+	for range n {
+		if r.checkCredit(1) {
+			passed++
+		}
+	}
+	return passed
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(2, 2, clock.Now)
+	assert.Equal(t, 2, spend(limiter, 5), "a full balance at first")
+
+	clock.Advance(250 * time.Millisecond)
+	assert.Equal(t, 0, spend(limiter, 1), "half a credit")
+	clock.Advance(250 * time.Millisecond)
+	assert.Equal(t, 1, spend(limiter, 5))
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, 2, spend(limiter, 5), "no more than the max balance")
+}
+
+func TestRateLimiter_ClockBack(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(1, 1, clock.Now)
+	assert.Equal(t, 1, spend(limiter, 1))
+
+	clock.Advance(-time.Second)
+	assert.Equal(t, 0, spend(limiter, 1), "nothing earned going back")
+	clock.Advance(time.Second)
+	assert.Equal(t, 0, spend(limiter, 1), "nor earned again coming back")
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, spend(limiter, 1))
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	clock := newFakeClock()
+	limiter := newRateLimiter(10, 10, clock.Now)
+	assert.Equal(t, 5, spend(limiter, 5))
+
+	limiter.update(2, 2)
+	assert.Equal(t, 1, spend(limiter, 5), "the half left of the balance scaled")
+	clock.Advance(time.Second)
+	assert.Equal(t, 2, spend(limiter, 5), "earned at the new rate")
+
+	limiter.update(4, 4)
+	assert.Equal(t, 0, spend(limiter, 1), "an empty balance stays empty")
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sampler decides which traces are sampled by a sampling strategy, as the samplers of the
+// Jaeger SDKs decide it, and tags the root span of each with the sampler.type and sampler.param
+// that model.Span.GetSamplerType and adaptive sampling read back:
+//
+//   - probabilistic: a trace is sampled when its trace ID falls under a boundary that is the
+//     probability's share of the IDs, so that every service that samples a trace by the same
+//     probability makes the same decision of it; sampler.param is the probability.
+//   - ratelimiting: traces are sampled up to a number a second, by a token bucket that holds at
+//     least one trace's credit, so that a limit below one a second samples a trace now and then;
+//     sampler.param is the limit.
+//   - lowerbound: a trace of an operation that its probability does not sample is by a rate limiter
+//     of the operation's lower bound, so that an operation with little traffic is sampled at least
+//     that often; sampler.param is the operation's probability, as it is of the traces that
+//     probability samples.
+//   - const: every trace is sampled, or none; sampler.param is which.
+//
+// A strategy with per-operation strategies samples each operation by its probability and the lower
+// bound, an operation it does not name by its default probability and the lower bound, and keeps
+// to no upper bound, as the SDKs do.
+package sampler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// DefaultMaxOperations is the number of operations a Sampler keeps a sampler of its own for,
+// unless WithMaxOperations says otherwise. It is the SDKs'.
+const DefaultMaxOperations = 2000
+
+// maxRandomNumber is the mask of the bits of a trace ID's low half the SDKs compare to a
+// probability's boundary: all but the sign bit, which some of them have no unsigned type for.
+const maxRandomNumber = ^(uint64(1) << 63)
+
+// Option configures a Sampler.
+type Option func(*options)
+
+type options struct {
+	now           func() time.Time
+	maxOperations int
+}
+
+// WithClock sets the clock the rate limiters earn their credits by. It is time.Now, unless a test
+// wants time to pass at its command.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithMaxOperations sets the number of operations the sampler keeps a sampler of its own for. An
+// operation past it that the strategy does not name is sampled by the default probability alone,
+// without a lower bound, so that a service whose operation names are unbounded, with an ID in
+// them, say, does not grow a sampler for each.
+func WithMaxOperations(n int) Option {
+	return func(o *options) {
+		o.maxOperations = n
+	}
+}
+
+// Sampler samples traces by a strategy. It is safe for concurrent use.
+type Sampler struct {
+	options options
+
+	mu      sync.Mutex
+	sampler sampler
+}
+
+// sampler is a decision of the Sampler, which serializes calls to it.
+type sampler interface {
+	sample(traceID model.TraceID, operation string) (bool, []model.KeyValue)
+}
+
+// New returns a sampler of a strategy, which it checks. The kind of sampler is that of the
+// sampling the strategy gives, per-operation, probabilistic or rate limiting, taken in that order
+// as the SDKs take it, rather than its strategy type, which an SDK does not read.
+func New(response *api_v2.SamplingStrategyResponse, opts ...Option) (*Sampler, error) {
+	s := newSampler(opts)
+	if err := s.Update(response); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewConst returns a sampler that samples every trace, or none, until it is updated with a
+// strategy, as a client that has yet to be given one may want.
+func NewConst(sampled bool, opts ...Option) *Sampler {
+	s := newSampler(opts)
+	s.sampler = constSampler{
+		sampled: sampled,
+		tags:    tags(model.SamplerTypeConst, model.Bool(model.SamplerParamKey, sampled)),
+	}
+	return s
+}
+
+func newSampler(opts []Option) *Sampler {
+	s := &Sampler{options: options{now: time.Now, maxOperations: DefaultMaxOperations}}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+// Sample returns whether a trace of an operation is sampled, and the tags to set on its root span,
+// which say how. The tags are the sampler's, and not to be changed.
+func (s *Sampler) Sample(traceID model.TraceID, operation string) (bool, []model.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sampler.sample(traceID, operation)
+}
+
+// Update samples by a new strategy from now on, which it checks. A strategy that does not check is
+// refused, and the sampler samples by the one it had. A rate limiter the new strategy keeps, that
+// of a rate-limiting strategy or of an operation's lower bound, keeps its share of credits, so
+// that a strategy polled again does not grant a burst of traces each time.
+func (s *Sampler) Update(response *api_v2.SamplingStrategyResponse) error {
+	if err := validate(response); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case response.OperationSampling != nil:
+		if current, ok := s.sampler.(*perOperationSampler); ok {
+			current.update(response.OperationSampling)
+		} else {
+			s.sampler = newPerOperationSampler(response.OperationSampling, s.options)
+		}
+	case response.ProbabilisticSampling != nil:
+		s.sampler = newProbabilisticSampler(response.ProbabilisticSampling.SamplingRate)
+	default:
+		limit := float64(response.RateLimitingSampling.MaxTracesPerSecond)
+		if current, ok := s.sampler.(*rateLimitingSampler); ok {
+			current.update(limit)
+		} else {
+			s.sampler = newRateLimitingSampler(limit, s.options.now)
+		}
+	}
+	return nil
+}
+
+// validate checks a strategy, and returns its first mistake, and where it is.
+func validate(response *api_v2.SamplingStrategyResponse) error {
+	switch {
+	case response == nil:
+		return errors.New("no sampling strategy")
+	case response.OperationSampling != nil:
+		o := response.OperationSampling
+		if err := validateProbability(o.DefaultSamplingProbability); err != nil {
+			return fmt.Errorf("operationSampling: default %w", err)
+		}
+		if !(o.DefaultLowerBoundTracesPerSecond >= 0) || math.IsInf(o.DefaultLowerBoundTracesPerSecond, 1) {
+			return fmt.Errorf("operationSampling: default lower bound %v is not a non-negative number", o.DefaultLowerBoundTracesPerSecond)
+		}
+		for i, op := range o.PerOperationStrategies {
+			if op == nil || op.ProbabilisticSampling == nil {
+				continue
+			}
+			if err := validateProbability(op.ProbabilisticSampling.SamplingRate); err != nil {
+				return fmt.Errorf("operationSampling.perOperationStrategies[%d]: %w", i, err)
+			}
+		}
+	case response.ProbabilisticSampling != nil:
+		if err := validateProbability(response.ProbabilisticSampling.SamplingRate); err != nil {
+			return fmt.Errorf("probabilisticSampling: %w", err)
+		}
+	case response.RateLimitingSampling != nil:
+		if limit := response.RateLimitingSampling.MaxTracesPerSecond; limit < 0 {
+			return fmt.Errorf("rateLimitingSampling: max traces per second %d is negative", limit)
+		}
+	default:
+		return errors.New("the sampling strategy gives no sampling")
+	}
+	return nil
+}
+
+func validateProbability(probability float64) error {
+	if !(probability >= 0 && probability <= 1) {
+		return fmt.Errorf("probability %v is not in [0, 1]", probability)
+	}
+	return nil
+}
+
+// tags returns the tags of a sampler.
+func tags(samplerType model.SamplerType, param model.KeyValue) []model.KeyValue {
+	return []model.KeyValue{model.String(model.SamplerTypeKey, samplerType.String()), param}
+}
+
+// constSampler samples every trace, or none.
+type constSampler struct {
+	sampled bool
+	tags    []model.KeyValue
+}
+
+func (s constSampler) sample(model.TraceID, string) (bool, []model.KeyValue) {
+	return s.sampled, s.tags
+}
+
+// probabilisticSampler samples a trace when its trace ID is under the boundary of a probability.
+type probabilisticSampler struct {
+	probability float64
+	boundary    uint64
+	tags        []model.KeyValue
+}
+
+func newProbabilisticSampler(probability float64) *probabilisticSampler {
+	return &probabilisticSampler{
+		probability: probability,
+		boundary:    uint64(float64(maxRandomNumber) * probability),
+		tags:        tags(model.SamplerTypeProbabilistic, model.Float64(model.SamplerParamKey, probability)),
+	}
+}
+
+// sample compares the low half of the trace ID, without its top bit, to the boundary, as the SDKs
+// do: the high half of a 128-bit ID is often a timestamp, and not random.
+func (s *probabilisticSampler) sample(traceID model.TraceID, _ string) (bool, []model.KeyValue) {
+	return s.boundary >= traceID.Low&maxRandomNumber, s.tags
+}
+
+// rateLimitingSampler samples traces up to a number a second.
+type rateLimitingSampler struct {
+	limiter *rateLimiter
+	tags    []model.KeyValue
+}
+
+func newRateLimitingSampler(maxTracesPerSecond float64, now func() time.Time) *rateLimitingSampler {
+	return &rateLimitingSampler{
+		limiter: newRateLimiter(maxTracesPerSecond, max(maxTracesPerSecond, 1), now),
+		tags:    tags(model.SamplerTypeRateLimiting, model.Float64(model.SamplerParamKey, maxTracesPerSecond)),
+	}
+}
+
+func (s *rateLimitingSampler) sample(model.TraceID, string) (bool, []model.KeyValue) {
+	return s.limiter.checkCredit(1), s.tags
+}
+
+func (s *rateLimitingSampler) update(maxTracesPerSecond float64) {
+	s.limiter.update(maxTracesPerSecond, max(maxTracesPerSecond, 1))
+	s.tags = tags(model.SamplerTypeRateLimiting, model.Float64(model.SamplerParamKey, maxTracesPerSecond))
+}
+
+// guaranteedThroughputSampler samples an operation by a probability, and by a rate limiter of its
+// lower bound the traces the probability does not.
+type guaranteedThroughputSampler struct {
+	probabilistic *probabilisticSampler
+	lowerBound    float64
+	limiter       *rateLimiter
+	tags          []model.KeyValue
+}
+
+func newGuaranteedThroughputSampler(probability, lowerBound float64, now func() time.Time) *guaranteedThroughputSampler {
+	s := &guaranteedThroughputSampler{
+		lowerBound: lowerBound,
+		limiter:    newRateLimiter(lowerBound, max(lowerBound, 1), now),
+	}
+	s.setProbability(probability)
+	return s
+}
+
+// sample spends a credit of the lower bound on a trace the probability samples too, so that the
+// lower bound is of the traces sampled, and not in addition to them.
+func (s *guaranteedThroughputSampler) sample(traceID model.TraceID, operation string) (bool, []model.KeyValue) {
+	if sampled, tags := s.probabilistic.sample(traceID, operation); sampled {
+		s.limiter.checkCredit(1)
+		return true, tags
+	}
+	return s.limiter.checkCredit(1), s.tags
+}
+
+func (s *guaranteedThroughputSampler) update(probability, lowerBound float64) {
+	if probability != s.probabilistic.probability {
+		s.setProbability(probability)
+	}
+	if lowerBound != s.lowerBound {
+		s.lowerBound = lowerBound
+		s.limiter.update(lowerBound, max(lowerBound, 1))
+	}
+}
+
+func (s *guaranteedThroughputSampler) setProbability(probability float64) {
+	s.probabilistic = newProbabilisticSampler(probability)
+	s.tags = tags(model.SamplerTypeLowerBound, model.Float64(model.SamplerParamKey, probability))
+}
+
+// perOperationSampler samples each operation by a guaranteed throughput sampler of its own, up to
+// a number of operations, and the rest by a default probability.
+type perOperationSampler struct {
+	options    options
+	operations map[string]*guaranteedThroughputSampler
+	// defaultSampler samples the operations past the max, and its probability is that of an
+	// operation the strategy does not name.
+	defaultSampler *probabilisticSampler
+	lowerBound     float64
+}
+
+func newPerOperationSampler(strategies *api_v2.PerOperationSamplingStrategies, options options) *perOperationSampler {
+	s := &perOperationSampler{
+		options:    options,
+		operations: make(map[string]*guaranteedThroughputSampler),
+	}
+	s.update(strategies)
+	return s
+}
+
+func (s *perOperationSampler) sample(traceID model.TraceID, operation string) (bool, []model.KeyValue) {
+	if sampler, ok := s.operations[operation]; ok {
+		return sampler.sample(traceID, operation)
+	}
+	if len(s.operations) >= s.options.maxOperations {
+		return s.defaultSampler.sample(traceID, operation)
+	}
+	sampler := newGuaranteedThroughputSampler(s.defaultSampler.probability, s.lowerBound, s.options.now)
+	s.operations[operation] = sampler
+	return sampler.sample(traceID, operation)
+}
+
+// update samples by new strategies. An operation they do not name, which an earlier strategy did,
+// or which was sampled without one, is sampled by the default probability again, rather than by
+// one they no longer give.
+func (s *perOperationSampler) update(strategies *api_v2.PerOperationSamplingStrategies) {
+	s.defaultSampler = newProbabilisticSampler(strategies.DefaultSamplingProbability)
+	s.lowerBound = strategies.DefaultLowerBoundTracesPerSecond
+	named := make(map[string]float64, len(strategies.PerOperationStrategies))
+	for _, op := range strategies.PerOperationStrategies {
+		if op == nil || op.ProbabilisticSampling == nil {
+			continue
+		}
+		named[op.Operation] = op.ProbabilisticSampling.SamplingRate
+	}
+	for operation, sampler := range s.operations {
+		probability, ok := named[operation]
+		if !ok {
+			probability = strategies.DefaultSamplingProbability
+		}
+		sampler.update(probability, s.lowerBound)
+	}
+	for operation, probability := range named {
+		if _, ok := s.operations[operation]; !ok {
+			s.operations[operation] = newGuaranteedThroughputSampler(probability, s.lowerBound, s.options.now)
+		}
+	}
+}
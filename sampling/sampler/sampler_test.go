@@ -0,0 +1,271 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package sampler
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// Trace IDs under and over every boundary but those of 0 and 1.
+var (
+	low  = model.NewTraceID(0, 1)
+	high = model.NewTraceID(0, maxRandomNumber)
+)
+
+func probabilistic(probability float64) *api_v2.SamplingStrategyResponse {
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: probability},
+	}
+}
+
+func rateLimiting(limit int32) *api_v2.SamplingStrategyResponse {
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: limit},
+	}
+}
+
+// perOperation returns a strategy with a default probability of 0, a lower bound and the
+// probabilities of operations, given as pairs of a name and a probability.
+func perOperation(lowerBound float64, operations ...any) *api_v2.SamplingStrategyResponse {
+	strategies := &api_v2.PerOperationSamplingStrategies{DefaultLowerBoundTracesPerSecond: lowerBound}
+	for i := 0; i < len(operations); i += 2 {
+		strategies.PerOperationStrategies = append(strategies.PerOperationStrategies, &api_v2.OperationSamplingStrategy{
+			Operation:             operations[i].(string),
+			ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: operations[i+1].(float64)},
+		})
+	}
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: 0},
+		OperationSampling:     strategies,
+	}
+}
+
+func newSamplerOf(t *testing.T, response *api_v2.SamplingStrategyResponse, opts ...Option) *Sampler {
+	s, err := New(response, opts...)
+	require.NoError(t, err)
+	return s
+}
+
+func samplerTags(samplerType string, param model.KeyValue) []model.KeyValue {
+	return []model.KeyValue{model.String(model.SamplerTypeKey, samplerType), param}
+}
+
+func TestSample_Probabilistic(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		traceID     model.TraceID
+		expected    bool
+	}{
+		{name: "on the boundary", probability: 0.5, traceID: model.NewTraceID(0, 1<<62), expected: true},
+		{name: "past the boundary", probability: 0.5, traceID: model.NewTraceID(0, 1<<62+1), expected: false},
+		{name: "the top bit ignored", probability: 0.5, traceID: model.NewTraceID(0, 1<<63|1), expected: true},
+		{name: "the high half ignored", probability: 0.5, traceID: model.NewTraceID(math.MaxUint64, 1), expected: true},
+		{name: "all", probability: 1, traceID: high, expected: true},
+		{name: "none", probability: 0, traceID: low, expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sampled, tags := newSamplerOf(t, probabilistic(test.probability)).Sample(test.traceID, "op")
+			assert.Equal(t, test.expected, sampled)
+			assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, test.probability)), tags)
+		})
+	}
+}
+
+func TestSample_RateLimiting(t *testing.T) {
+	clock := newFakeClock()
+	s := newSamplerOf(t, rateLimiting(2), WithClock(clock.Now))
+	sampled, tags := s.Sample(low, "op")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("ratelimiting", model.Float64(model.SamplerParamKey, 2)), tags)
+	sampled, _ = s.Sample(low, "op")
+	assert.True(t, sampled)
+	sampled, _ = s.Sample(low, "op")
+	assert.False(t, sampled)
+
+	clock.Advance(500 * time.Millisecond)
+	sampled, _ = s.Sample(low, "op")
+	assert.True(t, sampled)
+
+	// A limit below one a second holds a trace's credit all the same.
+	s = newSamplerOf(t, rateLimiting(0), WithClock(clock.Now))
+	sampled, _ = s.Sample(low, "op")
+	assert.True(t, sampled)
+	clock.Advance(time.Hour)
+	sampled, _ = s.Sample(low, "op")
+	assert.False(t, sampled)
+}
+
+func TestSample_PerOperation(t *testing.T) {
+	clock := newFakeClock()
+	s := newSamplerOf(t, perOperation(1, "all", 1.0, "half", 0.5), WithClock(clock.Now))
+
+	sampled, tags := s.Sample(high, "all")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, 1)), tags)
+
+	// An operation it does not name is sampled by the default probability, 0, and the lower bound.
+	sampled, tags = s.Sample(low, "unnamed")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("lowerbound", model.Float64(model.SamplerParamKey, 0)), tags)
+	sampled, _ = s.Sample(low, "unnamed")
+	assert.False(t, sampled, "the lower bound spent")
+	clock.Advance(time.Second)
+	sampled, _ = s.Sample(low, "unnamed")
+	assert.True(t, sampled)
+
+	// A trace the probability samples spends the lower bound's credit too.
+	sampled, tags = s.Sample(low, "half")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, 0.5)), tags)
+	sampled, tags = s.Sample(high, "half")
+	assert.False(t, sampled)
+	assert.Equal(t, samplerTags("lowerbound", model.Float64(model.SamplerParamKey, 0.5)), tags)
+}
+
+func TestSample_MaxOperations(t *testing.T) {
+	s := newSamplerOf(t, perOperation(1, "named", 0.0), WithMaxOperations(1))
+	sampled, tags := s.Sample(low, "named")
+	assert.True(t, sampled, "by the lower bound")
+	assert.Equal(t, samplerTags("lowerbound", model.Float64(model.SamplerParamKey, 0)), tags)
+
+	sampled, tags = s.Sample(low, "past the max")
+	assert.False(t, sampled, "without a lower bound")
+	assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, 0)), tags)
+}
+
+func TestSample_Const(t *testing.T) {
+	for _, expected := range []bool{true, false} {
+		sampled, tags := NewConst(expected).Sample(low, "op")
+		assert.Equal(t, expected, sampled)
+		assert.Equal(t, samplerTags("const", model.Bool(model.SamplerParamKey, expected)), tags)
+	}
+}
+
+func TestSample_SpanTags(t *testing.T) {
+	tests := []struct {
+		sampler  *Sampler
+		expected model.SamplerType
+	}{
+		{sampler: newSamplerOf(t, probabilistic(1)), expected: model.SamplerTypeProbabilistic},
+		{sampler: newSamplerOf(t, rateLimiting(1)), expected: model.SamplerTypeRateLimiting},
+		{sampler: newSamplerOf(t, perOperation(1)), expected: model.SamplerTypeLowerBound},
+		{sampler: NewConst(true), expected: model.SamplerTypeConst},
+	}
+	for _, test := range tests {
+		_, tags := test.sampler.Sample(low, "op")
+		span := &model.Span{Tags: tags}
+		assert.Equal(t, test.expected, span.GetSamplerType())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	clock := newFakeClock()
+	s := NewConst(false, WithClock(clock.Now))
+
+	require.NoError(t, s.Update(rateLimiting(2)))
+	sampled, _ := s.Sample(low, "op")
+	assert.True(t, sampled)
+	require.NoError(t, s.Update(rateLimiting(4)))
+	// The half of the balance left, scaled: 2 of 4.
+	sampled, tags := s.Sample(low, "op")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("ratelimiting", model.Float64(model.SamplerParamKey, 4)), tags)
+	sampled, _ = s.Sample(low, "op")
+	assert.True(t, sampled)
+	sampled, _ = s.Sample(low, "op")
+	assert.False(t, sampled, "no burst from the update")
+
+	require.NoError(t, s.Update(perOperation(1, "op", 0.0)))
+	sampled, _ = s.Sample(high, "op")
+	assert.True(t, sampled, "by a new lower bound")
+	require.NoError(t, s.Update(perOperation(1, "other", 0.0)))
+	sampled, tags = s.Sample(high, "op")
+	assert.False(t, sampled, "the lower bound spent is kept")
+	assert.Equal(t, samplerTags("lowerbound", model.Float64(model.SamplerParamKey, 0)), tags)
+
+	update := perOperation(1, "other", 0.0)
+	update.OperationSampling.DefaultSamplingProbability = 1
+	require.NoError(t, s.Update(update))
+	sampled, tags = s.Sample(high, "op")
+	assert.True(t, sampled, "an operation no longer named takes the default probability")
+	assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, 1)), tags)
+
+	require.NoError(t, s.Update(probabilistic(1)))
+	sampled, tags = s.Sample(high, "op")
+	assert.True(t, sampled)
+	assert.Equal(t, samplerTags("probabilistic", model.Float64(model.SamplerParamKey, 1)), tags)
+
+	require.Error(t, s.Update(probabilistic(2)))
+	sampled, _ = s.Sample(high, "op")
+	assert.True(t, sampled, "the strategy it had kept")
+}
+
+func TestUpdate_Invalid(t *testing.T) {
+	lowerBound := perOperation(-1)
+	operation := perOperation(0, "a", 0.5, "b", 1.5)
+	operation.OperationSampling.PerOperationStrategies = append(operation.OperationSampling.PerOperationStrategies, nil)
+	defaultProbability := perOperation(0)
+	defaultProbability.OperationSampling.DefaultSamplingProbability = math.NaN()
+	tests := []struct {
+		name     string
+		response *api_v2.SamplingStrategyResponse
+		expected string
+	}{
+		{name: "nil", expected: "no sampling strategy"},
+		{name: "empty", response: &api_v2.SamplingStrategyResponse{}, expected: "the sampling strategy gives no sampling"},
+		{name: "probability", response: probabilistic(-0.5), expected: "probabilisticSampling: probability -0.5 is not in [0, 1]"},
+		{name: "rate limit", response: rateLimiting(-1), expected: "rateLimitingSampling: max traces per second -1 is negative"},
+		{
+			name:     "default probability",
+			response: defaultProbability,
+			expected: "operationSampling: default probability NaN is not in [0, 1]",
+		},
+		{
+			name:     "lower bound",
+			response: lowerBound,
+			expected: "operationSampling: default lower bound -1 is not a non-negative number",
+		},
+		{
+			name:     "operation",
+			response: operation,
+			expected: "operationSampling.perOperationStrategies[1]: probability 1.5 is not in [0, 1]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(test.response)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestSampler_Concurrent(t *testing.T) {
+	s := newSamplerOf(t, perOperation(1, "a", 0.5))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 100 {
+				if j%10 == 0 {
+					assert.NoError(t, s.Update(perOperation(float64(i), "a", 0.5)))
+				}
+				s.Sample(model.NewTraceID(0, uint64(j)), "op")
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/sampling/strategy"
+)
+
+// DefaultReloadInterval is how often a FileProvider looks for a change to its file, unless
+// WithReloadInterval says otherwise.
+const DefaultReloadInterval = 5 * time.Second
+
+// FileOption configures a FileProvider.
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	reloadInterval time.Duration
+	onReloadError  func(error)
+}
+
+// WithReloadInterval sets how often the file is looked at for a change. An interval of zero or
+// less looks only when Reload is called.
+func WithReloadInterval(interval time.Duration) FileOption {
+	return func(o *fileOptions) {
+		o.reloadInterval = interval
+	}
+}
+
+// WithReloadErrorHandler sets a function that is called with the error of a reload that failed,
+// and the previous strategies kept, whether the file could not be read or did not check. It is
+// called once for each change that gives an error. Without one, such an error is dropped, and only
+// Reload returns it.
+func WithReloadErrorHandler(handler func(error)) FileOption {
+	return func(o *fileOptions) {
+		o.onReloadError = handler
+	}
+}
+
+// FileProvider serves the strategies of a strategies.json file, and reloads them when the file
+// changes.
+//
+// A change is found by reading the file every reload interval and comparing it to what was read
+// last, rather than by its modification time, which an editor or a mounted ConfigMap that swaps a
+// symlink does not reliably change. A file that no longer reads or checks is not served: the
+// strategies read last are kept, and the error is reported, so that a mistake in an edit does not
+// change how any service is sampled. So is a file caught half written, until a later look finds it
+// whole; a file replaced by a rename is never caught so.
+type FileProvider struct {
+	path    string
+	options fileOptions
+
+	strategies atomic.Pointer[strategy.Strategies]
+	// mu serializes reloads, and guards last, the content read last, and err, the error reading
+	// it gave, which stands until the content changes.
+	mu   sync.Mutex
+	last []byte
+	err  error
+
+	closeOnce sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewFileProvider reads, checks and serves the strategies of the file at a path. A file that
+// cannot be read or does not check is refused. Unless the reload interval is zero or less, the
+// file is looked at for changes until Close is called.
+func NewFileProvider(path string, opts ...FileOption) (*FileProvider, error) {
+	p := &FileProvider{
+		path:    path,
+		options: fileOptions{reloadInterval: DefaultReloadInterval},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&p.options)
+	}
+	if _, err := p.reload(); err != nil {
+		return nil, err
+	}
+	if p.options.reloadInterval <= 0 {
+		close(p.done)
+		return p, nil
+	}
+	go p.watch()
+	return p, nil
+}
+
+// GetSamplingStrategy returns the strategy of a service, from the strategies read last.
+func (p *FileProvider) GetSamplingStrategy(_ context.Context, serviceName string) (*api_v2.SamplingStrategyResponse, error) {
+	return p.strategies.Load().Get(serviceName), nil
+}
+
+// Reload reads the file, and serves its strategies if it changed and checks. When it does not,
+// the strategies read last are kept, and the error returned.
+func (p *FileProvider) Reload() error {
+	_, err := p.reload()
+	return err
+}
+
+// reload reloads the file, and returns whether it changed since it was read last, with the error
+// of reading it. A file that did not change gives the error it gave then.
+func (p *FileProvider) reload() (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		err = fmt.Errorf("cannot read sampling strategies: %w", err)
+		changed := p.err == nil || p.err.Error() != err.Error()
+		p.last, p.err = nil, err
+		return changed, err
+	}
+	if p.last != nil && bytes.Equal(data, p.last) {
+		return false, p.err
+	}
+	p.last = data
+	strategies, err := strategy.Parse(data)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", p.path, err)
+		return true, p.err
+	}
+	p.strategies.Store(strategies)
+	p.err = nil
+	return true, nil
+}
+
+// Close stops looking at the file for changes. The provider goes on serving the strategies read
+// last.
+func (p *FileProvider) Close() error {
+	p.closeOnce.Do(func() { close(p.stop) })
+	<-p.done
+	return nil
+}
+
+// watch reloads the file every reload interval until the provider is closed.
+func (p *FileProvider) watch() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.options.reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			// An error is reported once, when the file changes to what gives it, rather than
+			// at every look at a file that still gives it.
+			if changed, err := p.reload(); changed && err != nil && p.options.onReloadError != nil {
+				p.options.onReloadError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeStrategies replaces the file at a path whole, as a watcher never reads it half written.
+func writeStrategies(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(data), 0o600))
+	require.NoError(t, os.Rename(path+".tmp", path))
+}
+
+func samplingRate(t *testing.T, provider Provider, service string) float64 {
+	t.Helper()
+	response, err := provider.GetSamplingStrategy(context.Background(), service)
+	require.NoError(t, err)
+	return response.ProbabilisticSampling.GetSamplingRate()
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strategies.json")
+	writeStrategies(t, path, strategiesJSON)
+	provider, err := NewFileProvider(path, WithReloadInterval(0))
+	require.NoError(t, err)
+	defer provider.Close()
+	assert.Equal(t, 0.8, samplingRate(t, provider, "frontend"))
+
+	writeStrategies(t, path, `{"default_strategy": {"type": "probabilistic", "param": 0.1}}`)
+	assert.Equal(t, 0.8, samplingRate(t, provider, "frontend"), "not reloaded until asked")
+	require.NoError(t, provider.Reload())
+	assert.Equal(t, 0.1, samplingRate(t, provider, "frontend"))
+
+	writeStrategies(t, path, `{"default_strategy": {"type": "probabilistic", "param": 7}}`)
+	require.ErrorContains(t, provider.Reload(), "default_strategy: probability 7 is not in [0, 1]")
+	assert.Equal(t, 0.1, samplingRate(t, provider, "frontend"), "the strategies read last are kept")
+	require.Error(t, provider.Reload(), "the error stands until the file changes")
+
+	require.NoError(t, os.Remove(path))
+	require.ErrorContains(t, provider.Reload(), "cannot read sampling strategies")
+	assert.Equal(t, 0.1, samplingRate(t, provider, "frontend"))
+}
+
+func TestFileProvider_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewFileProvider(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "cannot read sampling strategies")
+
+	path := filepath.Join(dir, "strategies.json")
+	writeStrategies(t, path, `{"service_strategies": [{"service": ""}]}`)
+	_, err = NewFileProvider(path)
+	require.EqualError(t, err, path+": service_strategies[0]: service is required")
+}
+
+func TestFileProvider_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strategies.json")
+	writeStrategies(t, path, strategiesJSON)
+	var mu sync.Mutex
+	var errs []error
+	provider, err := NewFileProvider(path,
+		WithReloadInterval(time.Millisecond),
+		WithReloadErrorHandler(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}))
+	require.NoError(t, err)
+
+	writeStrategies(t, path, `{"default_strategy": {"type": "probabilistic", "param": 0.1}}`)
+	require.Eventually(t, func() bool { return samplingRate(t, provider, "frontend") == 0.1 }, 5*time.Second, time.Millisecond)
+
+	writeStrategies(t, path, `{"default_strategy": {"type": "probabilistic", "param": 7}}`)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, 5*time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, provider.Close())
+	require.NoError(t, provider.Close(), "closing twice")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, errs, 1, "reported once for the change, not at every look")
+	assert.ErrorContains(t, errs[0], "probability 7 is not in [0, 1]")
+	assert.Equal(t, 0.1, samplingRate(t, provider, "frontend"))
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/sampling/convert"
+)
+
+// maxRequestSize is the most bytes of a POST body read. The parameters of a request are a service
+// name.
+const maxRequestSize = 64 << 10
+
+// Handler returns the HTTP handler of the server's two HTTP endpoints:
+//
+//   - POST /api/v2/samplingStrategy, the binding sampling.proto declares for GetSamplingStrategy,
+//     which takes SamplingStrategyParameters and returns a SamplingStrategyResponse in their proto3
+//     JSON form, as a gRPC gateway does (see convert.FormatProto). An error is a google.rpc.Status,
+//     under the HTTP status a gateway maps its code to.
+//   - GET /sampling?service=, the endpoint of the Jaeger agent, which returns the Thrift
+//     SamplingStrategyResponse in the JSON the clients that poll the agent read (see
+//     convert.FormatThrift). An error is plain text.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v2/samplingStrategy", s.serveProto)
+	mux.HandleFunc("GET /sampling", s.serveAgent)
+	return mux
+}
+
+// serveProto serves POST /api/v2/samplingStrategy.
+func (s *Server) serveProto(w http.ResponseWriter, r *http.Request) {
+	params := &api_v2.SamplingStrategyParameters{}
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(http.MaxBytesReader(w, r.Body, maxRequestSize), params); err != nil {
+		writeStatus(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+		return
+	}
+	response, err := s.GetSamplingStrategy(r.Context(), params)
+	if err != nil {
+		writeStatus(w, err)
+		return
+	}
+	body, err := convert.MarshalJSON(response, convert.FormatProto)
+	if err != nil {
+		writeStatus(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeStatus writes a gRPC error as the google.rpc.Status a gRPC gateway writes, under the HTTP
+// status the gateway maps its code to.
+func writeStatus(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body, _ := json.Marshal(struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	}{Code: int32(st.Code()), Message: st.Message()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	w.Write(body)
+}
+
+// httpStatus returns the HTTP status a gRPC gateway answers a gRPC code with.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.Canceled:
+		// The status nginx answers a request its client closed with, which has no constant.
+		return 499
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// serveAgent serves GET /sampling?service=.
+func (s *Server) serveAgent(w http.ResponseWriter, r *http.Request) {
+	services := r.URL.Query()["service"]
+	if len(services) != 1 {
+		http.Error(w, "'service' parameter must be provided once", http.StatusBadRequest)
+		return
+	}
+	response, err := s.provider.GetSamplingStrategy(r.Context(), services[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body, err := convert.MarshalJSON(response, convert.FormatThrift)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// call sends a request to a handler, and returns the status and body of the response.
+func call(t *testing.T, handler http.Handler, method, target, body string) (int, string) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	data, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+	return recorder.Code, string(data)
+}
+
+func TestHandler_Proto(t *testing.T) {
+	handler := NewServer(staticProvider(t)).Handler()
+
+	code, body := call(t, handler, http.MethodPost, "/api/v2/samplingStrategy", `{"serviceName": "frontend", "extra": 1}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{
+		"strategyType": "PROBABILISTIC",
+		"probabilisticSampling": {"samplingRate": 0.8},
+		"rateLimitingSampling": null,
+		"operationSampling": {
+			"defaultSamplingProbability": 0.8,
+			"defaultLowerBoundTracesPerSecond": 0,
+			"defaultUpperBoundTracesPerSecond": 20,
+			"perOperationStrategies": [{"operation": "/health", "probabilisticSampling": {"samplingRate": 0}}]
+		}
+	}`, body)
+
+	code, body = call(t, handler, http.MethodPost, "/api/v2/samplingStrategy", `{"serviceName": "batch"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `"strategyType":"RATE_LIMITING"`)
+	assert.Contains(t, body, `"rateLimitingSampling":{"maxTracesPerSecond":100000}`)
+
+	code, body = call(t, handler, http.MethodPost, "/api/v2/samplingStrategy", `{"serviceName": `)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, `"code":3`)
+
+	code, _ = call(t, handler, http.MethodGet, "/api/v2/samplingStrategy", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestHandler_ProtoError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+		body     string
+	}{
+		{err: errors.New("store is down"), expected: http.StatusInternalServerError, body: `{"code":13,"message":"store is down"}`},
+		{err: status.Error(codes.Unavailable, "reloading"), expected: http.StatusServiceUnavailable, body: `{"code":14,"message":"reloading"}`},
+		{err: status.Error(codes.NotFound, "no such service"), expected: http.StatusNotFound, body: `{"code":5,"message":"no such service"}`},
+		{err: status.Error(codes.Aborted, "reloaded"), expected: http.StatusConflict, body: `{"code":10,"message":"reloaded"}`},
+		{err: status.Error(codes.AlreadyExists, "exists"), expected: http.StatusConflict, body: `{"code":6,"message":"exists"}`},
+		{err: status.Error(codes.Canceled, "gone"), expected: 499, body: `{"code":1,"message":"gone"}`},
+	}
+	for _, test := range tests {
+		handler := NewServer(failingProvider{err: test.err}).Handler()
+		code, body := call(t, handler, http.MethodPost, "/api/v2/samplingStrategy", `{"serviceName": "a"}`)
+		assert.Equal(t, test.expected, code)
+		assert.JSONEq(t, test.body, body)
+	}
+}
+
+func TestHandler_Agent(t *testing.T) {
+	handler := NewServer(staticProvider(t)).Handler()
+
+	code, body := call(t, handler, http.MethodGet, "/sampling?service=frontend", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{
+		"strategyType": 0,
+		"probabilisticSampling": {"samplingRate": 0.8},
+		"operationSampling": {
+			"defaultSamplingProbability": 0.8,
+			"defaultLowerBoundTracesPerSecond": 0,
+			"defaultUpperBoundTracesPerSecond": 20,
+			"perOperationStrategies": [{"operation": "/health", "probabilisticSampling": {"samplingRate": 0}}]
+		}
+	}`, body)
+
+	code, body = call(t, handler, http.MethodGet, "/sampling?service=batch", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"strategyType": 1, "rateLimitingSampling": {"maxTracesPerSecond": 32767}}`, body)
+
+	code, body = call(t, handler, http.MethodGet, "/sampling?service=unnamed", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"strategyType": 0, "probabilisticSampling": {"samplingRate": 0.5}}`, body)
+
+	for _, target := range []string{"/sampling", "/sampling?service=a&service=b"} {
+		code, body = call(t, handler, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusBadRequest, code, target)
+		assert.Equal(t, "'service' parameter must be provided once\n", body)
+	}
+
+	code, body = call(t, NewServer(failingProvider{err: errors.New("store is down")}).Handler(), http.MethodGet, "/sampling?service=a", "")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, "store is down\n", body)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package server serves sampling strategies the ways a Jaeger client asks for them: the api_v2
+// SamplingManager over gRPC, its POST /api/v2/samplingStrategy binding over HTTP, the agent's
+// GET /sampling?service= endpoint, and the legacy Thrift SamplingManager. Every one of them answers
+// from one Provider, so that a client asking by any of them is given the same strategy.
+//
+// What a Provider serves is the caller's to choose. FileProvider serves a strategies.json file, as
+// package strategy reads it, and reloads it when it changes; StaticProvider serves strategies that
+// do not change, which is what a test of a client's sampling wants.
+package server
+
+import (
+	"context"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/sampling/convert"
+	"github.com/jaegertracing/jaeger-idl/sampling/strategy"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/sampling"
+)
+
+// Provider is where a Server takes the strategy of a service from.
+type Provider interface {
+	// GetSamplingStrategy returns the strategy of a service, which the caller may change. A
+	// service a provider has no strategy of its own for is given its default one.
+	GetSamplingStrategy(ctx context.Context, serviceName string) (*api_v2.SamplingStrategyResponse, error)
+}
+
+// StaticProvider serves strategies that do not change.
+type StaticProvider struct {
+	strategies *strategy.Strategies
+}
+
+// NewStaticProvider returns a provider serving strategies.
+func NewStaticProvider(strategies *strategy.Strategies) *StaticProvider {
+	return &StaticProvider{strategies: strategies}
+}
+
+// GetSamplingStrategy returns the strategy of a service.
+func (p *StaticProvider) GetSamplingStrategy(_ context.Context, serviceName string) (*api_v2.SamplingStrategyResponse, error) {
+	return p.strategies.Get(serviceName), nil
+}
+
+// Server serves the strategies of a provider. It implements the api_v2 SamplingManagerServer, and
+// gives an HTTP handler and a Thrift processor for the other ways.
+type Server struct {
+	api_v2.UnimplementedSamplingManagerServer
+
+	provider Provider
+}
+
+// NewServer returns a server of the strategies of a provider.
+func NewServer(provider Provider) *Server {
+	return &Server{provider: provider}
+}
+
+// Register registers the server as the SamplingManager of a gRPC server.
+func (s *Server) Register(server *grpc.Server) {
+	api_v2.RegisterSamplingManagerServer(server, s)
+}
+
+// GetSamplingStrategy returns the strategy of the service the request names. An error of the
+// provider that is not a gRPC status is answered as Internal.
+func (s *Server) GetSamplingStrategy(ctx context.Context, params *api_v2.SamplingStrategyParameters) (*api_v2.SamplingStrategyResponse, error) {
+	response, err := s.provider.GetSamplingStrategy(ctx, params.ServiceName)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return response, nil
+}
+
+// ThriftProcessor returns a processor of the legacy Thrift SamplingManager, to serve over the
+// transport and protocol the caller's clients use.
+func (s *Server) ThriftProcessor() thrift.TProcessor {
+	return sampling.NewSamplingManagerProcessor(thriftManager{s})
+}
+
+// thriftManager answers the Thrift SamplingManager from a server.
+type thriftManager struct {
+	server *Server
+}
+
+func (m thriftManager) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	response, err := m.server.provider.GetSamplingStrategy(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ToThrift(response), nil
+}
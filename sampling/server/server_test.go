@@ -0,0 +1,149 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger-idl/sampling/strategy"
+	"github.com/jaegertracing/jaeger-idl/thrift-gen/sampling"
+)
+
+const strategiesJSON = `{
+	"default_strategy": {"type": "probabilistic", "param": 0.5},
+	"service_strategies": [
+		{
+			"service": "frontend",
+			"type": "probabilistic",
+			"param": 0.8,
+			"default_upper_bound_traces_per_second": 20,
+			"operation_strategies": [{"operation": "/health", "type": "probabilistic", "param": 0}]
+		},
+		{"service": "batch", "type": "ratelimiting", "param": 100000}
+	]
+}`
+
+func staticProvider(t *testing.T) *StaticProvider {
+	strategies, err := strategy.Parse([]byte(strategiesJSON))
+	require.NoError(t, err)
+	return NewStaticProvider(strategies)
+}
+
+// failingProvider answers every request with an error.
+type failingProvider struct {
+	err error
+}
+
+func (p failingProvider) GetSamplingStrategy(context.Context, string) (*api_v2.SamplingStrategyResponse, error) {
+	return nil, p.err
+}
+
+// serveGRPC serves a server over an in-memory connection, and returns a client of it.
+func serveGRPC(t *testing.T, server *Server) api_v2.SamplingManagerClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+	go grpcServer.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		grpcServer.Stop()
+	})
+	return api_v2.NewSamplingManagerClient(conn)
+}
+
+func TestServer_GRPC(t *testing.T) {
+	client := serveGRPC(t, NewServer(staticProvider(t)))
+	ctx := context.Background()
+
+	response, err := client.GetSamplingStrategy(ctx, &api_v2.SamplingStrategyParameters{ServiceName: "frontend"})
+	require.NoError(t, err)
+	assert.Equal(t, api_v2.SamplingStrategyType_PROBABILISTIC, response.StrategyType)
+	assert.Equal(t, 0.8, response.ProbabilisticSampling.SamplingRate)
+	assert.Equal(t, "/health", response.OperationSampling.PerOperationStrategies[0].Operation)
+
+	response, err = client.GetSamplingStrategy(ctx, &api_v2.SamplingStrategyParameters{ServiceName: "unnamed"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, response.ProbabilisticSampling.SamplingRate, "the default")
+}
+
+func TestServer_GRPCError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{name: "plain error", err: errors.New("store is down"), expected: codes.Internal},
+		{name: "status", err: status.Error(codes.Unavailable, "store is reloading"), expected: codes.Unavailable},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := serveGRPC(t, NewServer(failingProvider{err: test.err}))
+			_, err := client.GetSamplingStrategy(context.Background(), &api_v2.SamplingStrategyParameters{ServiceName: "a"})
+			assert.Equal(t, test.expected, status.Code(err))
+		})
+	}
+}
+
+// serveThrift serves a server's Thrift processor over HTTP, and returns a client of it.
+func serveThrift(t *testing.T, server *Server) *sampling.SamplingManagerClient {
+	protocol := thrift.NewTBinaryProtocolFactoryConf(nil)
+	httpServer := httptest.NewServer(http.HandlerFunc(thrift.NewThriftHandlerFunc(server.ThriftProcessor(), protocol, protocol)))
+	t.Cleanup(httpServer.Close)
+	transport, err := thrift.NewTHttpClient(httpServer.URL)
+	require.NoError(t, err)
+	return sampling.NewSamplingManagerClientFactory(transport, protocol)
+}
+
+func TestServer_Thrift(t *testing.T) {
+	client := serveThrift(t, NewServer(staticProvider(t)))
+	ctx := context.Background()
+
+	response, err := client.GetSamplingStrategy(ctx, "frontend")
+	require.NoError(t, err)
+	upper := 20.0
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.8},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.8,
+			DefaultUpperBoundTracesPerSecond: &upper,
+			PerOperationStrategies: []*sampling.OperationSamplingStrategy{{
+				Operation:             "/health",
+				ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0},
+			}},
+		},
+	}, response)
+
+	response, err = client.GetSamplingStrategy(ctx, "batch")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:         sampling.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &sampling.RateLimitingSamplingStrategy{MaxTracesPerSecond: 32767},
+	}, response, "a rate limit too large for 16 bits is clamped")
+}
+
+func TestServer_ThriftError(t *testing.T) {
+	client := serveThrift(t, NewServer(failingProvider{err: errors.New("store is down")}))
+	_, err := client.GetSamplingStrategy(context.Background(), "a")
+	require.ErrorContains(t, err, "store is down")
+}
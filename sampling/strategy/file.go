@@ -0,0 +1,137 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Type is the kind of sampling a strategy of the file names, spelled as the file and a span's
+// sampler.type tag spell it.
+type Type string
+
+const (
+	// TypeProbabilistic samples a trace with the probability the strategy's param gives, from 0
+	// to 1.
+	TypeProbabilistic Type = "probabilistic"
+	// TypeRateLimiting samples at most as many traces per second as the strategy's param gives,
+	// a whole number.
+	TypeRateLimiting Type = "ratelimiting"
+)
+
+// File is a strategies.json document, as Jaeger reads it:
+//
+//	{
+//	  "default_strategy": {"type": "probabilistic", "param": 0.5},
+//	  "service_strategies": [
+//	    {
+//	      "service": "frontend",
+//	      "type": "probabilistic",
+//	      "param": 0.8,
+//	      "operation_strategies": [{"operation": "GET /health", "type": "probabilistic", "param": 0}]
+//	    },
+//	    {"service": "batch", "type": "ratelimiting", "param": 5}
+//	  ]
+//	}
+//
+// A missing number is a nil pointer rather than zero, since for most of them zero is a value a
+// strategy means: a probability of 0 samples nothing, and is not the default's.
+type File struct {
+	// DefaultStrategy is the strategy of a service the file does not name, and what one it names
+	// inherits. It names no service, and has to have a type. Without it, the default samples with
+	// DefaultSamplingProbability.
+	DefaultStrategy   *ServiceStrategy   `json:"default_strategy,omitempty"`
+	ServiceStrategies []*ServiceStrategy `json:"service_strategies,omitempty"`
+}
+
+// ServiceStrategy is the strategy of one service, or the default strategy.
+type ServiceStrategy struct {
+	Service string `json:"service,omitempty"`
+	// Type and Param are given together, or, in a service's strategy, left out together to
+	// inherit the default strategy's.
+	Type  Type     `json:"type,omitempty"`
+	Param *float64 `json:"param,omitempty"`
+	// The bounds of per-operation sampling, in traces per second, which a service that leaves
+	// them out inherits from the default strategy. A lower bound is the rate each operation is
+	// sampled at however low its probability; an upper bound of 0, as of none, leaves the rate
+	// unbounded.
+	DefaultLowerBoundTracesPerSecond *float64 `json:"default_lower_bound_traces_per_second,omitempty"`
+	DefaultUpperBoundTracesPerSecond *float64 `json:"default_upper_bound_traces_per_second,omitempty"`
+	// OperationStrategies are probabilistic only, as PerOperationSamplingStrategies has them.
+	OperationStrategies []*OperationStrategy `json:"operation_strategies,omitempty"`
+}
+
+// OperationStrategy is the strategy of one operation of a service.
+type OperationStrategy struct {
+	Operation string   `json:"operation"`
+	Type      Type     `json:"type"`
+	Param     *float64 `json:"param"`
+}
+
+// validate checks a strategy on its own, reporting where with path. The bounds a service inherits
+// are checked against each other once resolved.
+func (s *ServiceStrategy) validate(path string) error {
+	if s.Type == "" {
+		if s.Param != nil {
+			return fmt.Errorf("%s: param is given without a type", path)
+		}
+	} else if err := validateParam(s.Type, s.Param); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, bound := range []struct {
+		name  string
+		value *float64
+	}{
+		{"default_lower_bound_traces_per_second", s.DefaultLowerBoundTracesPerSecond},
+		{"default_upper_bound_traces_per_second", s.DefaultUpperBoundTracesPerSecond},
+	} {
+		if bound.value != nil && !(*bound.value >= 0 && !math.IsInf(*bound.value, 1)) {
+			return fmt.Errorf("%s: %s %v is not a non-negative number", path, bound.name, *bound.value)
+		}
+	}
+	seen := map[string]bool{}
+	for i, op := range s.OperationStrategies {
+		opPath := fmt.Sprintf("%s.operation_strategies[%d]", path, i)
+		switch {
+		case op == nil:
+			return fmt.Errorf("%s: operation strategy is null", opPath)
+		case op.Operation == "":
+			return fmt.Errorf("%s: operation is required", opPath)
+		case seen[op.Operation]:
+			return fmt.Errorf("%s: operation %q has more than one strategy", opPath, op.Operation)
+		case op.Type != TypeProbabilistic:
+			return fmt.Errorf("%s: type %q is not %q, the only type an operation strategy has", opPath, op.Type, TypeProbabilistic)
+		}
+		if err := validateParam(op.Type, op.Param); err != nil {
+			return fmt.Errorf("%s: %w", opPath, err)
+		}
+		seen[op.Operation] = true
+	}
+	return nil
+}
+
+// validateParam checks the param of a strategy of a type is in the range the type gives it.
+func validateParam(t Type, param *float64) error {
+	switch t {
+	case TypeProbabilistic:
+		if param == nil {
+			return errors.New("param is required")
+		}
+		if !(*param >= 0 && *param <= 1) {
+			return fmt.Errorf("probability %v is not in [0, 1]", *param)
+		}
+	case TypeRateLimiting:
+		if param == nil {
+			return errors.New("param is required")
+		}
+		if !(*param >= 0 && *param <= math.MaxInt32) || *param != math.Trunc(*param) {
+			return fmt.Errorf("rate limit %v is not a whole number of traces per second from 0 to %d", *param, math.MaxInt32)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, want %q or %q", t, TypeProbabilistic, TypeRateLimiting)
+	}
+	return nil
+}
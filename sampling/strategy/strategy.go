@@ -0,0 +1,224 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package strategy reads the sampling strategies of a Jaeger strategies.json file, checks them,
+// and resolves each service's into the api_v2 SamplingStrategyResponse the SamplingManager answers
+// it with. The file is Jaeger's: a default_strategy, service_strategies each naming a service,
+// and, under either, operation_strategies naming its operations (see File).
+//
+// A file is checked whole before anything is served from it, and one that does not check is
+// refused with the first mistake and where it is, rather than served in part: a strategy that
+// reads wrong samples wrong, silently, in every process that asks for it.
+//
+// A service's strategy inherits what it leaves out from the default strategy, as Jaeger resolves
+// it:
+//
+//   - a strategy without a type and param samples as the default does;
+//   - its operation strategies are its own, followed by the default's for operations it does not
+//     name;
+//   - a per-operation bound it leaves out is the default's;
+//   - the probability of an operation without a strategy of its own is the service's, when it
+//     samples by probability, and the default's otherwise.
+//
+// A service that limits its rate, and names neither operation strategies nor bounds of its own,
+// inherits none of the default's: a client that is given per-operation strategies samples by them
+// instead of the rate limit, which the service's own strategy would then lose.
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gogo/protobuf/proto"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+// DefaultSamplingProbability is the probability a service is sampled with when the file has no
+// default strategy, and the one an operation is when neither its service nor the default strategy
+// gives one. It is Jaeger's.
+const DefaultSamplingProbability = 0.001
+
+// Strategies are the resolved strategies of a file. They are not changed once made, and are safe
+// for concurrent use.
+type Strategies struct {
+	defaultStrategy *api_v2.SamplingStrategyResponse
+	services        map[string]*api_v2.SamplingStrategyResponse
+}
+
+// Load reads, checks and resolves the strategies of the file at a path.
+func Load(path string) (*Strategies, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	strategies, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return strategies, nil
+}
+
+// Parse reads, checks and resolves the strategies of a strategies.json document. A member the
+// format does not define is refused, since it is most often a misspelt one whose strategy would
+// otherwise be dropped unnoticed.
+func Parse(data []byte) (*Strategies, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	file := &File{}
+	if err := decoder.Decode(file); err != nil {
+		return nil, fmt.Errorf("invalid strategies: %w", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid strategies: data after the document")
+	}
+	return New(file)
+}
+
+// New checks and resolves the strategies of a file. The strategies keep nothing of the file, which
+// the caller may go on changing.
+func New(file *File) (*Strategies, error) {
+	probability := DefaultSamplingProbability
+	defaultStrategy := &ServiceStrategy{Type: TypeProbabilistic, Param: &probability}
+	if file.DefaultStrategy != nil {
+		defaultStrategy = file.DefaultStrategy
+		switch {
+		case defaultStrategy.Service != "":
+			return nil, fmt.Errorf("default_strategy: service %q is given, but the default strategy is every service's", defaultStrategy.Service)
+		case defaultStrategy.Type == "":
+			return nil, errors.New("default_strategy: type is required")
+		}
+	}
+	if err := defaultStrategy.validate("default_strategy"); err != nil {
+		return nil, err
+	}
+	strategies := &Strategies{services: map[string]*api_v2.SamplingStrategyResponse{}}
+	var err error
+	if strategies.defaultStrategy, err = resolve(defaultStrategy, nil, "default_strategy"); err != nil {
+		return nil, err
+	}
+	for i, service := range file.ServiceStrategies {
+		path := fmt.Sprintf("service_strategies[%d]", i)
+		switch {
+		case service == nil:
+			return nil, fmt.Errorf("%s: service strategy is null", path)
+		case service.Service == "":
+			return nil, fmt.Errorf("%s: service is required", path)
+		case strategies.services[service.Service] != nil:
+			return nil, fmt.Errorf("%s: service %q has more than one strategy", path, service.Service)
+		}
+		if err := service.validate(path); err != nil {
+			return nil, err
+		}
+		if strategies.services[service.Service], err = resolve(service, defaultStrategy, path); err != nil {
+			return nil, err
+		}
+	}
+	return strategies, nil
+}
+
+// Get returns the strategy of a service: its own, when the file names it, or the default. The
+// response is the caller's to change.
+func (s *Strategies) Get(service string) *api_v2.SamplingStrategyResponse {
+	if response, ok := s.services[service]; ok {
+		return clone(response)
+	}
+	return s.Default()
+}
+
+// Default returns the default strategy, which a service the file does not name is sampled with.
+// The response is the caller's to change.
+func (s *Strategies) Default() *api_v2.SamplingStrategyResponse {
+	return clone(s.defaultStrategy)
+}
+
+// Services returns the services the file names a strategy for, sorted.
+func (s *Strategies) Services() []string {
+	services := make([]string, 0, len(s.services))
+	for service := range s.services {
+		services = append(services, service)
+	}
+	slices.Sort(services)
+	return services
+}
+
+// resolve returns the response a strategy resolves to with what it inherits from the default
+// strategy, or, given no default, the default strategy's own.
+func resolve(s, defaultStrategy *ServiceStrategy, path string) (*api_v2.SamplingStrategyResponse, error) {
+	inherited := defaultStrategy
+	if inherited == nil {
+		inherited = &ServiceStrategy{}
+	}
+	t, param := s.Type, s.Param
+	if t == "" {
+		t, param = inherited.Type, inherited.Param
+	}
+	response := &api_v2.SamplingStrategyResponse{}
+	if t == TypeRateLimiting {
+		response.StrategyType = api_v2.SamplingStrategyType_RATE_LIMITING
+		response.RateLimitingSampling = &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: int32(*param)}
+	} else {
+		response.StrategyType = api_v2.SamplingStrategyType_PROBABILISTIC
+		response.ProbabilisticSampling = &api_v2.ProbabilisticSamplingStrategy{SamplingRate: *param}
+	}
+
+	if t == TypeRateLimiting && len(s.OperationStrategies) == 0 &&
+		s.DefaultLowerBoundTracesPerSecond == nil && s.DefaultUpperBoundTracesPerSecond == nil {
+		return response, nil
+	}
+	operations := &api_v2.PerOperationSamplingStrategies{DefaultSamplingProbability: DefaultSamplingProbability}
+	switch {
+	case t == TypeProbabilistic:
+		operations.DefaultSamplingProbability = *param
+	case inherited.Type == TypeProbabilistic:
+		operations.DefaultSamplingProbability = *inherited.Param
+	}
+	lower, upper := s.DefaultLowerBoundTracesPerSecond, s.DefaultUpperBoundTracesPerSecond
+	if lower == nil {
+		lower = inherited.DefaultLowerBoundTracesPerSecond
+	}
+	if upper == nil {
+		upper = inherited.DefaultUpperBoundTracesPerSecond
+	}
+	if lower != nil {
+		operations.DefaultLowerBoundTracesPerSecond = *lower
+	}
+	if upper != nil {
+		operations.DefaultUpperBoundTracesPerSecond = *upper
+	}
+	if operations.DefaultUpperBoundTracesPerSecond != 0 &&
+		operations.DefaultUpperBoundTracesPerSecond < operations.DefaultLowerBoundTracesPerSecond {
+		return nil, fmt.Errorf("%s: upper bound %v traces per second is below the lower bound %v",
+			path, operations.DefaultUpperBoundTracesPerSecond, operations.DefaultLowerBoundTracesPerSecond)
+	}
+	named := map[string]bool{}
+	for _, op := range s.OperationStrategies {
+		operations.PerOperationStrategies = append(operations.PerOperationStrategies, operationStrategy(op))
+		named[op.Operation] = true
+	}
+	for _, op := range inherited.OperationStrategies {
+		if !named[op.Operation] {
+			operations.PerOperationStrategies = append(operations.PerOperationStrategies, operationStrategy(op))
+		}
+	}
+	if len(operations.PerOperationStrategies) == 0 && lower == nil && upper == nil {
+		return response, nil
+	}
+	response.OperationSampling = operations
+	return response, nil
+}
+
+func operationStrategy(op *OperationStrategy) *api_v2.OperationSamplingStrategy {
+	return &api_v2.OperationSamplingStrategy{
+		Operation:             op.Operation,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: *op.Param},
+	}
+}
+
+func clone(response *api_v2.SamplingStrategyResponse) *api_v2.SamplingStrategyResponse {
+	return proto.Clone(response).(*api_v2.SamplingStrategyResponse)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_v2 "github.com/jaegertracing/jaeger-idl/proto-gen/api_v2"
+)
+
+func probabilistic(rate float64, operations *api_v2.PerOperationSamplingStrategies) *api_v2.SamplingStrategyResponse {
+	return &api_v2.SamplingStrategyResponse{
+		StrategyType:          api_v2.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: rate},
+		OperationSampling:     operations,
+	}
+}
+
+func operation(name string, rate float64) *api_v2.OperationSamplingStrategy {
+	return &api_v2.OperationSamplingStrategy{
+		Operation:             name,
+		ProbabilisticSampling: &api_v2.ProbabilisticSamplingStrategy{SamplingRate: rate},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	strategies, err := Load("testdata/strategies.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"batch", "driver", "frontend", "redis"}, strategies.Services())
+
+	tests := []struct {
+		service  string
+		expected *api_v2.SamplingStrategyResponse
+	}{
+		{
+			service: "frontend",
+			expected: probabilistic(0.8, &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       0.8,
+				DefaultLowerBoundTracesPerSecond: 0.1,
+				PerOperationStrategies:           []*api_v2.OperationSamplingStrategy{operation("/metrics", 0.2), operation("GET /dispatch", 1), operation("/health", 0)},
+			}),
+		},
+		{
+			service: "driver",
+			expected: probabilistic(0.25, &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       0.25,
+				DefaultLowerBoundTracesPerSecond: 0.1,
+				PerOperationStrategies:           []*api_v2.OperationSamplingStrategy{operation("/health", 0), operation("/metrics", 0)},
+			}),
+		},
+		{
+			service: "batch",
+			expected: &api_v2.SamplingStrategyResponse{
+				StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+				RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 5},
+			},
+		},
+		{
+			service: "redis",
+			expected: probabilistic(0.5, &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       0.5,
+				DefaultLowerBoundTracesPerSecond: 0.1,
+				DefaultUpperBoundTracesPerSecond: 50,
+				PerOperationStrategies:           []*api_v2.OperationSamplingStrategy{operation("/health", 0), operation("/metrics", 0)},
+			}),
+		},
+		{
+			service: "unnamed",
+			expected: probabilistic(0.5, &api_v2.PerOperationSamplingStrategies{
+				DefaultSamplingProbability:       0.5,
+				DefaultLowerBoundTracesPerSecond: 0.1,
+				PerOperationStrategies:           []*api_v2.OperationSamplingStrategy{operation("/health", 0), operation("/metrics", 0)},
+			}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.service, func(t *testing.T) {
+			assert.Equal(t, test.expected, strategies.Get(test.service))
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load("testdata/missing.json")
+	require.ErrorContains(t, err, "missing.json")
+
+	_, err = Parse([]byte(`{"default_strategy": {"type": "probabilistic", "param": 2}}`))
+	require.EqualError(t, err, "default_strategy: probability 2 is not in [0, 1]")
+}
+
+func TestParse_Empty(t *testing.T) {
+	strategies, err := Parse([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, probabilistic(DefaultSamplingProbability, nil), strategies.Get("any"))
+	assert.Empty(t, strategies.Services())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "not JSON",
+			data: `{"service_strategies": [`,
+			err:  "invalid strategies: unexpected EOF",
+		},
+		{
+			name: "misspelt member",
+			data: `{"service_strategies": [{"service": "a", "operation_strategy": []}]}`,
+			err:  `invalid strategies: json: unknown field "operation_strategy"`,
+		},
+		{
+			name: "two documents",
+			data: `{} {}`,
+			err:  "invalid strategies: data after the document",
+		},
+		{
+			name: "default without a type",
+			data: `{"default_strategy": {"operation_strategies": []}}`,
+			err:  "default_strategy: type is required",
+		},
+		{
+			name: "default naming a service",
+			data: `{"default_strategy": {"service": "a", "type": "probabilistic", "param": 1}}`,
+			err:  `default_strategy: service "a" is given, but the default strategy is every service's`,
+		},
+		{
+			name: "unknown type",
+			data: `{"service_strategies": [{"service": "a", "type": "const", "param": 1}]}`,
+			err:  `service_strategies[0]: unknown type "const", want "probabilistic" or "ratelimiting"`,
+		},
+		{
+			name: "type without param",
+			data: `{"service_strategies": [{"service": "a", "type": "probabilistic"}]}`,
+			err:  "service_strategies[0]: param is required",
+		},
+		{
+			name: "param without type",
+			data: `{"service_strategies": [{"service": "a", "param": 0.5}]}`,
+			err:  "service_strategies[0]: param is given without a type",
+		},
+		{
+			name: "negative probability",
+			data: `{"service_strategies": [{"service": "a", "type": "probabilistic", "param": -0.1}]}`,
+			err:  "service_strategies[0]: probability -0.1 is not in [0, 1]",
+		},
+		{
+			name: "negative rate limit",
+			data: `{"service_strategies": [{"service": "a", "type": "ratelimiting", "param": -1}]}`,
+			err:  "service_strategies[0]: rate limit -1 is not a whole number of traces per second from 0 to 2147483647",
+		},
+		{
+			name: "fractional rate limit",
+			data: `{"service_strategies": [{"service": "a", "type": "ratelimiting", "param": 2.5}]}`,
+			err:  "service_strategies[0]: rate limit 2.5 is not a whole number of traces per second from 0 to 2147483647",
+		},
+		{
+			name: "negative bound",
+			data: `{"service_strategies": [{"service": "a", "default_lower_bound_traces_per_second": -1}]}`,
+			err:  "service_strategies[0]: default_lower_bound_traces_per_second -1 is not a non-negative number",
+		},
+		{
+			name: "upper bound below lower bound",
+			data: `{"service_strategies": [{"service": "a", "default_lower_bound_traces_per_second": 2, "default_upper_bound_traces_per_second": 1}]}`,
+			err:  "service_strategies[0]: upper bound 1 traces per second is below the lower bound 2",
+		},
+		{
+			name: "upper bound below the inherited lower bound",
+			data: `{
+				"default_strategy": {"type": "probabilistic", "param": 1, "default_lower_bound_traces_per_second": 2},
+				"service_strategies": [{"service": "a", "default_upper_bound_traces_per_second": 1}]
+			}`,
+			err: "service_strategies[0]: upper bound 1 traces per second is below the lower bound 2",
+		},
+		{
+			name: "service without a name",
+			data: `{"service_strategies": [{"type": "probabilistic", "param": 1}]}`,
+			err:  "service_strategies[0]: service is required",
+		},
+		{
+			name: "null service",
+			data: `{"service_strategies": [null]}`,
+			err:  "service_strategies[0]: service strategy is null",
+		},
+		{
+			name: "service twice",
+			data: `{"service_strategies": [{"service": "a"}, {"service": "a"}]}`,
+			err:  `service_strategies[1]: service "a" has more than one strategy`,
+		},
+		{
+			name: "operation without a name",
+			data: `{"service_strategies": [{"service": "a", "operation_strategies": [{"type": "probabilistic", "param": 1}]}]}`,
+			err:  "service_strategies[0].operation_strategies[0]: operation is required",
+		},
+		{
+			name: "operation twice",
+			data: `{"service_strategies": [{"service": "a", "operation_strategies": [
+				{"operation": "x", "type": "probabilistic", "param": 1},
+				{"operation": "x", "type": "probabilistic", "param": 0}
+			]}]}`,
+			err: `service_strategies[0].operation_strategies[1]: operation "x" has more than one strategy`,
+		},
+		{
+			name: "rate limited operation",
+			data: `{"default_strategy": {"type": "probabilistic", "param": 1, "operation_strategies": [{"operation": "x", "type": "ratelimiting", "param": 1}]}}`,
+			err:  `default_strategy.operation_strategies[0]: type "ratelimiting" is not "probabilistic", the only type an operation strategy has`,
+		},
+		{
+			name: "operation probability",
+			data: `{"service_strategies": [{"service": "a", "operation_strategies": [{"operation": "x", "type": "probabilistic", "param": 1.5}]}]}`,
+			err:  "service_strategies[0].operation_strategies[0]: probability 1.5 is not in [0, 1]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.data))
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+// TestInheritance covers how a service's strategy combines with a default that limits its rate,
+// and a rate limited service with one that samples by probability.
+func TestInheritance(t *testing.T) {
+	strategies, err := Parse([]byte(`{
+		"default_strategy": {
+			"type": "ratelimiting",
+			"param": 10,
+			"operation_strategies": [{"operation": "/health", "type": "probabilistic", "param": 0}]
+		},
+		"service_strategies": [
+			{"service": "inherits"},
+			{"service": "probabilistic", "type": "probabilistic", "param": 0.3},
+			{
+				"service": "ratelimited",
+				"type": "ratelimiting",
+				"param": 1,
+				"operation_strategies": [{"operation": "/login", "type": "probabilistic", "param": 1}]
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 10},
+	}, strategies.Get("inherits"), "a rate limit keeps its own sampling, without the default's operations")
+
+	assert.Equal(t, probabilistic(0.3, &api_v2.PerOperationSamplingStrategies{
+		DefaultSamplingProbability: 0.3,
+		PerOperationStrategies:     []*api_v2.OperationSamplingStrategy{operation("/health", 0)},
+	}), strategies.Get("probabilistic"))
+
+	assert.Equal(t, &api_v2.SamplingStrategyResponse{
+		StrategyType:         api_v2.SamplingStrategyType_RATE_LIMITING,
+		RateLimitingSampling: &api_v2.RateLimitingSamplingStrategy{MaxTracesPerSecond: 1},
+		OperationSampling: &api_v2.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: DefaultSamplingProbability,
+			PerOperationStrategies:     []*api_v2.OperationSamplingStrategy{operation("/login", 1), operation("/health", 0)},
+		},
+	}, strategies.Get("ratelimited"), "neither the service nor the default gives a probability")
+}
+
+func TestGet_Copy(t *testing.T) {
+	strategies, err := Load("testdata/strategies.json")
+	require.NoError(t, err)
+	strategies.Get("frontend").OperationSampling.PerOperationStrategies[0].Operation = "changed"
+	strategies.Default().ProbabilisticSampling.SamplingRate = 0
+	assert.Equal(t, "/metrics", strategies.Get("frontend").OperationSampling.PerOperationStrategies[0].Operation)
+	assert.Equal(t, 0.5, strategies.Get("unnamed").ProbabilisticSampling.SamplingRate)
+}
+
+func TestNew_KeepsNothing(t *testing.T) {
+	param := 0.5
+	file := &File{ServiceStrategies: []*ServiceStrategy{{Service: "a", Type: TypeProbabilistic, Param: &param}}}
+	strategies, err := New(file)
+	require.NoError(t, err)
+	param = 1
+	assert.Equal(t, 0.5, strategies.Get("a").ProbabilisticSampling.SamplingRate)
+}
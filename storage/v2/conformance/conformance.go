@@ -0,0 +1,82 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conformance tests that a storage backend honors the contract of the storage v2 gRPC API
+// (jaeger.storage.v2). An implementer runs it from a test of their own, against their backend:
+//
+//	func TestConformance(t *testing.T) {
+//		conn := ... // a connection to the backend under test
+//		conformance.Run(t, conformance.Backend{
+//			Reader:       storage.NewTraceReaderClient(conn),
+//			Capabilities: storage.NewCapabilitiesClient(conn),
+//			Write:        writeThroughOTLP,
+//		})
+//	}
+//
+// Run writes a small fixture of traces and then asks the backend about it: GetTraces and its time
+// range, GetServices, GetOperations and its span kind, every legacy predicate of a search, every
+// operator and every level of the structured filter, the time range FindTraceIDs reports and the
+// counts FindTraceSummaries does.
+//
+// What the contract leaves to a backend is not held against it. Results are compared as sets,
+// since a search has no guaranteed order, and a trace the fixture did not write is ignored, so the
+// backend may hold other data. A check that needs a capability the backend does not report from
+// GetCapabilities is skipped, as is every check of FindTraceSummaries when the backend answers it
+// with Unimplemented, which the contract allows. A filter the capability gates admitted may still
+// be refused, with InvalidArgument, and that is a skip too: the contract owes such a filter a
+// refusal rather than an answer, and a refusal is what it got.
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+// Backend is the storage backend under test.
+type Backend struct {
+	// Reader is a client of the backend's TraceReader service.
+	Reader storage.TraceReaderClient
+	// Capabilities is a client of the backend's Capabilities service. A backend that does not serve
+	// one may leave it nil, and is taken to report the least capable SearchCapabilities there is,
+	// which skips every check of the structured filter.
+	Capabilities storage.CapabilitiesClient
+	// Write writes traces to the backend, however it takes them; usually the OTLP TraceService
+	// that the TraceReader documentation asks a backend to serve alongside it. It returns once what
+	// it wrote can be read back, so a backend that indexes in the background waits for it here.
+	Write func(ctx context.Context, data *tracev1.TracesData) error
+}
+
+// suite is one run against one backend.
+type suite struct {
+	backend      Backend
+	fixture      *fixture
+	capabilities *storage.SearchCapabilities
+}
+
+// Run writes the fixture to a backend and runs every check against it, each as a subtest.
+func Run(t *testing.T, backend Backend) {
+	require.NotNil(t, backend.Reader, "Backend.Reader is required")
+	require.NotNil(t, backend.Write, "Backend.Write is required")
+	s := &suite{backend: backend, fixture: newFixture(t)}
+	require.NoError(t, backend.Write(t.Context(), s.fixture.tracesData()), "writing the fixture")
+	s.capabilities = &storage.SearchCapabilities{}
+	if backend.Capabilities != nil {
+		resp, err := backend.Capabilities.GetCapabilities(t.Context(), &storage.GetCapabilitiesRequest{})
+		require.NoError(t, err, "GetCapabilities")
+		if resp.GetSearch() != nil {
+			s.capabilities = resp.GetSearch()
+		}
+	}
+	t.Run("GetTraces", s.testGetTraces)
+	t.Run("GetServices", s.testGetServices)
+	t.Run("GetOperations", s.testGetOperations)
+	t.Run("FindTraces", s.testFindTraces)
+	t.Run("Filter", s.testFilter)
+	t.Run("FindTraceIDs", s.testFindTraceIDs)
+	t.Run("FindTraceSummaries", s.testFindTraceSummaries)
+}
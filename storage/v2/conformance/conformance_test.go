@@ -0,0 +1,55 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/storage/v2/memory"
+)
+
+// serve serves the reference store over an in-process connection, which is how an implementer
+// runs the suite against their own backend.
+func serve(t *testing.T) (*memory.Store, *grpc.ClientConn) {
+	store := memory.NewStore()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	store.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return store, conn
+}
+
+func TestRun_MemoryStore(t *testing.T) {
+	store, conn := serve(t)
+	Run(t, Backend{
+		Reader:       storage.NewTraceReaderClient(conn),
+		Capabilities: storage.NewCapabilitiesClient(conn),
+		Write:        func(_ context.Context, data *tracev1.TracesData) error { return store.WriteTraces(data) },
+	})
+}
+
+func TestRun_WithoutCapabilities(t *testing.T) {
+	store, conn := serve(t)
+	Run(t, Backend{
+		Reader: storage.NewTraceReaderClient(conn),
+		Write:  func(_ context.Context, data *tracev1.TracesData) error { return store.WriteTraces(data) },
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// filterCase is a structured filter and the traces of the fixture it finds.
+type filterCase struct {
+	name     string
+	filter   *expression.Call
+	expected []string
+	sameSpan bool
+}
+
+// testFilter checks every operator of the structured filter and every level, each as RFC 0005
+// defines it, against a backend that reports both from GetCapabilities; a case using one it does
+// not report is skipped.
+func (s *suite) testFilter(t *testing.T) {
+	f := s.fixture
+	name := field(expression.LevelSpan, expression.SpanFieldName)
+	duration := field(expression.LevelSpan, expression.SpanFieldDuration)
+	kind := field(expression.LevelSpan, expression.SpanFieldKind)
+	service := field(expression.LevelResource, expression.ResourceFieldService)
+	statusCode := attr("", "http.response.status_code")
+	eventName := field(expression.LevelEvent, expression.EventFieldName)
+	exceptionType := attr(expression.LevelEvent, "exception.type")
+	tests := []filterCase{
+		{
+			name:     "eq",
+			filter:   call(expression.OpEq, name, untyped("GET /config")),
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "ne of a value some spans lack",
+			filter:   call(expression.OpNe, statusCode, untyped("200")),
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "gt",
+			filter:   call(expression.OpGt, duration, untyped("400ms")),
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "gte",
+			filter:   call(expression.OpGte, statusCode, untyped("404")),
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "lt",
+			filter:   call(expression.OpLt, duration, untyped("2ms")),
+			expected: []string{traceOrphan},
+		},
+		{
+			name:     "lte",
+			filter:   call(expression.OpLte, duration, untyped("1ms")),
+			expected: []string{traceOrphan},
+		},
+		{
+			name:     "regex",
+			filter:   call(expression.OpRegex, name, untyped(`/(dis|con)`)),
+			expected: []string{traceDispatch, traceConfig},
+		},
+		{
+			name:     "exists",
+			filter:   call(expression.OpExists, attr(expression.LevelSpan, "cached")),
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "in",
+			filter:   call(expression.OpIn, kind, &expression.List{Values: []string{"producer", "client"}}),
+			expected: []string{traceDispatch, traceOrphan},
+		},
+		{
+			name:     "not_in",
+			filter:   call(expression.OpNotIn, service, &expression.List{Values: []string{f.frontend, f.driver}}),
+			expected: []string{traceOrphan},
+		},
+		{
+			name:     "and",
+			filter:   call(expression.OpAnd, call(expression.OpEq, service, untyped(f.frontend)), call(expression.OpEq, statusCode, untyped("404"))),
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "and across spans",
+			filter:   call(expression.OpAnd, call(expression.OpEq, service, untyped(f.frontend)), call(expression.OpEq, kind, untyped("client"))),
+			sameSpan: true,
+		},
+		{
+			name:     "or",
+			filter:   call(expression.OpOr, call(expression.OpEq, name, untyped("GET")), call(expression.OpEq, name, untyped("GET /config"))),
+			expected: []string{traceConfig, traceOrphan},
+		},
+		{
+			name:     "not",
+			filter:   call(expression.OpNot, call(expression.OpEq, kind, untyped("server"))),
+			expected: []string{traceDispatch, traceOrphan},
+		},
+		{
+			name: "some",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpAnd, call(expression.OpEq, eventName, untyped("exception")), call(expression.OpEq, exceptionType, untyped("timeout")))),
+			expected: []string{traceDispatch},
+		},
+		{
+			name: "some holds of one event",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpAnd, call(expression.OpEq, eventName, untyped("retry")), call(expression.OpExists, exceptionType))),
+		},
+		{
+			name:     "span level",
+			filter:   call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldStatus), untyped("error")),
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "resource level",
+			filter:   call(expression.OpEq, attr(expression.LevelResource, "deployment.environment"), untyped("staging")),
+			expected: []string{traceOrphan},
+		},
+		{
+			name: "scope level",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, field(expression.LevelScope, expression.ScopeFieldName), untyped(fixtureScope)),
+				call(expression.OpEq, field(expression.LevelScope, expression.ScopeFieldVersion), untyped(fixtureVersion))),
+			expected: []string{traceDispatch, traceConfig, traceOrphan},
+		},
+		{
+			name:     "event level",
+			filter:   call(expression.OpEq, eventName, untyped("retry")),
+			expected: []string{traceDispatch},
+		},
+		{
+			name: "link level",
+			filter: call(expression.OpAnd,
+				call(expression.OpEq, field(expression.LevelLink, expression.LinkFieldTraceID), untyped(hex.EncodeToString(f.traceIDs[traceOrphan]))),
+				call(expression.OpEq, attr(expression.LevelLink, "link.reason"), untyped("cache"))),
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "unqualified attribute of the span",
+			filter:   call(expression.OpEq, attr("", "http.request.method"), untyped("GET")),
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "unqualified attribute of the resource",
+			filter:   call(expression.OpEq, attr("", "deployment.environment"), untyped("staging")),
+			expected: []string{traceOrphan},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if reason, ok := s.admits(test.filter); !ok {
+				t.Skip(reason)
+			}
+			filter := filterMessage(t, test.filter)
+			s.skipUnsupported(t, searchCase{query: &storage.TraceQueryParameters{Filter: filter}, sameSpan: test.sameSpan})
+			s.search(t, searchCase{query: &storage.TraceQueryParameters{Filter: filter}, expected: test.expected}, true)
+		})
+	}
+}
+
+// admits reports whether a filter passes the gates the backend's FilterCapabilities set, and
+// says which one stops it when it does not.
+func (s *suite) admits(filter *expression.Call) (string, bool) {
+	capabilities := s.capabilities.GetFilter()
+	if len(capabilities.GetOperators()) == 0 {
+		return "the backend reports no structured filter support", false
+	}
+	var reason string
+	var walk func(expression.Expression) bool
+	walk = func(e expression.Expression) bool {
+		var level expression.Level
+		switch e := e.(type) {
+		case *expression.Call:
+			if !slices.Contains(capabilities.GetOperators(), string(e.Op)) {
+				reason = fmt.Sprintf("the backend does not report operator %q", e.Op)
+				return false
+			}
+			return !slices.ContainsFunc(e.Args, func(arg expression.Expression) bool { return !walk(arg) })
+		case *expression.AttributeRef:
+			level = e.Level
+		case *expression.FieldRef:
+			level = e.Level
+		case *expression.NestedRef:
+			level = e.Level
+		}
+		if level != "" && !slices.Contains(capabilities.GetLevels(), string(level)) {
+			reason = fmt.Sprintf("the backend does not report level %q", level)
+			return false
+		}
+		return true
+	}
+	return reason, walk(filter)
+}
+
+// filterMessage turns a filter into the message TraceQueryParameters carries it in, by way of the
+// proto3 JSON form both speak.
+func filterMessage(t *testing.T, filter *expression.Call) *expressionpb.Call {
+	data, err := filter.MarshalJSON()
+	require.NoError(t, err)
+	message := &expressionpb.Call{}
+	require.NoError(t, protojson.Unmarshal(data, message))
+	return message
+}
+
+func call(op expression.Operator, args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: op, Args: args}
+}
+
+func field(level expression.Level, name string) *expression.FieldRef {
+	return &expression.FieldRef{Level: level, Name: name}
+}
+
+func attr(level expression.Level, key string) *expression.AttributeRef {
+	return &expression.AttributeRef{Level: level, Key: key}
+}
+
+func untyped(value string) *expression.AnyValue {
+	return &expression.AnyValue{Value: value}
+}
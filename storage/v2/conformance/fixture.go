@@ -0,0 +1,166 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// The traces of the fixture, by the names a check expects them by:
+//
+//   - dispatch, at base: frontend's server span GET /dispatch, which calls driver's client span
+//     FindDriverIDs, which failed after a retry event and an exception event, links to the orphan
+//     trace, and has an internal child redis.Lookup.
+//   - config, a second later: frontend's server span GET /config, alone, answered with a 404.
+//   - orphan, two seconds later: redis's client span GET, whose parent was never written, in the
+//     staging environment where the others are in prod.
+const (
+	traceDispatch = "dispatch"
+	traceConfig   = "config"
+	traceOrphan   = "orphan"
+)
+
+// fixtureScope is the instrumentation scope every span of the fixture is written under.
+const (
+	fixtureScope   = "conformance"
+	fixtureVersion = "1.0.0"
+)
+
+// fixture is the traces a run writes. Its IDs and service names are random, so that neither
+// another run against the same backend nor data it already holds can answer for them, and it
+// lies half an hour in the past, within any retention.
+type fixture struct {
+	base                    time.Time
+	frontend, driver, redis string
+	traceIDs                map[string][]byte
+	names                   map[string]string
+	orphanSpanID, missingID []byte
+	data                    *tracev1.TracesData
+}
+
+func newFixture(t *testing.T) *fixture {
+	run := hex.EncodeToString(randomID(t, 4))
+	f := &fixture{
+		base:     time.Now().UTC().Add(-30 * time.Minute).Truncate(time.Millisecond),
+		frontend: "conformance-" + run + "-frontend",
+		driver:   "conformance-" + run + "-driver",
+		redis:    "conformance-" + run + "-redis",
+		traceIDs: map[string][]byte{},
+		names:    map[string]string{},
+	}
+	for _, name := range []string{traceDispatch, traceConfig, traceOrphan} {
+		id := randomID(t, 16)
+		f.traceIDs[name] = id
+		f.names[string(id)] = name
+	}
+	f.orphanSpanID, f.missingID = randomID(t, 8), randomID(t, 8)
+	dispatchRoot, driverSpan := randomID(t, 8), randomID(t, 8)
+
+	dispatch, config, orphan := f.traceIDs[traceDispatch], f.traceIDs[traceConfig], f.traceIDs[traceOrphan]
+	frontendSpans := []*tracev1.Span{
+		f.span(dispatch, dispatchRoot, nil, "GET /dispatch", tracev1.Span_SPAN_KIND_SERVER, 0, 500*time.Millisecond,
+			&tracev1.Status{Code: tracev1.Status_STATUS_CODE_OK},
+			intAttribute("http.response.status_code", 200), stringAttribute("http.request.method", "GET")),
+		f.span(config, randomID(t, 8), nil, "GET /config", tracev1.Span_SPAN_KIND_SERVER, time.Second, 10*time.Millisecond, nil,
+			intAttribute("http.response.status_code", 404)),
+	}
+	findDriverIDs := f.span(dispatch, driverSpan, dispatchRoot, "FindDriverIDs", tracev1.Span_SPAN_KIND_CLIENT, 10*time.Millisecond, 100*time.Millisecond,
+		&tracev1.Status{Code: tracev1.Status_STATUS_CODE_ERROR, Message: "timeout"},
+		stringAttribute("param.location", "728,326"),
+		&commonv1.KeyValue{Key: "retries", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: 1.5}}},
+		&commonv1.KeyValue{Key: "cached", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: true}}})
+	findDriverIDs.Events = []*tracev1.Span_Event{
+		{Name: "retry", TimeUnixNano: f.at(20 * time.Millisecond)},
+		{
+			Name: "exception", TimeUnixNano: f.at(100 * time.Millisecond),
+			Attributes: []*commonv1.KeyValue{stringAttribute("exception.type", "timeout")},
+		},
+	}
+	findDriverIDs.Links = []*tracev1.Span_Link{{
+		TraceId: orphan, SpanId: f.orphanSpanID,
+		Attributes: []*commonv1.KeyValue{stringAttribute("link.reason", "cache")},
+	}}
+	driverSpans := []*tracev1.Span{
+		findDriverIDs,
+		f.span(dispatch, randomID(t, 8), driverSpan, "redis.Lookup", tracev1.Span_SPAN_KIND_INTERNAL, 15*time.Millisecond, 5*time.Millisecond, nil),
+	}
+	redisSpans := []*tracev1.Span{
+		f.span(orphan, f.orphanSpanID, f.missingID, "GET", tracev1.Span_SPAN_KIND_CLIENT, 2*time.Second, time.Millisecond, nil),
+	}
+	f.data = &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{
+		resourceSpans(f.frontend, "prod", frontendSpans),
+		resourceSpans(f.driver, "prod", driverSpans),
+		resourceSpans(f.redis, "staging", redisSpans),
+	}}
+	return f
+}
+
+// tracesData returns the fixture as one TracesData, a copy for the backend to keep.
+func (f *fixture) tracesData() *tracev1.TracesData {
+	return proto.Clone(f.data).(*tracev1.TracesData)
+}
+
+// at is a moment of the fixture as OTLP writes it.
+func (f *fixture) at(offset time.Duration) uint64 {
+	return uint64(f.base.Add(offset).UnixNano())
+}
+
+// name returns the name of a trace of the fixture, or "" for a trace the backend found elsewhere.
+func (f *fixture) name(traceID []byte) string {
+	return f.names[string(traceID)]
+}
+
+func (f *fixture) span(traceID, spanID, parentID []byte, name string, kind tracev1.Span_SpanKind, start, duration time.Duration, status *tracev1.Status, attributes ...*commonv1.KeyValue) *tracev1.Span {
+	return &tracev1.Span{
+		TraceId:           traceID,
+		SpanId:            spanID,
+		ParentSpanId:      parentID,
+		Name:              name,
+		Kind:              kind,
+		StartTimeUnixNano: f.at(start),
+		EndTimeUnixNano:   f.at(start + duration),
+		Status:            status,
+		Attributes:        attributes,
+	}
+}
+
+func resourceSpans(service, environment string, spans []*tracev1.Span) *tracev1.ResourceSpans {
+	return &tracev1.ResourceSpans{
+		Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{
+			stringAttribute("service.name", service),
+			stringAttribute("deployment.environment", environment),
+		}},
+		ScopeSpans: []*tracev1.ScopeSpans{{
+			Scope: &commonv1.InstrumentationScope{Name: fixtureScope, Version: fixtureVersion},
+			Spans: spans,
+		}},
+	}
+}
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+func intAttribute(key string, value int64) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: value}}}
+}
+
+func randomID(t *testing.T, size int) []byte {
+	id := make([]byte, size)
+	_, err := rand.Read(id)
+	if err != nil {
+		t.Fatalf("reading random bytes: %v", err)
+	}
+	// An ID of zeros is no ID at all, so one byte of it is never zero.
+	id[0] |= 1
+	return id
+}
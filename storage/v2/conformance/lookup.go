@@ -0,0 +1,139 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+// testGetTraces checks that GetTraces returns every trace it is asked for, whole and under the
+// resource it was written with, ignores one it does not hold, and finds a trace within a time
+// range holding it. A range that does not hold the trace is not checked: the range only says where
+// to look, and a backend may look elsewhere too.
+func (s *suite) testGetTraces(t *testing.T) {
+	f := s.fixture
+	tests := []struct {
+		name     string
+		query    []*storage.GetTraceParams
+		expected []string
+	}{
+		{
+			name:     "one trace",
+			query:    []*storage.GetTraceParams{{TraceId: f.traceIDs[traceDispatch]}},
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "several traces",
+			query:    []*storage.GetTraceParams{{TraceId: f.traceIDs[traceConfig]}, {TraceId: f.traceIDs[traceOrphan]}},
+			expected: []string{traceConfig, traceOrphan},
+		},
+		{
+			name:     "unknown trace ignored",
+			query:    []*storage.GetTraceParams{{TraceId: randomID(t, 16)}, {TraceId: f.traceIDs[traceConfig]}},
+			expected: []string{traceConfig},
+		},
+		{
+			name:  "only unknown traces",
+			query: []*storage.GetTraceParams{{TraceId: randomID(t, 16)}},
+		},
+		{
+			name: "time range holding the trace",
+			query: []*storage.GetTraceParams{{
+				TraceId:   f.traceIDs[traceDispatch],
+				StartTime: timestamppb.New(f.base.Add(-time.Second)),
+				EndTime:   timestamppb.New(f.base.Add(time.Second)),
+			}},
+			expected: []string{traceDispatch},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := s.backend.Reader.GetTraces(t.Context(), &storage.GetTracesRequest{Query: test.query})
+			require.NoError(t, err)
+			traces, err := s.readTraces(t, stream)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, names(traces))
+		})
+	}
+
+	t.Run("whole trace", func(t *testing.T) {
+		stream, err := s.backend.Reader.GetTraces(t.Context(), &storage.GetTracesRequest{
+			Query: []*storage.GetTraceParams{{TraceId: f.traceIDs[traceDispatch]}},
+		})
+		require.NoError(t, err)
+		traces, err := s.readTraces(t, stream)
+		require.NoError(t, err)
+		got := map[string]string{}
+		for _, span := range traces[traceDispatch] {
+			got[span.name] = span.service
+		}
+		assert.Equal(t, map[string]string{
+			"GET /dispatch": f.frontend,
+			"FindDriverIDs": f.driver,
+			"redis.Lookup":  f.driver,
+		}, got, "span names, each with the service it was written under")
+	})
+}
+
+// testGetServices checks that GetServices names every service of the fixture. The backend may
+// name others.
+func (s *suite) testGetServices(t *testing.T) {
+	resp, err := s.backend.Reader.GetServices(t.Context(), &storage.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Subset(t, resp.GetServices(), []string{s.fixture.frontend, s.fixture.driver, s.fixture.redis})
+}
+
+// testGetOperations checks the operations of each service, with and without a span kind. A
+// kind is spelled the way OTLP's enum is, in lower case and without its prefix.
+func (s *suite) testGetOperations(t *testing.T) {
+	f := s.fixture
+	type operation struct{ name, kind string }
+	tests := []struct {
+		name     string
+		request  *storage.GetOperationsRequest
+		expected []operation
+	}{
+		{
+			name:     "every kind",
+			request:  &storage.GetOperationsRequest{Service: f.driver},
+			expected: []operation{{"FindDriverIDs", "client"}, {"redis.Lookup", "internal"}},
+		},
+		{
+			name:     "one operation twice",
+			request:  &storage.GetOperationsRequest{Service: f.frontend},
+			expected: []operation{{"GET /dispatch", "server"}, {"GET /config", "server"}},
+		},
+		{
+			name:     "span kind",
+			request:  &storage.GetOperationsRequest{Service: f.driver, SpanKind: "client"},
+			expected: []operation{{"FindDriverIDs", "client"}},
+		},
+		{
+			name:    "span kind the service has none of",
+			request: &storage.GetOperationsRequest{Service: f.frontend, SpanKind: "consumer"},
+		},
+		{
+			name:    "unknown service",
+			request: &storage.GetOperationsRequest{Service: f.frontend + "-unknown"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := s.backend.Reader.GetOperations(t.Context(), test.request)
+			require.NoError(t, err)
+			var got []operation
+			for _, o := range resp.GetOperations() {
+				got = append(got, operation{o.GetName(), o.GetSpanKind()})
+			}
+			assert.ElementsMatch(t, test.expected, got)
+		})
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+// searchDepth is the depth of every search, deep enough that the fixture is found even among
+// whatever else the backend holds at the same time.
+const searchDepth = 1000
+
+// foundSpan is a span a backend returned, with the service it was returned under.
+type foundSpan struct {
+	name, service string
+	span          *tracev1.Span
+}
+
+// readTraces reads a stream of TracesData to its end, and returns the spans of each fixture trace
+// it held, by the trace's name. Spans of other traces are dropped.
+//
+// It holds the stream to the chunking rules GetTraces and FindTraces share: no chunk is empty or
+// holds spans of two traces, and the chunks of one trace are consecutive.
+func (s *suite) readTraces(t *testing.T, stream grpc.ServerStreamingClient[tracev1.TracesData]) (map[string][]foundSpan, error) {
+	traces := map[string][]foundSpan{}
+	var finished [][]byte
+	var current []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return traces, nil
+		}
+		if err != nil {
+			return traces, err
+		}
+		var chunkTrace []byte
+		for _, rs := range chunk.GetResourceSpans() {
+			service := ""
+			for _, kv := range rs.GetResource().GetAttributes() {
+				if kv.GetKey() == "service.name" {
+					service = kv.GetValue().GetStringValue()
+				}
+			}
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					if chunkTrace == nil {
+						chunkTrace = span.GetTraceId()
+					}
+					assert.Equal(t, chunkTrace, span.GetTraceId(), "a chunk holds spans of more than one trace")
+					if name := s.fixture.name(span.GetTraceId()); name != "" {
+						traces[name] = append(traces[name], foundSpan{name: span.GetName(), service: service, span: span})
+					}
+				}
+			}
+		}
+		if !assert.NotNil(t, chunkTrace, "a chunk holds no span") {
+			continue
+		}
+		if string(chunkTrace) != string(current) {
+			assert.NotContains(t, finished, chunkTrace, "the chunks of a trace are not consecutive")
+			if current != nil {
+				finished = append(finished, current)
+			}
+			current = chunkTrace
+		}
+	}
+}
+
+// names returns the names of the traces found, sorted.
+func names[V any](traces map[string]V) []string {
+	var found []string
+	for name := range traces {
+		found = append(found, name)
+	}
+	slices.Sort(found)
+	return found
+}
+
+// searchCase is a search and the traces of the fixture it finds.
+type searchCase struct {
+	name     string
+	query    *storage.TraceQueryParameters
+	expected []string
+	// sameSpan marks a search whose answer depends on matching a conjunction within one span.
+	sameSpan bool
+}
+
+// params completes a search with what every search of the suite carries: a time range bounding
+// the fixture, unless the search sets its own, and the search depth.
+func (s *suite) params(query *storage.TraceQueryParameters) *storage.TraceQueryParameters {
+	if query.StartTimeMin == nil && query.StartTimeMax == nil {
+		query.StartTimeMin = timestamppb.New(s.fixture.base.Add(-time.Minute))
+		query.StartTimeMax = timestamppb.New(s.fixture.base.Add(time.Minute))
+	}
+	query.SearchDepth = searchDepth
+	return query
+}
+
+// skipUnsupported skips a search that needs a capability the backend does not report.
+func (s *suite) skipUnsupported(t *testing.T, test searchCase) {
+	if test.query.GetServiceName() == "" && test.query.GetFilter() == nil && !s.capabilities.GetWithoutServiceName() {
+		t.Skip("the backend does not report without_service_name")
+	}
+	if test.sameSpan && !s.capabilities.GetSameSpanConjunction() {
+		t.Skip("the backend does not report same_span_conjunction")
+	}
+}
+
+// search runs a search through FindTraces and FindTraceIDs, and checks that both find the traces
+// expected. A backend that refuses the search is answered with a skip when refusing is allowed.
+func (s *suite) search(t *testing.T, test searchCase, refusable bool) {
+	query := s.params(test.query)
+	stream, err := s.backend.Reader.FindTraces(t.Context(), &storage.FindTracesRequest{Query: query})
+	var traces map[string][]foundSpan
+	if err == nil {
+		traces, err = s.readTraces(t, stream)
+	}
+	if refusable && isRefusal(err) {
+		t.Skipf("the backend refused the search: %v", err)
+	}
+	require.NoError(t, err)
+	assert.ElementsMatch(t, test.expected, names(traces), "FindTraces")
+
+	resp, err := s.backend.Reader.FindTraceIDs(t.Context(), &storage.FindTraceIDsRequest{Query: query})
+	require.NoError(t, err)
+	found := map[string]bool{}
+	for _, id := range resp.GetTraceIds() {
+		if name := s.fixture.name(id.GetTraceId()); name != "" {
+			found[name] = true
+		}
+	}
+	assert.ElementsMatch(t, test.expected, names(found), "FindTraceIDs")
+}
+
+func isRefusal(err error) bool {
+	code := status.Code(err)
+	return code == codes.InvalidArgument || code == codes.Unimplemented
+}
+
+// testFindTraces checks each of the legacy predicates, and the time range, which a search matches
+// against spans: a trace is found when one of its spans matches the whole query.
+func (s *suite) testFindTraces(t *testing.T) {
+	f := s.fixture
+	tests := []searchCase{
+		{
+			name:     "service",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend},
+			expected: []string{traceDispatch, traceConfig},
+		},
+		{
+			name:  "unknown service",
+			query: &storage.TraceQueryParameters{ServiceName: f.frontend + "-unknown"},
+		},
+		{
+			name:     "service and operation",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend, OperationName: "GET /config"},
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "operation of another service of the trace",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend, OperationName: "FindDriverIDs"},
+			sameSpan: true,
+		},
+		{
+			name:     "span attribute",
+			query:    &storage.TraceQueryParameters{ServiceName: f.driver, Attributes: []*storage.KeyValue{stringKeyValue("param.location", "728,326")}},
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "integer attribute as text",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend, Attributes: []*storage.KeyValue{stringKeyValue("http.response.status_code", "404")}},
+			expected: []string{traceConfig},
+		},
+		{
+			name:     "resource attribute",
+			query:    &storage.TraceQueryParameters{ServiceName: f.redis, Attributes: []*storage.KeyValue{stringKeyValue("deployment.environment", "staging")}},
+			expected: []string{traceOrphan},
+		},
+		{
+			name: "two attributes of one span",
+			query: &storage.TraceQueryParameters{ServiceName: f.driver, Attributes: []*storage.KeyValue{
+				stringKeyValue("param.location", "728,326"), stringKeyValue("cached", "true"),
+			}},
+			expected: []string{traceDispatch},
+		},
+		{
+			name: "two attributes of two spans",
+			query: &storage.TraceQueryParameters{Attributes: []*storage.KeyValue{
+				stringKeyValue("param.location", "728,326"), stringKeyValue("http.request.method", "GET"),
+			}},
+			sameSpan: true,
+		},
+		{
+			name:     "duration range",
+			query:    &storage.TraceQueryParameters{ServiceName: f.driver, DurationMin: durationpb.New(50 * time.Millisecond), DurationMax: durationpb.New(time.Second)},
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "duration_min inclusive",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend, DurationMin: durationpb.New(500 * time.Millisecond)},
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "duration_max inclusive",
+			query:    &storage.TraceQueryParameters{ServiceName: f.redis, DurationMax: durationpb.New(time.Millisecond)},
+			expected: []string{traceOrphan},
+		},
+		{
+			name:     "start_time_min inclusive",
+			query:    &storage.TraceQueryParameters{ServiceName: f.frontend, StartTimeMin: timestamppb.New(f.base.Add(time.Second)), StartTimeMax: timestamppb.New(f.base.Add(time.Minute))},
+			expected: []string{traceConfig},
+		},
+		{
+			name:  "start_time_max exclusive",
+			query: &storage.TraceQueryParameters{ServiceName: f.redis, StartTimeMin: timestamppb.New(f.base), StartTimeMax: timestamppb.New(f.base.Add(2 * time.Second))},
+		},
+		{
+			name:     "time range of a later span",
+			query:    &storage.TraceQueryParameters{ServiceName: f.driver, StartTimeMin: timestamppb.New(f.base.Add(12 * time.Millisecond)), StartTimeMax: timestamppb.New(f.base.Add(time.Second))},
+			expected: []string{traceDispatch},
+		},
+		{
+			name:     "any service",
+			query:    &storage.TraceQueryParameters{},
+			expected: []string{traceDispatch, traceConfig, traceOrphan},
+		},
+		{
+			name:     "duration of any service",
+			query:    &storage.TraceQueryParameters{DurationMin: durationpb.New(400 * time.Millisecond)},
+			expected: []string{traceDispatch},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s.skipUnsupported(t, test)
+			s.search(t, test, false)
+		})
+	}
+}
+
+// testFindTraceIDs checks the time range FindTraceIDs reports with a trace. It is only a hint,
+// and may be left out, but a range reported has to hold the whole trace, since it is meant to be
+// passed on to GetTraces; so GetTraces is asked with it, and has to find the trace.
+func (s *suite) testFindTraceIDs(t *testing.T) {
+	f := s.fixture
+	query := s.params(&storage.TraceQueryParameters{ServiceName: f.frontend})
+	resp, err := s.backend.Reader.FindTraceIDs(t.Context(), &storage.FindTraceIDsRequest{Query: query})
+	require.NoError(t, err)
+	ends := map[string][2]time.Time{
+		traceDispatch: {f.base, f.base.Add(500 * time.Millisecond)},
+		traceConfig:   {f.base.Add(time.Second), f.base.Add(time.Second + 10*time.Millisecond)},
+	}
+	found := map[string]bool{}
+	for _, id := range resp.GetTraceIds() {
+		name := s.fixture.name(id.GetTraceId())
+		if name == "" {
+			continue
+		}
+		found[name] = true
+		t.Run(name, func(t *testing.T) {
+			if id.GetStart() != nil {
+				assert.False(t, id.GetStart().AsTime().After(ends[name][0]), "start %v is after the trace starts", id.GetStart().AsTime())
+			}
+			if id.GetEnd() != nil {
+				assert.False(t, id.GetEnd().AsTime().Before(ends[name][1]), "end %v is before the trace ends", id.GetEnd().AsTime())
+			}
+			stream, err := s.backend.Reader.GetTraces(t.Context(), &storage.GetTracesRequest{Query: []*storage.GetTraceParams{{
+				TraceId: id.GetTraceId(), StartTime: id.GetStart(), EndTime: id.GetEnd(),
+			}}})
+			require.NoError(t, err)
+			traces, err := s.readTraces(t, stream)
+			require.NoError(t, err)
+			assert.Equal(t, []string{name}, names(traces), "GetTraces with the reported range")
+		})
+	}
+	assert.Equal(t, []string{traceConfig, traceDispatch}, names(found))
+}
+
+// testFindTraceSummaries checks what a summary counts: the root, which for a trace whose root was
+// never written is the earliest of its orphans, the spans, the errors and the orphans, each
+// service's, and when the trace began and ended. A time it leaves at zero is not known, which is
+// allowed.
+func (s *suite) testFindTraceSummaries(t *testing.T) {
+	f := s.fixture
+	expected := map[string]*storage.TraceSummary{
+		traceDispatch: {
+			RootServiceName: f.frontend, RootOperationName: "GET /dispatch",
+			MinStartTimeUnixNano: f.at(0), MaxEndTimeUnixNano: f.at(500 * time.Millisecond),
+			SpanCount: 3, ErrorSpanCount: 1,
+			Services: []*storage.ServiceSummary{{Name: f.frontend, SpanCount: 1}, {Name: f.driver, SpanCount: 2, ErrorSpanCount: 1}},
+		},
+		traceConfig: {
+			RootServiceName: f.frontend, RootOperationName: "GET /config",
+			MinStartTimeUnixNano: f.at(time.Second), MaxEndTimeUnixNano: f.at(time.Second + 10*time.Millisecond),
+			SpanCount: 1,
+			Services:  []*storage.ServiceSummary{{Name: f.frontend, SpanCount: 1}},
+		},
+		traceOrphan: {
+			RootServiceName: f.redis, RootOperationName: "GET",
+			MinStartTimeUnixNano: f.at(2 * time.Second), MaxEndTimeUnixNano: f.at(2*time.Second + time.Millisecond),
+			SpanCount: 1, OrphanSpanCount: 1,
+			Services: []*storage.ServiceSummary{{Name: f.redis, SpanCount: 1}},
+		},
+	}
+	summaries := map[string]*storage.TraceSummary{}
+	for _, service := range []string{f.frontend, f.redis} {
+		stream, err := s.backend.Reader.FindTraceSummaries(t.Context(), &storage.FindTraceSummariesRequest{
+			Query: s.params(&storage.TraceQueryParameters{ServiceName: service}),
+		})
+		for err == nil {
+			var chunk *storage.FindTraceSummariesResponse
+			if chunk, err = stream.Recv(); err == nil {
+				for _, summary := range chunk.GetSummaries() {
+					if name := f.name(summary.GetTraceId()); name != "" {
+						assert.NotContains(t, summaries, name, "a trace is summarized twice")
+						summaries[name] = summary
+					}
+				}
+			}
+		}
+		if status.Code(err) == codes.Unimplemented {
+			t.Skip("the backend does not implement FindTraceSummaries")
+		}
+		require.ErrorIs(t, err, io.EOF)
+	}
+	require.Equal(t, names(expected), names(summaries))
+	for name, want := range expected {
+		t.Run(name, func(t *testing.T) {
+			got := summaries[name]
+			assert.Equal(t, want.GetRootServiceName(), got.GetRootServiceName(), "root service")
+			assert.Equal(t, want.GetRootOperationName(), got.GetRootOperationName(), "root operation")
+			assert.Equal(t, want.GetSpanCount(), got.GetSpanCount(), "spans")
+			assert.Equal(t, want.GetErrorSpanCount(), got.GetErrorSpanCount(), "errors")
+			assert.Equal(t, want.GetOrphanSpanCount(), got.GetOrphanSpanCount(), "orphans")
+			if got.GetMinStartTimeUnixNano() != 0 {
+				assert.Equal(t, want.GetMinStartTimeUnixNano(), got.GetMinStartTimeUnixNano(), "start")
+			}
+			if got.GetMaxEndTimeUnixNano() != 0 {
+				assert.Equal(t, want.GetMaxEndTimeUnixNano(), got.GetMaxEndTimeUnixNano(), "end")
+			}
+			type service struct {
+				name          string
+				spans, errors int32
+			}
+			var wantServices, gotServices []service
+			for _, s := range want.GetServices() {
+				wantServices = append(wantServices, service{s.GetName(), s.GetSpanCount(), s.GetErrorSpanCount()})
+			}
+			for _, s := range got.GetServices() {
+				gotServices = append(gotServices, service{s.GetName(), s.GetSpanCount(), s.GetErrorSpanCount()})
+			}
+			assert.ElementsMatch(t, wantServices, gotServices, "services")
+		})
+	}
+}
+
+func stringKeyValue(key, value string) *storage.KeyValue {
+	return &storage.KeyValue{Key: key, Value: &storage.AnyValue{Value: &storage.AnyValue_StringValue{StringValue: value}}}
+}
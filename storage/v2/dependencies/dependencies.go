@@ -0,0 +1,291 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dependencies derives the links between services that DependencyReader.GetDependencies
+// returns (see the storage v2 API) from the traces that show one service calling another, for a
+// backend or an offline job that does not run the Spark job Jaeger derives them with. Traces are
+// read as OTLP TracesData or as v1 model.Trace, and may be added in any order and over any number
+// of calls, so a job can stream them from storage.
+//
+// A call from one service to another is seen in one of three ways, and counted once:
+//
+//   - A server span whose parent is a client span, or a consumer span whose parent is a producer
+//     span: the parent's service called the child's.
+//   - A consumer span with a link, or in the v1 model a reference other than its parent, to a
+//     producer span: the producer's service called the consumer's. The producer may be in another
+//     trace, as it usually is when a consumer starts a trace of its own.
+//   - A client or producer span that none of the above matched, with a peer.service attribute:
+//     its service called the peer, which is not instrumented, or whose spans were not added.
+//
+// A call is counted in the time window holding the start of the calling span. A span whose service
+// is not known, because its resource has no service.name, is the end of no link.
+package dependencies
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+// PeerServiceKey is the attribute that names the service a client or producer span called.
+const PeerServiceKey = "peer.service"
+
+// Window is the links derived for one window of time.
+type Window struct {
+	// Start is the start of the window, inclusive, and End its end, exclusive.
+	Start, End time.Time
+	// Dependencies are the links of the window, one for each pair of services, sorted by parent and
+	// then by child. Their source is "jaeger", as the links Jaeger derives are.
+	Dependencies []*storage.Dependency
+}
+
+// Aggregator derives links from the traces added to it, counted in windows of time. Which spans
+// match only shows once all of them have been added, so the links are derived when asked for: an
+// aggregator keeps what it needs of every client, server, producer and consumer span until then.
+type Aggregator struct {
+	window time.Duration
+	spans  map[spanKey]*span
+	// order holds the spans in the order they were added, which makes the result the same whatever
+	// order a map would iterate in.
+	order []*span
+}
+
+// spanKey identifies a span across traces.
+type spanKey struct {
+	trace model.TraceID
+	span  model.SpanID
+}
+
+// span is what deriving links reads of one span.
+type span struct {
+	key         spanKey
+	kind        model.SpanKind
+	service     string
+	peerService string
+	start       time.Time
+	parent      *spanKey
+	links       []spanKey
+}
+
+// NewAggregator returns an aggregator that counts links in windows of the given length, aligned to
+// it in UTC, so that a window of a day runs from one midnight to the next. A length that is not
+// positive puts every link in one window, whose start and end are zero.
+func NewAggregator(window time.Duration) *Aggregator {
+	return &Aggregator{window: window, spans: map[spanKey]*span{}}
+}
+
+// Derive returns the links the traces show, in one window over all time.
+func Derive(traces ...*tracev1.TracesData) []*storage.Dependency {
+	a := NewAggregator(0)
+	for _, data := range traces {
+		a.AddTraces(data)
+	}
+	windows := a.Windows()
+	if len(windows) == 0 {
+		return nil
+	}
+	return windows[0].Dependencies
+}
+
+// AddTraces adds the spans of OTLP TracesData, which may hold spans of any number of traces. A
+// span whose trace or span ID is not one is skipped.
+func (a *Aggregator) AddTraces(data *tracev1.TracesData) {
+	for _, rs := range data.GetResourceSpans() {
+		service := ""
+		for _, kv := range rs.GetResource().GetAttributes() {
+			if kv.GetKey() == "service.name" {
+				service = kv.GetValue().GetStringValue()
+			}
+		}
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				kind, ok := otlpKinds[s.GetKind()]
+				if !ok {
+					continue
+				}
+				key, ok := otlpKey(s.GetTraceId(), s.GetSpanId())
+				if !ok {
+					continue
+				}
+				record := &span{key: key, kind: kind, service: service, start: time.Unix(0, int64(s.GetStartTimeUnixNano())).UTC()}
+				if parent, ok := otlpKey(s.GetTraceId(), s.GetParentSpanId()); ok {
+					record.parent = &parent
+				}
+				for _, link := range s.GetLinks() {
+					if key, ok := otlpKey(link.GetTraceId(), link.GetSpanId()); ok {
+						record.links = append(record.links, key)
+					}
+				}
+				for _, kv := range s.GetAttributes() {
+					if kv.GetKey() == PeerServiceKey {
+						record.peerService = kv.GetValue().GetStringValue()
+					}
+				}
+				a.add(record)
+			}
+		}
+	}
+}
+
+// AddModelTrace adds the spans of a v1 model trace. A span's kind is its span.kind tag, its
+// service is that of its process, found in the trace's process map when the span does not carry
+// it, and its links are its references other than the one to its parent.
+func (a *Aggregator) AddModelTrace(trace *model.Trace) {
+	processes := map[string]*model.Process{}
+	for i := range trace.ProcessMap {
+		processes[trace.ProcessMap[i].ProcessID] = &trace.ProcessMap[i].Process
+	}
+	for _, s := range trace.Spans {
+		kind, ok := s.GetSpanKind()
+		if !ok || kind == model.SpanKindInternal {
+			continue
+		}
+		process := s.Process
+		if process == nil {
+			process = processes[s.ProcessID]
+		}
+		record := &span{
+			key:   spanKey{trace: s.TraceID, span: s.SpanID},
+			kind:  kind,
+			start: s.StartTime.UTC(),
+		}
+		if process != nil {
+			record.service = process.ServiceName
+		}
+		parent := s.ParentSpanID()
+		if parent != 0 {
+			record.parent = &spanKey{trace: s.TraceID, span: parent}
+		}
+		for _, ref := range s.References {
+			if ref.TraceID != s.TraceID || ref.SpanID != parent {
+				record.links = append(record.links, spanKey{trace: ref.TraceID, span: ref.SpanID})
+			}
+		}
+		if tag, ok := model.KeyValues(s.Tags).FindByKey(PeerServiceKey); ok {
+			record.peerService = tag.AsString()
+		}
+		a.add(record)
+	}
+}
+
+// add adds a span. A span added twice, as one written twice may be read back, is counted once.
+func (a *Aggregator) add(record *span) {
+	if _, ok := a.spans[record.key]; ok {
+		return
+	}
+	a.spans[record.key] = record
+	a.order = append(a.order, record)
+}
+
+// Windows derives the links of every span added so far, and returns the windows that hold one,
+// in order.
+func (a *Aggregator) Windows() []Window {
+	type edge struct {
+		start         time.Time
+		parent, child string
+	}
+	counts := map[edge]uint64{}
+	count := func(caller *span, callee string) {
+		if caller.service == "" || callee == "" {
+			return
+		}
+		start := time.Time{}
+		if a.window > 0 {
+			start = caller.start.Truncate(a.window)
+		}
+		counts[edge{start: start, parent: caller.service, child: callee}]++
+	}
+	matched := map[*span]bool{}
+	for _, callee := range a.order {
+		if callee.kind != model.SpanKindServer && callee.kind != model.SpanKindConsumer {
+			continue
+		}
+		var parent *span
+		if callee.parent != nil {
+			parent = a.spans[*callee.parent]
+		}
+		if parent != nil && calls(parent.kind, callee.kind) {
+			count(parent, callee.service)
+			matched[parent] = true
+		}
+		if callee.kind != model.SpanKindConsumer {
+			continue
+		}
+		for _, link := range callee.links {
+			producer := a.spans[link]
+			if producer == nil || producer == parent || producer.kind != model.SpanKindProducer {
+				continue
+			}
+			count(producer, callee.service)
+			matched[producer] = true
+		}
+	}
+	for _, caller := range a.order {
+		if (caller.kind == model.SpanKindClient || caller.kind == model.SpanKindProducer) && !matched[caller] {
+			count(caller, caller.peerService)
+		}
+	}
+
+	var windows []Window
+	byStart := map[time.Time]int{}
+	for e, n := range counts {
+		i, ok := byStart[e.start]
+		if !ok {
+			i = len(windows)
+			byStart[e.start] = i
+			window := Window{Start: e.start}
+			if a.window > 0 {
+				window.End = e.start.Add(a.window)
+			}
+			windows = append(windows, window)
+		}
+		windows[i].Dependencies = append(windows[i].Dependencies, &storage.Dependency{
+			Parent:    e.parent,
+			Child:     e.child,
+			CallCount: n,
+			Source:    model.JaegerDependencyLinkSource,
+		})
+	}
+	slices.SortFunc(windows, func(a, b Window) int { return a.Start.Compare(b.Start) })
+	for _, window := range windows {
+		slices.SortFunc(window.Dependencies, func(a, b *storage.Dependency) int {
+			if order := strings.Compare(a.GetParent(), b.GetParent()); order != 0 {
+				return order
+			}
+			return strings.Compare(a.GetChild(), b.GetChild())
+		})
+	}
+	return windows
+}
+
+// calls reports whether a span of one kind calls its child of another.
+func calls(parent, child model.SpanKind) bool {
+	return (parent == model.SpanKindClient && child == model.SpanKindServer) ||
+		(parent == model.SpanKindProducer && child == model.SpanKindConsumer)
+}
+
+// otlpKinds maps the OTLP span kinds that take part in a call to the v1 model's.
+var otlpKinds = map[tracev1.Span_SpanKind]model.SpanKind{
+	tracev1.Span_SPAN_KIND_CLIENT:   model.SpanKindClient,
+	tracev1.Span_SPAN_KIND_SERVER:   model.SpanKindServer,
+	tracev1.Span_SPAN_KIND_PRODUCER: model.SpanKindProducer,
+	tracev1.Span_SPAN_KIND_CONSUMER: model.SpanKindConsumer,
+}
+
+// otlpKey reads an OTLP trace and span ID. A missing span ID, empty or zeros, is not one.
+func otlpKey(traceID, spanID []byte) (spanKey, bool) {
+	trace, err := model.TraceIDFromBytes(traceID)
+	if err != nil || len(traceID) != 16 {
+		return spanKey{}, false
+	}
+	id, err := model.SpanIDFromBytes(spanID)
+	if err != nil || id == 0 {
+		return spanKey{}, false
+	}
+	return spanKey{trace: trace, span: id}, true
+}
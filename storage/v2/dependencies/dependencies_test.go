@@ -0,0 +1,231 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dependencies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	model "github.com/jaegertracing/jaeger-idl/model/v1"
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type testSpan struct {
+	trace, id, parent byte
+	kind              tracev1.Span_SpanKind
+	start             time.Duration
+	peer              string
+	link              [2]byte
+}
+
+func traceID(n byte) []byte {
+	id := make([]byte, 16)
+	id[15] = n
+	return id
+}
+
+func spanID(n byte) []byte {
+	if n == 0 {
+		return nil
+	}
+	return []byte{0, 0, 0, 0, 0, 0, 0, n}
+}
+
+// spans builds a TracesData of the spans one service wrote.
+func spans(service string, ss ...testSpan) *tracev1.TracesData {
+	scope := &tracev1.ScopeSpans{}
+	for _, s := range ss {
+		span := &tracev1.Span{
+			TraceId:           traceID(s.trace),
+			SpanId:            spanID(s.id),
+			ParentSpanId:      spanID(s.parent),
+			Kind:              s.kind,
+			StartTimeUnixNano: uint64(base.Add(s.start).UnixNano()),
+		}
+		if s.peer != "" {
+			span.Attributes = []*commonv1.KeyValue{{Key: PeerServiceKey, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: s.peer}}}}
+		}
+		if s.link != [2]byte{} {
+			span.Links = []*tracev1.Span_Link{{TraceId: traceID(s.link[0]), SpanId: spanID(s.link[1])}}
+		}
+		scope.Spans = append(scope.Spans, span)
+	}
+	resource := &resourcev1.Resource{}
+	if service != "" {
+		resource.Attributes = []*commonv1.KeyValue{{Key: "service.name", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: service}}}}
+	}
+	return &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{{Resource: resource, ScopeSpans: []*tracev1.ScopeSpans{scope}}}}
+}
+
+func link(parent, child string, count uint64) *storage.Dependency {
+	return &storage.Dependency{Parent: parent, Child: child, CallCount: count, Source: "jaeger"}
+}
+
+func assertLinks(t *testing.T, expected, actual []*storage.Dependency) {
+	t.Helper()
+	require.Len(t, actual, len(expected), "%v", actual)
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
+
+const (
+	client   = tracev1.Span_SPAN_KIND_CLIENT
+	server   = tracev1.Span_SPAN_KIND_SERVER
+	producer = tracev1.Span_SPAN_KIND_PRODUCER
+	consumer = tracev1.Span_SPAN_KIND_CONSUMER
+	internal = tracev1.Span_SPAN_KIND_INTERNAL
+)
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name     string
+		traces   []*tracev1.TracesData
+		expected []*storage.Dependency
+	}{
+		{
+			name: "client and server",
+			traces: []*tracev1.TracesData{
+				spans("frontend", testSpan{trace: 1, id: 1, kind: server}, testSpan{trace: 1, id: 2, parent: 1, kind: client}, testSpan{trace: 1, id: 4, parent: 1, kind: client}),
+				spans("driver", testSpan{trace: 1, id: 3, parent: 2, kind: server}, testSpan{trace: 1, id: 5, parent: 4, kind: server}),
+			},
+			expected: []*storage.Dependency{link("frontend", "driver", 2)},
+		},
+		{
+			name: "server under a span that is not a client",
+			traces: []*tracev1.TracesData{
+				spans("frontend", testSpan{trace: 1, id: 1, kind: internal}, testSpan{trace: 1, id: 2, parent: 1, kind: server}),
+			},
+		},
+		{
+			name: "producer and consumer as parent and child",
+			traces: []*tracev1.TracesData{
+				spans("orders", testSpan{trace: 1, id: 1, kind: producer}),
+				spans("billing", testSpan{trace: 1, id: 2, parent: 1, kind: consumer}),
+			},
+			expected: []*storage.Dependency{link("orders", "billing", 1)},
+		},
+		{
+			name: "consumer linking to a producer in another trace added later",
+			traces: []*tracev1.TracesData{
+				spans("billing", testSpan{trace: 2, id: 9, kind: consumer, link: [2]byte{1, 1}}),
+				spans("orders", testSpan{trace: 1, id: 1, kind: producer}),
+			},
+			expected: []*storage.Dependency{link("orders", "billing", 1)},
+		},
+		{
+			name: "consumer whose parent is also its link",
+			traces: []*tracev1.TracesData{
+				spans("orders", testSpan{trace: 1, id: 1, kind: producer}),
+				spans("billing", testSpan{trace: 1, id: 2, parent: 1, kind: consumer, link: [2]byte{1, 1}}),
+			},
+			expected: []*storage.Dependency{link("orders", "billing", 1)},
+		},
+		{
+			name: "peer.service of an unmatched client",
+			traces: []*tracev1.TracesData{
+				spans("driver", testSpan{trace: 1, id: 1, kind: client, peer: "redis"}, testSpan{trace: 1, id: 2, kind: client, peer: "redis"}),
+				spans("orders", testSpan{trace: 1, id: 3, kind: producer, peer: "kafka"}),
+			},
+			expected: []*storage.Dependency{link("driver", "redis", 2), link("orders", "kafka", 1)},
+		},
+		{
+			name: "peer.service of a matched client",
+			traces: []*tracev1.TracesData{
+				spans("frontend", testSpan{trace: 1, id: 1, kind: client, peer: "driver-alias"}),
+				spans("driver", testSpan{trace: 1, id: 2, parent: 1, kind: server}),
+			},
+			expected: []*storage.Dependency{link("frontend", "driver", 1)},
+		},
+		{
+			name: "span added twice",
+			traces: []*tracev1.TracesData{
+				spans("driver", testSpan{trace: 1, id: 1, kind: client, peer: "redis"}),
+				spans("driver", testSpan{trace: 1, id: 1, kind: client, peer: "redis"}),
+			},
+			expected: []*storage.Dependency{link("driver", "redis", 1)},
+		},
+		{
+			name: "unknown service",
+			traces: []*tracev1.TracesData{
+				spans("", testSpan{trace: 1, id: 1, kind: client, peer: "redis"}),
+				spans("frontend", testSpan{trace: 1, id: 2, kind: client}),
+				spans("", testSpan{trace: 1, id: 3, parent: 2, kind: server}),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertLinks(t, test.expected, Derive(test.traces...))
+		})
+	}
+}
+
+func TestAggregator_Windows(t *testing.T) {
+	a := NewAggregator(time.Hour)
+	a.AddTraces(spans("frontend",
+		testSpan{trace: 1, id: 1, kind: client, peer: "driver"},
+		testSpan{trace: 1, id: 2, kind: client, start: 59 * time.Minute, peer: "driver"},
+		testSpan{trace: 2, id: 1, kind: client, start: 61 * time.Minute, peer: "driver"},
+		testSpan{trace: 2, id: 2, kind: client, start: 90 * time.Minute, peer: "customer"},
+	))
+	windows := a.Windows()
+	require.Len(t, windows, 2)
+	assert.Equal(t, base, windows[0].Start)
+	assert.Equal(t, base.Add(time.Hour), windows[0].End)
+	assertLinks(t, []*storage.Dependency{link("frontend", "driver", 2)}, windows[0].Dependencies)
+	assert.Equal(t, base.Add(time.Hour), windows[1].Start)
+	assertLinks(t, []*storage.Dependency{link("frontend", "customer", 1), link("frontend", "driver", 1)}, windows[1].Dependencies)
+
+	assert.Empty(t, NewAggregator(time.Hour).Windows())
+}
+
+func TestAggregator_AddModelTrace(t *testing.T) {
+	traceID := model.NewTraceID(0, 1)
+	otherTrace := model.NewTraceID(0, 2)
+	kind := func(k model.SpanKind) []model.KeyValue { return []model.KeyValue{model.SpanKindTag(k)} }
+	trace := &model.Trace{
+		Spans: []*model.Span{
+			{TraceID: traceID, SpanID: 1, StartTime: base, Tags: kind(model.SpanKindClient), ProcessID: "p1"},
+			{
+				TraceID: traceID, SpanID: 2, StartTime: base, Tags: kind(model.SpanKindServer),
+				Process: model.NewProcess("driver", nil), References: []model.SpanRef{model.NewChildOfRef(traceID, 1)},
+			},
+			{
+				TraceID: traceID, SpanID: 3, StartTime: base, ProcessID: "p1",
+				Tags: append(kind(model.SpanKindClient), model.String(PeerServiceKey, "redis")),
+			},
+			{TraceID: traceID, SpanID: 4, StartTime: base, Tags: kind(model.SpanKindProducer), ProcessID: "p1"},
+			{
+				TraceID: otherTrace, SpanID: 5, StartTime: base, Tags: kind(model.SpanKindConsumer),
+				Process: model.NewProcess("billing", nil), References: []model.SpanRef{model.NewFollowsFromRef(traceID, 4)},
+			},
+			{
+				TraceID: traceID, SpanID: 6, StartTime: base, Tags: kind(model.SpanKindConsumer),
+				Process: model.NewProcess("audit", nil), References: []model.SpanRef{model.NewFollowsFromRef(traceID, 4)},
+			},
+		},
+		ProcessMap: []model.Trace_ProcessMapping{{ProcessID: "p1", Process: *model.NewProcess("frontend", nil)}},
+	}
+	a := NewAggregator(0)
+	a.AddModelTrace(trace)
+	windows := a.Windows()
+	require.Len(t, windows, 1)
+	assert.True(t, windows[0].Start.IsZero())
+	assertLinks(t, []*storage.Dependency{
+		link("frontend", "audit", 1),
+		link("frontend", "billing", 1),
+		link("frontend", "driver", 1),
+		link("frontend", "redis", 1),
+	}, windows[0].Dependencies)
+}
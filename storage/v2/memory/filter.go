@@ -0,0 +1,517 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// A filter is evaluated against one span at a time, and answers as RFC 0005 defines:
+//
+//   - A reference reads every value it names. An unqualified attribute names the span's and then
+//     the resource's, and a reference at the event or link level names that value of every event
+//     or link the span has, or of the one an enclosing `some` has bound. A predicate holds when it
+//     holds of one of those values, so `some` is what asks that several predicates hold of the same
+//     event.
+//   - A value that is missing satisfies no comparison, `ne` and `not_in` included; `not` is the only
+//     way to ask that something is absent. OTLP cannot tell an empty string or ID from a missing
+//     one, so neither is a value.
+//   - A typed constant matches values of its own type only, and a list values of the type it is
+//     read as. Ints and doubles are the one exception: validation puts them in one number domain,
+//     so either compares with the other by value. An untyped constant, which is what a constant compared against an attribute usually
+//     is, is read as whatever type the value it is compared with was stored as, and matches nothing
+//     it cannot be read as.
+//   - Booleans compare for equality only, and a regular expression matches text anywhere in it.
+//
+// A value the filter's vocabulary has no constant for, such as an array attribute, is there for
+// `exists` and for nothing else.
+
+// evaluator evaluates a finalized filter. It compiles each pattern once, so one is made per query.
+type evaluator struct {
+	patterns map[string]*regexp.Regexp
+}
+
+func newEvaluator() *evaluator {
+	return &evaluator{patterns: map[string]*regexp.Regexp{}}
+}
+
+// binding is the event or the link an enclosing `some` has bound, which a reference at that level
+// reads instead of the span's whole collection.
+type binding struct {
+	event *tracev1.Span_Event
+	link  *tracev1.Span_Link
+}
+
+// call reports whether a predicate holds of a span. The filter has been finalized, so every call
+// has the arguments its operator takes and every constant beside a built-in field is typed.
+func (e *evaluator) call(call *expression.Call, record *spanRecord, b binding) bool {
+	switch call.Op {
+	case expression.OpAnd:
+		for _, arg := range call.Args {
+			if !e.call(arg.(*expression.Call), record, b) {
+				return false
+			}
+		}
+		return true
+	case expression.OpOr:
+		for _, arg := range call.Args {
+			if e.call(arg.(*expression.Call), record, b) {
+				return true
+			}
+		}
+		return false
+	case expression.OpNot:
+		return !e.call(call.Args[0].(*expression.Call), record, b)
+	case expression.OpSome:
+		return e.some(call, record, b)
+	case expression.OpExists:
+		return len(values(call.Args[0], record, b)) > 0
+	case expression.OpRegex:
+		return e.regex(call, record, b)
+	case expression.OpIn, expression.OpNotIn:
+		return membership(call, record, b)
+	default:
+		for _, left := range values(call.Args[0], record, b) {
+			for _, right := range values(call.Args[1], record, b) {
+				if order, ok := compare(left, right); ok && holds(call.Op, order, left, right) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func (e *evaluator) some(call *expression.Call, record *spanRecord, b binding) bool {
+	predicate := call.Args[1].(*expression.Call)
+	switch call.Args[0].(*expression.NestedRef).Level {
+	case expression.LevelEvent:
+		for _, event := range record.span.GetEvents() {
+			if e.call(predicate, record, binding{event: event, link: b.link}) {
+				return true
+			}
+		}
+	case expression.LevelLink:
+		for _, link := range record.span.GetLinks() {
+			if e.call(predicate, record, binding{event: b.event, link: link}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *evaluator) regex(call *expression.Call, record *spanRecord, b binding) bool {
+	var pattern string
+	switch constant := call.Args[1].(type) {
+	case *expression.AnyValue:
+		pattern = constant.Value
+	case *expression.StringValue:
+		pattern = constant.Value
+	}
+	re, ok := e.patterns[pattern]
+	if !ok {
+		// Finalizing refused a pattern that does not compile, so this does not fail.
+		re, _ = regexp.Compile(pattern)
+		e.patterns[pattern] = re
+	}
+	for _, value := range values(call.Args[0], record, b) {
+		if text, ok := value.(*expression.StringValue); ok && re != nil && re.MatchString(text.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// membership answers in and not_in. not_in holds of a value of the list's type equal to none of its
+// elements, so a value of another type satisfies neither.
+func membership(call *expression.Call, record *spanRecord, b binding) bool {
+	list := call.Args[1].(*expression.List)
+	var fieldType expression.FieldType
+	if ref, ok := call.Args[0].(*expression.FieldRef); ok {
+		field, _ := expression.LookupField(ref.Level, ref.Name)
+		fieldType = field.Type
+	}
+	elements := make([]expression.Expression, 0, len(list.Values))
+	for _, text := range list.Values {
+		// Finalizing read every element already, so this does not fail.
+		if element, err := expression.ReadElement(list, fieldType, text); err == nil {
+			elements = append(elements, element)
+		}
+	}
+	for _, value := range values(call.Args[0], record, b) {
+		comparable, equal := false, false
+		for _, element := range elements {
+			order, ok := compare(value, element)
+			comparable = comparable || ok
+			equal = equal || (ok && order == 0)
+		}
+		if call.Op == expression.OpIn && equal {
+			return true
+		}
+		if call.Op == expression.OpNotIn && comparable && !equal {
+			return true
+		}
+	}
+	return false
+}
+
+// holds reports whether a comparison holds of two values in the given order.
+func holds(op expression.Operator, order int, left, right expression.Expression) bool {
+	switch op {
+	case expression.OpEq:
+		return order == 0
+	case expression.OpNe:
+		return order != 0
+	}
+	if isBool(left) || isBool(right) {
+		return false
+	}
+	switch op {
+	case expression.OpGt:
+		return order > 0
+	case expression.OpGte:
+		return order >= 0
+	case expression.OpLt:
+		return order < 0
+	case expression.OpLte:
+		return order <= 0
+	}
+	return false
+}
+
+func isBool(e expression.Expression) bool {
+	_, ok := e.(*expression.BoolValue)
+	return ok
+}
+
+// compare orders two values, and reports false when they cannot be compared: they are of two types
+// other than an int and a double, one is a value the vocabulary has no constant for, or one is NaN. An untyped constant is first
+// read as the other value's type. Two booleans that differ are ordered arbitrarily, which is all
+// eq and ne ask of them.
+func compare(a, b expression.Expression) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	x, untypedA := a.(*expression.AnyValue)
+	y, untypedB := b.(*expression.AnyValue)
+	switch {
+	case untypedA && untypedB:
+		return strings.Compare(x.Value, y.Value), true
+	case untypedA:
+		if a = readAs(b, x.Value); a == nil {
+			return 0, false
+		}
+	case untypedB:
+		if b = readAs(a, y.Value); b == nil {
+			return 0, false
+		}
+	}
+	switch x := a.(type) {
+	case *expression.StringValue:
+		if y, ok := b.(*expression.StringValue); ok {
+			return strings.Compare(x.Value, y.Value), true
+		}
+	case *expression.IntValue:
+		switch y := b.(type) {
+		case *expression.IntValue:
+			return cmp.Compare(x.Value, y.Value), true
+		case *expression.DoubleValue:
+			return compareNumbers(float64(x.Value), y.Value)
+		}
+	case *expression.DoubleValue:
+		switch y := b.(type) {
+		case *expression.DoubleValue:
+			return compareNumbers(x.Value, y.Value)
+		case *expression.IntValue:
+			return compareNumbers(x.Value, float64(y.Value))
+		}
+	case *expression.BoolValue:
+		if y, ok := b.(*expression.BoolValue); ok {
+			if x.Value == y.Value {
+				return 0, true
+			}
+			return 1, true
+		}
+	case *expression.DurationValue:
+		if y, ok := b.(*expression.DurationValue); ok {
+			return cmp.Compare(x.Value, y.Value), true
+		}
+	case *expression.TimestampValue:
+		if y, ok := b.(*expression.TimestampValue); ok {
+			return x.Value.Compare(y.Value), true
+		}
+	}
+	return 0, false
+}
+
+// compareNumbers orders two numbers, an int among them read as a double, and reports false when one
+// is NaN, which has no order.
+func compareNumbers(x, y float64) (int, bool) {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return 0, false
+	}
+	return cmp.Compare(x, y), true
+}
+
+// readAs reads an untyped constant's text as the type of the value it is compared with, and returns
+// nil when the text is not one.
+func readAs(like expression.Expression, text string) expression.Expression {
+	switch like.(type) {
+	case *expression.StringValue:
+		return &expression.StringValue{Value: text}
+	case *expression.IntValue:
+		if value, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return &expression.IntValue{Value: value}
+		}
+	case *expression.DoubleValue:
+		if value, err := strconv.ParseFloat(text, 64); err == nil {
+			return &expression.DoubleValue{Value: value}
+		}
+	case *expression.BoolValue:
+		if value, err := strconv.ParseBool(text); err == nil {
+			return &expression.BoolValue{Value: value}
+		}
+	}
+	return nil
+}
+
+// values reads every value a term names: a constant is its own one value, and a reference has as
+// many as it finds. A nil in the result is a value the vocabulary has no constant for.
+func values(term expression.Expression, record *spanRecord, b binding) []expression.Expression {
+	switch ref := term.(type) {
+	case *expression.AttributeRef:
+		return attributeValues(ref, record, b)
+	case *expression.FieldRef:
+		return fieldValues(ref, record, b)
+	default:
+		return []expression.Expression{term}
+	}
+}
+
+func attributeValues(ref *expression.AttributeRef, record *spanRecord, b binding) []expression.Expression {
+	span := record.span
+	switch ref.Level {
+	case "":
+		return append(attribute(span.GetAttributes(), ref.Key), attribute(record.resource.message.GetResource().GetAttributes(), ref.Key)...)
+	case expression.LevelSpan:
+		return attribute(span.GetAttributes(), ref.Key)
+	case expression.LevelResource:
+		return attribute(record.resource.message.GetResource().GetAttributes(), ref.Key)
+	case expression.LevelScope:
+		return attribute(record.scope.message.GetScope().GetAttributes(), ref.Key)
+	case expression.LevelEvent:
+		var found []expression.Expression
+		for _, event := range events(span, b) {
+			found = append(found, attribute(event.GetAttributes(), ref.Key)...)
+		}
+		return found
+	case expression.LevelLink:
+		var found []expression.Expression
+		for _, link := range links(span, b) {
+			found = append(found, attribute(link.GetAttributes(), ref.Key)...)
+		}
+		return found
+	}
+	return nil
+}
+
+// attribute reads the value an attribute map holds under a key, as a constant of its type. A key
+// that is not there, or holds an empty value, has no value.
+func attribute(attributes []*commonv1.KeyValue, key string) []expression.Expression {
+	for _, kv := range attributes {
+		if kv.GetKey() != key {
+			continue
+		}
+		switch value := kv.GetValue().GetValue().(type) {
+		case nil:
+			return nil
+		case *commonv1.AnyValue_StringValue:
+			return []expression.Expression{&expression.StringValue{Value: value.StringValue}}
+		case *commonv1.AnyValue_IntValue:
+			return []expression.Expression{&expression.IntValue{Value: value.IntValue}}
+		case *commonv1.AnyValue_DoubleValue:
+			return []expression.Expression{&expression.DoubleValue{Value: value.DoubleValue}}
+		case *commonv1.AnyValue_BoolValue:
+			return []expression.Expression{&expression.BoolValue{Value: value.BoolValue}}
+		default:
+			return []expression.Expression{nil}
+		}
+	}
+	return nil
+}
+
+func events(span *tracev1.Span, b binding) []*tracev1.Span_Event {
+	if b.event != nil {
+		return []*tracev1.Span_Event{b.event}
+	}
+	return span.GetEvents()
+}
+
+func links(span *tracev1.Span, b binding) []*tracev1.Span_Link {
+	if b.link != nil {
+		return []*tracev1.Span_Link{b.link}
+	}
+	return span.GetLinks()
+}
+
+func fieldValues(ref *expression.FieldRef, record *spanRecord, b binding) []expression.Expression {
+	switch ref.Level {
+	case expression.LevelSpan:
+		return spanField(ref.Name, record.span)
+	case expression.LevelResource:
+		return resourceField(ref.Name, record)
+	case expression.LevelScope:
+		return scopeField(ref.Name, record.scope.message)
+	case expression.LevelEvent:
+		var found []expression.Expression
+		for _, event := range events(record.span, b) {
+			found = append(found, eventField(ref.Name, event, record.span)...)
+		}
+		return found
+	case expression.LevelLink:
+		var found []expression.Expression
+		for _, link := range links(record.span, b) {
+			found = append(found, linkField(ref.Name, link)...)
+		}
+		return found
+	}
+	return nil
+}
+
+func spanField(name string, span *tracev1.Span) []expression.Expression {
+	switch name {
+	case expression.SpanFieldTraceID:
+		return id(span.GetTraceId())
+	case expression.SpanFieldSpanID:
+		return id(span.GetSpanId())
+	case expression.SpanFieldParentSpanID:
+		return id(span.GetParentSpanId())
+	case expression.SpanFieldTraceState:
+		return text(span.GetTraceState())
+	case expression.SpanFieldName:
+		return text(span.GetName())
+	case expression.SpanFieldKind:
+		return text(spanKind(span))
+	case expression.SpanFieldStartTime:
+		return instant(span.GetStartTimeUnixNano())
+	case expression.SpanFieldEndTime:
+		return instant(span.GetEndTimeUnixNano())
+	case expression.SpanFieldDuration:
+		if duration, ok := spanDuration(span); ok {
+			return []expression.Expression{&expression.DurationValue{Value: duration}}
+		}
+	case expression.SpanFieldStatus:
+		return text(spanStatus(span))
+	case expression.SpanFieldStatusMessage:
+		return text(span.GetStatus().GetMessage())
+	case expression.SpanFieldFlags:
+		return count(span.GetFlags())
+	case expression.SpanFieldDroppedAttributesCount:
+		return count(span.GetDroppedAttributesCount())
+	case expression.SpanFieldDroppedEventsCount:
+		return count(span.GetDroppedEventsCount())
+	case expression.SpanFieldDroppedLinksCount:
+		return count(span.GetDroppedLinksCount())
+	case expression.SpanFieldEventCount:
+		return count(uint32(len(span.GetEvents())))
+	case expression.SpanFieldLinkCount:
+		return count(uint32(len(span.GetLinks())))
+	}
+	return nil
+}
+
+func resourceField(name string, record *spanRecord) []expression.Expression {
+	switch name {
+	case expression.ResourceFieldService:
+		return text(serviceName(record))
+	case expression.ResourceFieldSchemaURL:
+		return text(record.resource.message.GetSchemaUrl())
+	case expression.ResourceFieldDroppedAttributesCount:
+		return count(record.resource.message.GetResource().GetDroppedAttributesCount())
+	}
+	return nil
+}
+
+func scopeField(name string, scope *tracev1.ScopeSpans) []expression.Expression {
+	switch name {
+	case expression.ScopeFieldName:
+		return text(scope.GetScope().GetName())
+	case expression.ScopeFieldVersion:
+		return text(scope.GetScope().GetVersion())
+	case expression.ScopeFieldSchemaURL:
+		return text(scope.GetSchemaUrl())
+	case expression.ScopeFieldDroppedAttributesCount:
+		return count(scope.GetScope().GetDroppedAttributesCount())
+	}
+	return nil
+}
+
+func eventField(name string, event *tracev1.Span_Event, span *tracev1.Span) []expression.Expression {
+	switch name {
+	case expression.EventFieldName:
+		return text(event.GetName())
+	case expression.EventFieldTime:
+		return instant(event.GetTimeUnixNano())
+	case expression.EventFieldTimeSinceStart:
+		at, start := event.GetTimeUnixNano(), span.GetStartTimeUnixNano()
+		if at != 0 && start != 0 && at >= start {
+			return []expression.Expression{&expression.DurationValue{Value: time.Duration(at - start)}}
+		}
+	case expression.EventFieldDroppedAttributesCount:
+		return count(event.GetDroppedAttributesCount())
+	}
+	return nil
+}
+
+func linkField(name string, link *tracev1.Span_Link) []expression.Expression {
+	switch name {
+	case expression.LinkFieldTraceID:
+		return id(link.GetTraceId())
+	case expression.LinkFieldSpanID:
+		return id(link.GetSpanId())
+	case expression.LinkFieldTraceState:
+		return text(link.GetTraceState())
+	case expression.LinkFieldFlags:
+		return count(link.GetFlags())
+	case expression.LinkFieldDroppedAttributesCount:
+		return count(link.GetDroppedAttributesCount())
+	}
+	return nil
+}
+
+func id(raw []byte) []expression.Expression {
+	if hex, ok := hexID(raw); ok {
+		return []expression.Expression{&expression.StringValue{Value: hex}}
+	}
+	return nil
+}
+
+func text(value string) []expression.Expression {
+	if value == "" {
+		return nil
+	}
+	return []expression.Expression{&expression.StringValue{Value: value}}
+}
+
+func instant(ns uint64) []expression.Expression {
+	if at := unixNano(ns); !at.IsZero() {
+		return []expression.Expression{&expression.TimestampValue{Value: at}}
+	}
+	return nil
+}
+
+func count(n uint32) []expression.Expression {
+	return []expression.Expression{&expression.IntValue{Value: int64(n)}}
+}
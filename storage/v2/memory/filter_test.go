@@ -0,0 +1,240 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+func call(op expression.Operator, args ...expression.Expression) *expression.Call {
+	return &expression.Call{Op: op, Args: args}
+}
+
+func attr(key string, level expression.Level) *expression.AttributeRef {
+	return &expression.AttributeRef{Key: key, Level: level}
+}
+
+func field(level expression.Level, name string) *expression.FieldRef {
+	return &expression.FieldRef{Level: level, Name: name}
+}
+
+func untyped(value string) *expression.AnyValue {
+	return &expression.AnyValue{Value: value}
+}
+
+// record is the FindDriverIDs span of the fixture, with its events and an array attribute.
+func record(t *testing.T) *spanRecord {
+	store := fixture(t)
+	for _, record := range store.traces[traceID(testID(1, 16))].spans {
+		if record.span.GetName() == "FindDriverIDs" {
+			record.span.Attributes = append(record.span.Attributes,
+				&commonv1.KeyValue{Key: "retries", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: 1.5}}},
+				&commonv1.KeyValue{Key: "cached", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: true}}},
+				&commonv1.KeyValue{Key: "hosts", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_ArrayValue{ArrayValue: &commonv1.ArrayValue{}}}},
+			)
+			record.span.Links = []*tracev1.Span_Link{{TraceId: testID(3, 16), SpanId: testID(4, 8)}}
+			return record
+		}
+	}
+	require.FailNow(t, "no FindDriverIDs span")
+	return nil
+}
+
+func TestEvaluator(t *testing.T) {
+	span := record(t)
+	tests := []struct {
+		name     string
+		filter   *expression.Call
+		expected bool
+	}{
+		{
+			name:     "span field",
+			filter:   call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldName), untyped("FindDriverIDs")),
+			expected: true,
+		},
+		{
+			name:     "service",
+			filter:   call(expression.OpEq, field(expression.LevelResource, expression.ResourceFieldService), untyped("driver")),
+			expected: true,
+		},
+		{
+			name:     "scope",
+			filter:   call(expression.OpEq, field(expression.LevelScope, expression.ScopeFieldVersion), untyped("1.0")),
+			expected: true,
+		},
+		{
+			name:     "kind and status words",
+			filter:   call(expression.OpAnd, call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldKind), untyped("client")), call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldStatus), untyped("error"))),
+			expected: true,
+		},
+		{
+			name:     "duration",
+			filter:   call(expression.OpGte, field(expression.LevelSpan, expression.SpanFieldDuration), untyped("100ms")),
+			expected: true,
+		},
+		{
+			name:     "duration turned around",
+			filter:   call(expression.OpLt, untyped("100ms"), field(expression.LevelSpan, expression.SpanFieldDuration)),
+			expected: false,
+		},
+		{
+			name:     "start time",
+			filter:   call(expression.OpGt, field(expression.LevelSpan, expression.SpanFieldStartTime), untyped(base.Format(time.RFC3339Nano))),
+			expected: true,
+		},
+		{
+			name:     "parent ID",
+			filter:   call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldParentSpanID), untyped("0000000000000001")),
+			expected: true,
+		},
+		{
+			name:     "event count",
+			filter:   call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldEventCount), untyped("2")),
+			expected: true,
+		},
+		{
+			name:     "missing field is not ne",
+			filter:   call(expression.OpNe, field(expression.LevelSpan, expression.SpanFieldTraceState), untyped("x")),
+			expected: false,
+		},
+		{
+			name:     "unqualified attribute on the resource",
+			filter:   call(expression.OpEq, attr("deployment.environment", ""), untyped("prod")),
+			expected: true,
+		},
+		{
+			name:     "span-level attribute is not the resource's",
+			filter:   call(expression.OpEq, attr("deployment.environment", expression.LevelSpan), untyped("prod")),
+			expected: false,
+		},
+		{
+			name:     "untyped constant read as a double",
+			filter:   call(expression.OpGt, attr("retries", ""), untyped("1")),
+			expected: true,
+		},
+		{
+			name:     "untyped constant that is not a double",
+			filter:   call(expression.OpEq, attr("retries", ""), untyped("many")),
+			expected: false,
+		},
+		{
+			name:     "typed constant of another type",
+			filter:   call(expression.OpEq, attr("retries", ""), &expression.StringValue{Value: "1.5"}),
+			expected: false,
+		},
+		{
+			name:     "double attribute against an int",
+			filter:   call(expression.OpGt, attr("retries", expression.LevelSpan), &expression.IntValue{Value: 1}),
+			expected: true,
+		},
+		{
+			name:     "double attribute equal to no int",
+			filter:   call(expression.OpEq, attr("retries", expression.LevelSpan), &expression.IntValue{Value: 1}),
+			expected: false,
+		},
+		{
+			name:     "int field against a double",
+			filter:   call(expression.OpGte, field(expression.LevelSpan, expression.SpanFieldEventCount), &expression.DoubleValue{Value: 0.5}),
+			expected: true,
+		},
+		{
+			name:     "int field against a larger double",
+			filter:   call(expression.OpGt, field(expression.LevelSpan, expression.SpanFieldEventCount), &expression.DoubleValue{Value: 2.5}),
+			expected: false,
+		},
+		{
+			name:     "int field against NaN",
+			filter:   call(expression.OpNe, field(expression.LevelSpan, expression.SpanFieldEventCount), &expression.DoubleValue{Value: math.NaN()}),
+			expected: false,
+		},
+		{
+			name:     "boolean equality",
+			filter:   call(expression.OpEq, attr("cached", ""), untyped("true")),
+			expected: true,
+		},
+		{
+			name:     "booleans are not ordered",
+			filter:   call(expression.OpGt, attr("cached", ""), untyped("false")),
+			expected: false,
+		},
+		{
+			name:     "regex matches anywhere",
+			filter:   call(expression.OpRegex, attr("param.location", ""), untyped(`\d+,3`)),
+			expected: true,
+		},
+		{
+			name:     "exists of an array",
+			filter:   call(expression.OpExists, attr("hosts", "")),
+			expected: true,
+		},
+		{
+			name:     "array equals nothing",
+			filter:   call(expression.OpNe, attr("hosts", ""), untyped("x")),
+			expected: false,
+		},
+		{
+			name:     "not of a missing attribute",
+			filter:   call(expression.OpNot, call(expression.OpExists, attr("http.route", ""))),
+			expected: true,
+		},
+		{
+			name:     "in",
+			filter:   call(expression.OpIn, field(expression.LevelSpan, expression.SpanFieldKind), &expression.List{Values: []string{"server", "client"}}),
+			expected: true,
+		},
+		{
+			name:     "not_in",
+			filter:   call(expression.OpNotIn, field(expression.LevelSpan, expression.SpanFieldKind), &expression.List{Values: []string{"server", "consumer"}}),
+			expected: true,
+		},
+		{
+			name:     "not_in of another type",
+			filter:   call(expression.OpNotIn, attr("param.location", ""), &expression.List{Values: []string{"1", "2"}, Type: expression.ValueTypeInt}),
+			expected: false,
+		},
+		{
+			name:     "event level outside some is any event",
+			filter:   call(expression.OpAnd, call(expression.OpEq, field(expression.LevelEvent, expression.EventFieldName), untyped("retry")), call(expression.OpExists, attr("exception.type", expression.LevelEvent))),
+			expected: true,
+		},
+		{
+			name: "some binds one event",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpAnd, call(expression.OpEq, field(expression.LevelEvent, expression.EventFieldName), untyped("retry")), call(expression.OpExists, attr("exception.type", expression.LevelEvent)))),
+			expected: false,
+		},
+		{
+			name: "time since start",
+			filter: call(expression.OpSome, &expression.NestedRef{Level: expression.LevelEvent},
+				call(expression.OpEq, field(expression.LevelEvent, expression.EventFieldTimeSinceStart), untyped("90ms"))),
+			expected: true,
+		},
+		{
+			name:     "link",
+			filter:   call(expression.OpEq, field(expression.LevelLink, expression.LinkFieldTraceID), untyped("00000000000000000000000000000003")),
+			expected: true,
+		},
+		{
+			name:     "or",
+			filter:   call(expression.OpOr, call(expression.OpEq, field(expression.LevelSpan, expression.SpanFieldName), untyped("nope")), call(expression.OpExists, attr("cached", ""))),
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := expression.Finalize(test.filter)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, newEvaluator().call(filter, span, binding{}))
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// FuzzEvaluator checks the evaluator's answers on the fixture's spans against what can be known of
+// them without it:
+//
+//   - where expression.Implies proves that every span one filter matches, another matches too, no
+//     span matches the first without the second. Implies reasons over the filters alone, and shares
+//     no code with the evaluator, so the two disagreeing means one of them is wrong;
+//   - `not` answers the opposite of the predicate it negates, which holds whatever a predicate asks,
+//     a missing value included;
+//   - `and` and `or` answer as the boolean operators over their arguments' answers, which a naive
+//     re-evaluation of the filter's structure computes independently.
+//
+// The input is the choices a filterBuilder makes, over references the fixture's spans hold, lack
+// and hold under several types, and constants around their values, so that the filters built are
+// often ones a span matches and often ones two filters can be compared by.
+func FuzzEvaluator(f *testing.F) {
+	f.Add([]byte{6, 3, 0, 6, 3, 1})
+	f.Add([]byte{6, 1, 2, 3, 6, 1, 3, 3})
+	f.Add([]byte{0, 6, 4, 0, 0, 6, 4, 1, 6, 4, 1})
+	f.Add([]byte{3, 5, 0, 1, 6, 5, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &filterBuilder{data: data}
+		a, err := expression.Finalize(b.filter())
+		if err != nil {
+			return
+		}
+		c, err := expression.Finalize(b.filter())
+		if err != nil {
+			return
+		}
+		implied := expression.Implies(a, c)
+		negated := &expression.Call{Op: expression.OpNot, Args: []expression.Expression{a}}
+		ev := newEvaluator()
+		for _, record := range fuzzRecords(t) {
+			matchesA := ev.call(a, record, binding{})
+			if implied && matchesA {
+				require.True(t, ev.call(c, record, binding{}),
+					"Implies proved %v implies %v, and span %q matches only the first", a, c, record.span.GetName())
+			}
+			require.Equal(t, !matchesA, ev.call(negated, record, binding{}), "not %v", a)
+			require.Equal(t, naive(ev, a, record), matchesA, "%v", a)
+		}
+	})
+}
+
+// naive evaluates the boolean structure of a filter itself, and hands only what is not `and`, `or`
+// or `not` to the evaluator.
+func naive(ev *evaluator, call *expression.Call, record *spanRecord) bool {
+	switch call.Op {
+	case expression.OpAnd, expression.OpOr:
+		and := call.Op == expression.OpAnd
+		result := and
+		for _, arg := range call.Args {
+			if and {
+				result = naive(ev, arg.(*expression.Call), record) && result
+			} else {
+				result = naive(ev, arg.(*expression.Call), record) || result
+			}
+		}
+		return result
+	case expression.OpNot:
+		return !naive(ev, call.Args[0].(*expression.Call), record)
+	default:
+		return ev.call(call, record, binding{})
+	}
+}
+
+// fuzzRecords returns every span of the fixture, and the FindDriverIDs span again with the
+// attributes record gives it.
+func fuzzRecords(t *testing.T) []*spanRecord {
+	var records []*spanRecord
+	for _, trace := range fixture(t).traces {
+		records = append(records, trace.spans...)
+	}
+	return append(records, record(t))
+}
+
+// subject is a reference a filter may compare, and the constants it is compared against.
+type subject struct {
+	ref       expression.Expression
+	constants []expression.Expression
+	// listType is the type a list compared against the reference declares, which a list beside an
+	// attribute has to. It is empty beside a field, whose type the list is read at.
+	listType expression.ValueType
+}
+
+func str(value string) expression.Expression     { return &expression.StringValue{Value: value} }
+func integer(value int64) expression.Expression  { return &expression.IntValue{Value: value} }
+func double(value float64) expression.Expression { return &expression.DoubleValue{Value: value} }
+
+func duration(value time.Duration) expression.Expression {
+	return &expression.DurationValue{Value: value}
+}
+
+// The references the fixture's spans hold, lack, or hold as one type on one span and another on
+// the next, with constants on either side of what they hold.
+var (
+	spanSubjects = []subject{
+		{ref: field(expression.LevelSpan, expression.SpanFieldName), constants: []expression.Expression{str("FindDriverIDs"), str("GET"), str("GET /config")}},
+		{ref: field(expression.LevelSpan, expression.SpanFieldKind), constants: []expression.Expression{str("client"), str("server")}},
+		{ref: field(expression.LevelSpan, expression.SpanFieldStatus), constants: []expression.Expression{str("error"), str("ok"), str("unset")}},
+		{ref: field(expression.LevelSpan, expression.SpanFieldDuration), constants: []expression.Expression{duration(time.Millisecond), duration(100 * time.Millisecond), duration(time.Second)}},
+		{ref: field(expression.LevelSpan, expression.SpanFieldEventCount), constants: []expression.Expression{integer(0), integer(2), double(0.5), double(2)}},
+		{ref: field(expression.LevelResource, expression.ResourceFieldService), constants: []expression.Expression{str("driver"), str("frontend"), str("redis")}},
+		{ref: attr("retries", expression.LevelSpan), constants: []expression.Expression{double(1.5), double(1), integer(1), integer(2), untyped("1.5")}, listType: expression.ValueTypeDouble},
+		{ref: attr("http.response.status_code", ""), constants: []expression.Expression{integer(200), integer(404), double(404), untyped("200")}, listType: expression.ValueTypeInt},
+		{ref: attr("param.location", ""), constants: []expression.Expression{str("728,326"), str("1,2"), untyped("728,326")}, listType: expression.ValueTypeString},
+		{ref: attr("cached", ""), constants: []expression.Expression{&expression.BoolValue{Value: true}, &expression.BoolValue{Value: false}}, listType: expression.ValueTypeBool},
+		{ref: attr("hosts", ""), constants: []expression.Expression{str("a")}, listType: expression.ValueTypeString},
+		{ref: attr("http.route", ""), constants: []expression.Expression{str("/")}, listType: expression.ValueTypeString},
+	}
+	eventSubjects = []subject{
+		{ref: field(expression.LevelEvent, expression.EventFieldName), constants: []expression.Expression{str("retry"), str("exception")}},
+		{ref: field(expression.LevelEvent, expression.EventFieldTimeSinceStart), constants: []expression.Expression{duration(10 * time.Millisecond), duration(90 * time.Millisecond)}},
+		{ref: attr("exception.type", expression.LevelEvent), constants: []expression.Expression{str("timeout"), str("refused")}, listType: expression.ValueTypeString},
+	}
+	fuzzPatterns = []string{"Driver", "^GET", "x|GET", "[0-9]+,3", ""}
+	fuzzOps      = []expression.Operator{
+		expression.OpEq, expression.OpNe, expression.OpGt, expression.OpGte, expression.OpLt, expression.OpLte,
+		expression.OpRegex, expression.OpIn, expression.OpNotIn, expression.OpExists,
+	}
+)
+
+// filterBuilder turns fuzz input into a filter. Each byte it reads is one choice, taken modulo the
+// number of options, and an exhausted input chooses 0 from then on, which ends every branch.
+type filterBuilder struct {
+	data []byte
+}
+
+func (b *filterBuilder) next() int {
+	if len(b.data) == 0 {
+		return 0
+	}
+	choice := int(b.data[0])
+	b.data = b.data[1:]
+	return choice
+}
+
+func pick[T any](b *filterBuilder, options []T) T {
+	return options[b.next()%len(options)]
+}
+
+// filter builds a predicate: a combinator up to three deep, and a comparison below that. The first
+// choice of each predicate is what it is, so an exhausted input builds `and()` of nothing, which
+// finalizing refuses, rather than a comparison the seeds would all share.
+func (b *filterBuilder) filter() *expression.Call {
+	return b.predicate(0, spanSubjects, true)
+}
+
+func (b *filterBuilder) predicate(depth int, subjects []subject, quantify bool) *expression.Call {
+	choice := b.next() % 7
+	if depth >= 3 {
+		choice = 6
+	}
+	switch choice {
+	case 0, 1:
+		op := []expression.Operator{expression.OpAnd, expression.OpOr}[choice]
+		args := make([]expression.Expression, b.next()%3)
+		for i := range args {
+			args[i] = b.predicate(depth+1, subjects, quantify)
+		}
+		return &expression.Call{Op: op, Args: args}
+	case 2:
+		return &expression.Call{Op: expression.OpNot, Args: []expression.Expression{b.predicate(depth+1, subjects, quantify)}}
+	case 3:
+		if quantify {
+			return &expression.Call{Op: expression.OpSome, Args: []expression.Expression{
+				&expression.NestedRef{Level: expression.LevelEvent},
+				b.predicate(depth+1, eventSubjects, false),
+			}}
+		}
+	case 4:
+		subjects = eventSubjects
+	}
+	return b.comparison(pick(b, subjects))
+}
+
+// comparison compares a subject by an operator it may or may not take, which finalizing refuses.
+func (b *filterBuilder) comparison(s subject) *expression.Call {
+	op := pick(b, fuzzOps)
+	switch op {
+	case expression.OpExists:
+		return &expression.Call{Op: op, Args: []expression.Expression{s.ref}}
+	case expression.OpRegex:
+		return &expression.Call{Op: op, Args: []expression.Expression{s.ref, str(pick(b, fuzzPatterns))}}
+	case expression.OpIn, expression.OpNotIn:
+		list := &expression.List{Type: s.listType}
+		for range 1 + b.next()%2 {
+			list.Values = append(list.Values, constantText(pick(b, s.constants)))
+		}
+		return &expression.Call{Op: op, Args: []expression.Expression{s.ref, list}}
+	}
+	constant := pick(b, s.constants)
+	if b.next()%4 == 0 {
+		// The constant first, which finalizing turns around.
+		return &expression.Call{Op: op, Args: []expression.Expression{constant, s.ref}}
+	}
+	return &expression.Call{Op: op, Args: []expression.Expression{s.ref, constant}}
+}
+
+// constantText is how a constant is written as an element of a list.
+func constantText(e expression.Expression) string {
+	switch value := e.(type) {
+	case *expression.StringValue:
+		return value.Value
+	case *expression.AnyValue:
+		return value.Value
+	case *expression.IntValue:
+		return strconv.FormatInt(value.Value, 10)
+	case *expression.DoubleValue:
+		return strconv.FormatFloat(value.Value, 'g', -1, 64)
+	case *expression.BoolValue:
+		return strconv.FormatBool(value.Value)
+	case *expression.DurationValue:
+		return value.Value.String()
+	}
+	return ""
+}
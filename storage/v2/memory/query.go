@@ -0,0 +1,290 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// query is a TraceQueryParameters read once, before any span is looked at, so that a query the
+// store refuses is refused whatever it holds.
+type query struct {
+	params *storage.TraceQueryParameters
+	// filter is the finalized structured filter, or nil for a query made of the legacy predicates.
+	filter *expression.Call
+	// evaluator evaluates filter, and is made with it, so each pattern is compiled once a query
+	// rather than once a trace.
+	evaluator *evaluator
+	window    timeWindow
+	depth     int
+}
+
+func newQuery(params *storage.TraceQueryParameters) (*query, error) {
+	if params == nil {
+		return nil, errors.New("query is required")
+	}
+	if params.GetSearchDepth() <= 0 {
+		return nil, fmt.Errorf("search_depth has to be positive, got %d", params.GetSearchDepth())
+	}
+	q := &query{
+		params: params,
+		window: timeWindow{min: params.GetStartTimeMin(), max: params.GetStartTimeMax()},
+		depth:  int(params.GetSearchDepth()),
+	}
+	if !q.window.valid() {
+		return nil, errors.New("start_time_max is before start_time_min")
+	}
+	if params.GetDurationMin() != nil && params.GetDurationMax() != nil &&
+		params.GetDurationMax().AsDuration() < params.GetDurationMin().AsDuration() {
+		return nil, errors.New("duration_max is less than duration_min")
+	}
+	if params.GetFilter() == nil {
+		return q, nil
+	}
+	if legacy := legacyPredicates(params); len(legacy) > 0 {
+		return nil, fmt.Errorf("filter is mutually exclusive with the legacy predicates, and the query also sets %s", legacy[0])
+	}
+	filter, err := readFilter(params.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	q.filter, q.evaluator = filter, newEvaluator()
+	return q, nil
+}
+
+// legacyPredicates names the legacy predicate fields a query sets.
+func legacyPredicates(params *storage.TraceQueryParameters) []string {
+	var set []string
+	if params.GetServiceName() != "" {
+		set = append(set, "service_name")
+	}
+	if params.GetOperationName() != "" {
+		set = append(set, "operation_name")
+	}
+	if len(params.GetAttributes()) > 0 {
+		set = append(set, "attributes")
+	}
+	if params.GetDurationMin() != nil {
+		set = append(set, "duration_min")
+	}
+	if params.GetDurationMax() != nil {
+		set = append(set, "duration_max")
+	}
+	return set
+}
+
+// readFilter turns the generated message into the filter the expression package reads, by way of
+// the proto3 JSON form both of them speak, and finalizes it.
+func readFilter(message *expressionpb.Call) (*expression.Call, error) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	filter := &expression.Call{}
+	if err := filter.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return expression.Finalize(filter)
+}
+
+// matches reports whether one span of the trace matches everything the query asks.
+func (q *query) matches(t *trace) bool {
+	return slices.ContainsFunc(t.spans, func(record *spanRecord) bool {
+		if !q.window.holds(record) {
+			return false
+		}
+		if q.filter != nil {
+			return q.evaluator.call(q.filter, record, binding{})
+		}
+		return q.matchesLegacy(record)
+	})
+}
+
+func (q *query) matchesLegacy(record *spanRecord) bool {
+	params := q.params
+	if params.GetServiceName() != "" && serviceName(record) != params.GetServiceName() {
+		return false
+	}
+	if params.GetOperationName() != "" && record.span.GetName() != params.GetOperationName() {
+		return false
+	}
+	if params.GetDurationMin() != nil || params.GetDurationMax() != nil {
+		duration, ok := spanDuration(record.span)
+		if !ok {
+			return false
+		}
+		if params.GetDurationMin() != nil && duration < params.GetDurationMin().AsDuration() {
+			return false
+		}
+		if params.GetDurationMax() != nil && duration > params.GetDurationMax().AsDuration() {
+			return false
+		}
+	}
+	for _, attribute := range params.GetAttributes() {
+		if !hasAttribute(record, attribute) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAttribute reports whether a span or its resource holds an attribute of the key and value the
+// query names. A legacy attribute was always a tag compared as text, so the value is too.
+func hasAttribute(record *spanRecord, want *storage.KeyValue) bool {
+	for _, attributes := range [][]*commonv1.KeyValue{record.span.GetAttributes(), record.resource.message.GetResource().GetAttributes()} {
+		for _, kv := range attributes {
+			if kv.GetKey() == want.GetKey() && sameValue(kv.GetValue(), want.GetValue()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameValue compares a stored value with a queried one. A scalar is compared as text, which is how
+// a legacy tag was always matched; an array or a map is compared as the structure it is.
+func sameValue(stored *commonv1.AnyValue, want *storage.AnyValue) bool {
+	wantText, wantScalar := queriedText(want)
+	haveText, haveScalar := storedText(stored)
+	if wantScalar || haveScalar {
+		return wantScalar && haveScalar && wantText == haveText
+	}
+	// The storage API copies the OTLP messages field for field, so one reads as the other.
+	data, err := proto.Marshal(want)
+	if err != nil {
+		return false
+	}
+	converted := &commonv1.AnyValue{}
+	if err := proto.Unmarshal(data, converted); err != nil {
+		return false
+	}
+	return proto.Equal(stored, converted)
+}
+
+func storedText(value *commonv1.AnyValue) (string, bool) {
+	switch v := value.GetValue().(type) {
+	case *commonv1.AnyValue_StringValue:
+		return v.StringValue, true
+	case *commonv1.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10), true
+	case *commonv1.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64), true
+	case *commonv1.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue), true
+	case *commonv1.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue), true
+	}
+	return "", false
+}
+
+func queriedText(value *storage.AnyValue) (string, bool) {
+	switch v := value.GetValue().(type) {
+	case *storage.AnyValue_StringValue:
+		return v.StringValue, true
+	case *storage.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10), true
+	case *storage.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64), true
+	case *storage.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue), true
+	case *storage.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue), true
+	}
+	return "", false
+}
+
+// timeWindow bounds when a span started. Either end may be missing. The start is inclusive; the
+// end is exclusive for a search, as api_v3 defines start_time_max, and inclusive for GetTraces,
+// whose end_time only says where to look.
+type timeWindow struct {
+	min, max     *timestamppb.Timestamp
+	maxInclusive bool
+}
+
+func (w timeWindow) valid() bool {
+	return w.min == nil || w.max == nil || !w.max.AsTime().Before(w.min.AsTime())
+}
+
+func (w timeWindow) holds(record *spanRecord) bool {
+	if w.min == nil && w.max == nil {
+		return true
+	}
+	start := unixNano(record.span.GetStartTimeUnixNano())
+	if start.IsZero() {
+		return false
+	}
+	if w.min != nil && start.Before(w.min.AsTime()) {
+		return false
+	}
+	if w.max != nil {
+		order := start.Compare(w.max.AsTime())
+		return order < 0 || (order == 0 && w.maxInclusive)
+	}
+	return true
+}
+
+// serviceName is the service.name attribute of the resource a span was written under.
+func serviceName(record *spanRecord) string {
+	for _, kv := range record.resource.message.GetResource().GetAttributes() {
+		if kv.GetKey() == "service.name" {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
+
+// spanDuration is how long a span lasted, known when it says both when it started and when it
+// ended, in that order.
+func spanDuration(span *tracev1.Span) (time.Duration, bool) {
+	start, end := span.GetStartTimeUnixNano(), span.GetEndTimeUnixNano()
+	if start == 0 || end == 0 || end < start {
+		return 0, false
+	}
+	return time.Duration(end - start), true
+}
+
+// spanKind is the word span.kind holds for a span's kind, as a filter spells it.
+func spanKind(span *tracev1.Span) string {
+	return wordOf(expression.SpanKinds(), int(span.GetKind()))
+}
+
+// spanStatus is the word span.status holds for a span's status code. A span without a status has
+// the unset one.
+func spanStatus(span *tracev1.Span) string {
+	return wordOf(expression.SpanStatuses(), int(span.GetStatus().GetCode()))
+}
+
+// wordOf reads an OTLP enum as the word at its position, which is how the expression package lists
+// them. A value past the end is one this version of OTLP does not define, and has no word.
+func wordOf(words []string, value int) string {
+	if value < 0 || value >= len(words) {
+		return ""
+	}
+	return words[value]
+}
+
+// hexID spells an ID the way a filter compares it, in lower-case hex. OTLP writes a missing ID as
+// empty or as zeros, and either is absent rather than an ID.
+func hexID(id []byte) (string, bool) {
+	if len(id) == 0 || bytes.Equal(id, make([]byte, len(id))) {
+		return "", false
+	}
+	return fmt.Sprintf("%x", id), true
+}
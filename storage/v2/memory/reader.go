@@ -0,0 +1,264 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+// GetTraces streams each trace the request names, one TracesData per trace, in the order they are
+// named. An ID named twice is answered once, and one the store does not hold is skipped.
+//
+// A time range narrows where the trace is looked for, as it would in a backend partitioned by time:
+// a trace none of whose spans started within it is not found. Once found, a trace is returned whole.
+func (s *Store) GetTraces(req *storage.GetTracesRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	for _, query := range req.GetQuery() {
+		if len(query.GetTraceId()) != len(traceID{}) {
+			return status.Errorf(codes.InvalidArgument, "trace ID has %d bytes, and a trace ID has %d", len(query.GetTraceId()), len(traceID{}))
+		}
+	}
+	var found []*tracev1.TracesData
+	seen := map[traceID]bool{}
+	s.mu.RLock()
+	for _, query := range req.GetQuery() {
+		id := traceID(query.GetTraceId())
+		t, ok := s.traces[id]
+		if !ok || seen[id] {
+			continue
+		}
+		window := timeWindow{min: query.GetStartTime(), max: query.GetEndTime(), maxInclusive: true}
+		if !slices.ContainsFunc(t.spans, window.holds) {
+			continue
+		}
+		seen[id] = true
+		found = append(found, t.tracesData())
+	}
+	s.mu.RUnlock()
+	for _, data := range found {
+		if err := stream.Send(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetServices returns the name of every service a stored span was written under, sorted.
+func (s *Store) GetServices(context.Context, *storage.GetServicesRequest) (*storage.GetServicesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var services []string
+	for _, t := range s.traces {
+		for _, record := range t.spans {
+			if name := serviceName(record); name != "" && !slices.Contains(services, name) {
+				services = append(services, name)
+			}
+		}
+	}
+	slices.Sort(services)
+	return &storage.GetServicesResponse{Services: services}, nil
+}
+
+// GetOperations returns the span names a service has written, each with the kind of span it was
+// written as, sorted by name and then by kind. A kind is one of the words span.kind holds in a
+// filter ("server", "client"); given one, only operations of that kind are returned.
+func (s *Store) GetOperations(_ context.Context, req *storage.GetOperationsRequest) (*storage.GetOperationsResponse, error) {
+	if req.GetService() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service is required")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var operations []*storage.Operation
+	for _, t := range s.traces {
+		for _, record := range t.spans {
+			if serviceName(record) != req.GetService() {
+				continue
+			}
+			kind := spanKind(record.span)
+			if req.GetSpanKind() != "" && kind != req.GetSpanKind() {
+				continue
+			}
+			if !slices.ContainsFunc(operations, func(o *storage.Operation) bool {
+				return o.GetName() == record.span.GetName() && o.GetSpanKind() == kind
+			}) {
+				operations = append(operations, &storage.Operation{Name: record.span.GetName(), SpanKind: kind})
+			}
+		}
+	}
+	slices.SortFunc(operations, func(a, b *storage.Operation) int {
+		if order := strings.Compare(a.GetName(), b.GetName()); order != 0 {
+			return order
+		}
+		return strings.Compare(a.GetSpanKind(), b.GetSpanKind())
+	})
+	return &storage.GetOperationsResponse{Operations: operations}, nil
+}
+
+// FindTraces streams the traces the query matches, one TracesData per trace, the latest first and
+// at most search_depth of them. A trace is returned whole, not just the spans that matched.
+//
+// A trace matches when one of its spans matches every field the query sets:
+//
+//   - service_name, operation_name, attributes and duration_min/max are the legacy predicates. The
+//     span was written by the service, is named the operation, and lasted at least duration_min and
+//     at most duration_max. Each attribute is looked for among the span's attributes and then its
+//     resource's, and matches a value of the same text, so "500" matches the integer 500.
+//   - filter is the structured filter, which the span satisfies as RFC 0005 defines. It is
+//     finalized first, and one that is not well formed is refused. It is mutually exclusive with
+//     the legacy predicates, so a query setting both is refused too.
+//   - start_time_min and start_time_max bound when the span started, the first inclusively and the
+//     second not. Either may be left out, and so may service_name.
+//
+// search_depth has to be positive. A query the store refuses is answered with InvalidArgument.
+func (s *Store) FindTraces(req *storage.FindTracesRequest, stream grpc.ServerStreamingServer[tracev1.TracesData]) error {
+	traces, err := s.find(req.GetQuery())
+	if err != nil {
+		return err
+	}
+	for _, t := range traces {
+		if err := stream.Send(t.tracesData()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindTraceIDs returns the IDs of the traces FindTraces would return, in the same order, each with
+// the earliest start and the latest end among its spans.
+func (s *Store) FindTraceIDs(_ context.Context, req *storage.FindTraceIDsRequest) (*storage.FindTraceIDsResponse, error) {
+	traces, err := s.find(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	resp := &storage.FindTraceIDsResponse{}
+	for _, t := range traces {
+		found := &storage.FoundTraceID{TraceId: t.id[:]}
+		start, end := t.timeRange()
+		if !start.IsZero() {
+			found.Start = timestamppb.New(start)
+		}
+		if !end.IsZero() {
+			found.End = timestamppb.New(end)
+		}
+		resp.TraceIds = append(resp.TraceIds, found)
+	}
+	return resp, nil
+}
+
+// FindTraceSummaries streams a summary of each trace FindTraces would return, in the same order.
+// They are sent together, as one chunk, and not at all when nothing matches.
+func (s *Store) FindTraceSummaries(req *storage.FindTraceSummariesRequest, stream grpc.ServerStreamingServer[storage.FindTraceSummariesResponse]) error {
+	traces, err := s.find(req.GetQuery())
+	if err != nil {
+		return err
+	}
+	if len(traces) == 0 {
+		return nil
+	}
+	resp := &storage.FindTraceSummariesResponse{}
+	for _, t := range traces {
+		resp.Summaries = append(resp.Summaries, t.summary())
+	}
+	return stream.Send(resp)
+}
+
+// find returns the traces a query matches, in the order the search RPCs answer in. It holds the
+// read lock only while it looks, which is safe because a stored trace is never modified, only
+// appended to; the records a caller reads were complete when they were found.
+func (s *Store) find(params *storage.TraceQueryParameters) ([]*trace, error) {
+	query, err := newQuery(params)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var found []*trace
+	for _, t := range s.sortedTraces() {
+		if len(found) == query.depth {
+			break
+		}
+		if query.matches(t) {
+			found = append(found, &trace{id: t.id, spans: slices.Clone(t.spans)})
+		}
+	}
+	return found, nil
+}
+
+// GetDependencies returns the links written at a moment within the request's time range, both ends
+// included, with the call counts of the links between the same two services from the same source
+// added together. They are sorted by parent, child and source. Either end of the range may be left
+// out.
+func (s *Store) GetDependencies(_ context.Context, req *storage.GetDependenciesRequest) (*storage.GetDependenciesResponse, error) {
+	window := timeWindow{min: req.GetStartTime(), max: req.GetEndTime(), maxInclusive: true}
+	if !window.valid() {
+		return nil, status.Error(codes.InvalidArgument, "end_time is before start_time")
+	}
+	type key struct{ parent, child, source string }
+	counts := map[key]*storage.Dependency{}
+	resp := &storage.GetDependenciesResponse{}
+	s.mu.RLock()
+	for _, batch := range s.dependencies {
+		if (window.min != nil && batch.at.Before(window.min.AsTime())) || (window.max != nil && batch.at.After(window.max.AsTime())) {
+			continue
+		}
+		for _, link := range batch.links {
+			k := key{link.GetParent(), link.GetChild(), link.GetSource()}
+			dependency, ok := counts[k]
+			if !ok {
+				dependency = &storage.Dependency{Parent: k.parent, Child: k.child, Source: k.source}
+				counts[k] = dependency
+				resp.Dependencies = append(resp.Dependencies, dependency)
+			}
+			dependency.CallCount += link.GetCallCount()
+		}
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(resp.Dependencies, func(a, b *storage.Dependency) int {
+		if order := strings.Compare(a.GetParent(), b.GetParent()); order != 0 {
+			return order
+		}
+		if order := strings.Compare(a.GetChild(), b.GetChild()); order != 0 {
+			return order
+		}
+		return strings.Compare(a.GetSource(), b.GetSource())
+	})
+	return resp, nil
+}
+
+// GetCapabilities reports everything a search supports, which is every field, at every level and
+// under every operator the filter has, with conjunctions matched within a span.
+func (*Store) GetCapabilities(context.Context, *storage.GetCapabilitiesRequest) (*storage.GetCapabilitiesResponse, error) {
+	levels := []expression.Level{
+		expression.LevelSpan, expression.LevelResource, expression.LevelScope, expression.LevelEvent, expression.LevelLink,
+	}
+	operators := []expression.Operator{
+		expression.OpAnd, expression.OpOr, expression.OpNot,
+		expression.OpEq, expression.OpNe, expression.OpGt, expression.OpLt, expression.OpGte, expression.OpLte,
+		expression.OpRegex, expression.OpExists, expression.OpIn, expression.OpNotIn, expression.OpSome,
+	}
+	filter := &storage.FilterCapabilities{}
+	for _, level := range levels {
+		filter.Levels = append(filter.Levels, string(level))
+	}
+	for _, op := range operators {
+		filter.Operators = append(filter.Operators, string(op))
+	}
+	return &storage.GetCapabilitiesResponse{
+		Search: &storage.SearchCapabilities{
+			WithoutServiceName:  true,
+			SameSpanConjunction: true,
+			Filter:              filter,
+		},
+	}, nil
+}
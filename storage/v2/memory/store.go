@@ -0,0 +1,215 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements the storage v2 gRPC API (jaeger.storage.v2) over traces held in
+// memory, as a stand-in for a remote storage backend wherever a test wants one: a client of the API
+// is tested against a backend that answers every call the way the contract says, without running
+// one. It is a reference rather than a store to run in production. Nothing is evicted, and every
+// search walks every span.
+//
+// A Store serves the TraceReader, DependencyReader and Capabilities services. Traces are written
+// to it as OTLP TracesData, the form the OTLP TraceService takes them in, and dependencies as the
+// links GetDependencies returns.
+//
+// A search honors every field of TraceQueryParameters, and holds a backend's strictest reading of
+// each: a legacy predicate and the time range are matched within one span, as is every conjunct of
+// a structured filter, which this backend evaluates at every level and under every operator RFC 0005
+// defines (see the expression package). What it does with each field is documented on FindTraces.
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	"github.com/jaegertracing/jaeger-idl/storage/v2/tracesummary"
+)
+
+// Store holds traces and dependencies in memory and serves them over the storage v2 API. The zero
+// value is not ready for use; a Store is made with NewStore.
+//
+// A Store is safe for concurrent use: a write is seen in full by every call that starts after it
+// returns, and by no call that started before.
+type Store struct {
+	storage.UnimplementedTraceReaderServer
+	storage.UnimplementedDependencyReaderServer
+	storage.UnimplementedCapabilitiesServer
+
+	mu     sync.RWMutex
+	traces map[traceID]*trace
+	// dependencies holds every batch written, in the order it was written.
+	dependencies []dependencyBatch
+}
+
+// traceID is a trace ID as a map key: the 16 bytes OTLP carries it in.
+type traceID [16]byte
+
+// trace is every span written for one trace ID, in the order they were written.
+type trace struct {
+	id    traceID
+	spans []*spanRecord
+}
+
+// spanRecord is one span with the resource and the scope it was written under, which a filter
+// reads as the resource and scope levels and which GetTraces writes the span back under.
+type spanRecord struct {
+	resource *resourceEntry
+	scope    *scopeEntry
+	span     *tracev1.Span
+}
+
+// resourceEntry is a ResourceSpans without its spans, shared by every span written under it.
+type resourceEntry struct {
+	message *tracev1.ResourceSpans
+}
+
+// scopeEntry is a ScopeSpans without its spans, shared by every span written under it.
+type scopeEntry struct {
+	message *tracev1.ScopeSpans
+}
+
+type dependencyBatch struct {
+	at    time.Time
+	links []*storage.Dependency
+}
+
+// NewStore returns an empty store.
+func NewStore() *Store {
+	return &Store{traces: map[traceID]*trace{}}
+}
+
+// Register registers the store as the TraceReader, DependencyReader and Capabilities services of
+// a gRPC server.
+func (s *Store) Register(server grpc.ServiceRegistrar) {
+	storage.RegisterTraceReaderServer(server, s)
+	storage.RegisterDependencyReaderServer(server, s)
+	storage.RegisterCapabilitiesServer(server, s)
+}
+
+// WriteTraces stores the spans of a TracesData, each under the trace its trace ID names. A trace
+// may arrive over several writes, and its spans are kept in the order they arrived. The store keeps
+// a copy, so the caller may go on using what it wrote.
+//
+// Every span has to carry a trace ID of 16 bytes, since it is stored under one; a write holding a
+// span that does not is refused, and stores nothing.
+func (s *Store) WriteTraces(data *tracev1.TracesData) error {
+	data = proto.Clone(data).(*tracev1.TracesData)
+	var records []*spanRecord
+	for _, rs := range data.GetResourceSpans() {
+		resource := &resourceEntry{message: &tracev1.ResourceSpans{Resource: rs.GetResource(), SchemaUrl: rs.GetSchemaUrl()}}
+		for _, ss := range rs.GetScopeSpans() {
+			scope := &scopeEntry{message: &tracev1.ScopeSpans{Scope: ss.GetScope(), SchemaUrl: ss.GetSchemaUrl()}}
+			for _, span := range ss.GetSpans() {
+				if len(span.GetTraceId()) != len(traceID{}) {
+					return fmt.Errorf("span %q has a trace ID of %d bytes, and a trace ID has %d",
+						span.GetName(), len(span.GetTraceId()), len(traceID{}))
+				}
+				records = append(records, &spanRecord{resource: resource, scope: scope, span: span})
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range records {
+		id := traceID(record.span.GetTraceId())
+		t, ok := s.traces[id]
+		if !ok {
+			t = &trace{id: id}
+			s.traces[id] = t
+		}
+		t.spans = append(t.spans, record)
+	}
+	return nil
+}
+
+// WriteDependencies stores the dependency links observed at a moment, which GetDependencies returns
+// for a time range holding it. The store keeps copies of the links.
+func (s *Store) WriteDependencies(at time.Time, links ...*storage.Dependency) {
+	batch := dependencyBatch{at: at}
+	for _, link := range links {
+		batch.links = append(batch.links, proto.Clone(link).(*storage.Dependency))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dependencies = append(s.dependencies, batch)
+}
+
+// tracesData builds the TracesData a trace is returned as: each span under the resource and the
+// scope it was written under, grouped as they were written. What it returns is a copy, so nothing a
+// caller does to it reaches the store.
+func (t *trace) tracesData() *tracev1.TracesData {
+	data := &tracev1.TracesData{}
+	resources := map[*resourceEntry]*tracev1.ResourceSpans{}
+	scopes := map[*scopeEntry]*tracev1.ScopeSpans{}
+	for _, record := range t.spans {
+		rs, ok := resources[record.resource]
+		if !ok {
+			rs = &tracev1.ResourceSpans{Resource: record.resource.message.GetResource(), SchemaUrl: record.resource.message.GetSchemaUrl()}
+			resources[record.resource] = rs
+			data.ResourceSpans = append(data.ResourceSpans, rs)
+		}
+		ss, ok := scopes[record.scope]
+		if !ok {
+			ss = &tracev1.ScopeSpans{Scope: record.scope.message.GetScope(), SchemaUrl: record.scope.message.GetSchemaUrl()}
+			scopes[record.scope] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, record.span)
+	}
+	return proto.Clone(data).(*tracev1.TracesData)
+}
+
+// timeRange returns when a trace began and ended: the earliest start and the latest end of its
+// spans. A span that does not say when it started or ended is left out of that end.
+func (t *trace) timeRange() (start, end time.Time) {
+	for _, record := range t.spans {
+		if at := unixNano(record.span.GetStartTimeUnixNano()); !at.IsZero() && (start.IsZero() || at.Before(start)) {
+			start = at
+		}
+		if at := unixNano(record.span.GetEndTimeUnixNano()); at.After(end) {
+			end = at
+		}
+	}
+	return start, end
+}
+
+// summary summarizes a trace as FindTraceSummaries returns it.
+func (t *trace) summary() *storage.TraceSummary {
+	var b tracesummary.Builder
+	// Every span was stored under the trace's ID, so they are all of one trace.
+	_ = b.Add(t.tracesData())
+	return b.Summary()
+}
+
+// sortedTraces returns the traces of the store, the latest first, which is the order a search
+// answers in. Two traces that began together are ordered by ID, so the order is always the same.
+func (s *Store) sortedTraces() []*trace {
+	traces := make([]*trace, 0, len(s.traces))
+	for _, t := range s.traces {
+		traces = append(traces, t)
+	}
+	slices.SortFunc(traces, func(a, b *trace) int {
+		startA, _ := a.timeRange()
+		startB, _ := b.timeRange()
+		if order := startB.Compare(startA); order != 0 {
+			return order
+		}
+		return bytes.Compare(a.id[:], b.id[:])
+	})
+	return traces
+}
+
+// unixNano reads an OTLP timestamp, where zero means the time is not known.
+func unixNano(ns uint64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(ns)).UTC()
+}
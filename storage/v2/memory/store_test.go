@@ -0,0 +1,524 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	expressionpb "github.com/jaegertracing/jaeger-idl/proto-gen/expression/v1"
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+	expression "github.com/jaegertracing/jaeger-idl/query/expression/v1"
+)
+
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testID(trace, size int) []byte {
+	id := make([]byte, size)
+	id[size-1] = byte(trace)
+	return id
+}
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+func intAttribute(key string, value int64) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: value}}}
+}
+
+type testSpan struct {
+	trace, id, parent int
+	name              string
+	kind              tracev1.Span_SpanKind
+	start, duration   time.Duration
+	status            tracev1.Status_StatusCode
+	attributes        []*commonv1.KeyValue
+	events            []*tracev1.Span_Event
+}
+
+func (s testSpan) build() *tracev1.Span {
+	span := &tracev1.Span{
+		TraceId:           testID(s.trace, 16),
+		SpanId:            testID(s.id, 8),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: uint64(base.Add(s.start).UnixNano()),
+		EndTimeUnixNano:   uint64(base.Add(s.start + s.duration).UnixNano()),
+		Status:            &tracev1.Status{Code: s.status},
+		Attributes:        s.attributes,
+		Events:            s.events,
+	}
+	if s.parent != 0 {
+		span.ParentSpanId = testID(s.parent, 8)
+	}
+	return span
+}
+
+func resourceSpans(service string, spans ...testSpan) *tracev1.ResourceSpans {
+	ss := &tracev1.ScopeSpans{Scope: &commonv1.InstrumentationScope{Name: "test", Version: "1.0"}}
+	for _, span := range spans {
+		ss.Spans = append(ss.Spans, span.build())
+	}
+	return &tracev1.ResourceSpans{
+		Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{
+			stringAttribute("service.name", service),
+			stringAttribute("deployment.environment", "prod"),
+		}},
+		ScopeSpans: []*tracev1.ScopeSpans{ss},
+	}
+}
+
+// fixture writes three traces:
+//
+//   - 1, at base: frontend's GET /dispatch calling driver's FindDriverIDs, which failed with an
+//     exception event.
+//   - 2, a second later: frontend's GET /config, alone.
+//   - 3, two seconds later: a redis GET whose parent never arrived.
+func fixture(t *testing.T) *Store {
+	store := NewStore()
+	require.NoError(t, store.WriteTraces(&tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{
+		resourceSpans("frontend",
+			testSpan{trace: 1, id: 1, name: "GET /dispatch", kind: tracev1.Span_SPAN_KIND_SERVER, duration: 500 * time.Millisecond,
+				status: tracev1.Status_STATUS_CODE_OK, attributes: []*commonv1.KeyValue{intAttribute("http.response.status_code", 200)}},
+			testSpan{trace: 2, id: 2, name: "GET /config", kind: tracev1.Span_SPAN_KIND_SERVER, start: time.Second, duration: 10 * time.Millisecond,
+				attributes: []*commonv1.KeyValue{intAttribute("http.response.status_code", 404)}},
+		),
+		resourceSpans("driver",
+			testSpan{trace: 1, id: 3, parent: 1, name: "FindDriverIDs", kind: tracev1.Span_SPAN_KIND_CLIENT, start: 10 * time.Millisecond, duration: 100 * time.Millisecond,
+				status: tracev1.Status_STATUS_CODE_ERROR, attributes: []*commonv1.KeyValue{stringAttribute("param.location", "728,326")},
+				events: []*tracev1.Span_Event{
+					{Name: "retry", TimeUnixNano: uint64(base.Add(20 * time.Millisecond).UnixNano())},
+					{
+						Name: "exception", TimeUnixNano: uint64(base.Add(100 * time.Millisecond).UnixNano()),
+						Attributes: []*commonv1.KeyValue{stringAttribute("exception.type", "timeout")},
+					},
+				}},
+		),
+	}}))
+	require.NoError(t, store.WriteTraces(&tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{
+		resourceSpans("redis",
+			testSpan{trace: 3, id: 4, parent: 9, name: "GET", kind: tracev1.Span_SPAN_KIND_CLIENT, start: 2 * time.Second, duration: time.Millisecond}),
+	}}))
+	return store
+}
+
+type clients struct {
+	traces       storage.TraceReaderClient
+	dependencies storage.DependencyReaderClient
+	capabilities storage.CapabilitiesClient
+}
+
+// serve serves a store over an in-process connection, so that the tests go through the generated
+// stubs as a client would.
+func serve(t *testing.T, store *Store) clients {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	store.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return clients{
+		traces:       storage.NewTraceReaderClient(conn),
+		dependencies: storage.NewDependencyReaderClient(conn),
+		capabilities: storage.NewCapabilitiesClient(conn),
+	}
+}
+
+func receive[T any](t *testing.T, stream grpc.ServerStreamingClient[T]) ([]*T, error) {
+	var received []*T
+	for {
+		message, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+		received = append(received, message)
+	}
+}
+
+// traceIDsOf reads the trace each chunk holds, and checks that it holds only one.
+func traceIDsOf(t *testing.T, chunks []*tracev1.TracesData) []int {
+	var ids []int
+	for _, chunk := range chunks {
+		id := -1
+		for _, rs := range chunk.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					n := int(span.GetTraceId()[15])
+					require.True(t, id == -1 || id == n, "a chunk holds spans of two traces")
+					id = n
+				}
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func filterOf(t *testing.T, call *expression.Call) *expressionpb.Call {
+	data, err := call.MarshalJSON()
+	require.NoError(t, err)
+	message := &expressionpb.Call{}
+	require.NoError(t, protojson.Unmarshal(data, message))
+	return message
+}
+
+func TestStore_WriteTraces(t *testing.T) {
+	store := NewStore()
+	data := &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{resourceSpans("frontend", testSpan{trace: 1, id: 1, name: "a"})}}
+	require.NoError(t, store.WriteTraces(data))
+	data.ResourceSpans[0].ScopeSpans[0].Spans[0].Name = "changed"
+	assert.Equal(t, "a", store.traces[traceID(testID(1, 16))].spans[0].span.GetName(), "the store keeps a copy")
+
+	bad := &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{resourceSpans("frontend",
+		testSpan{trace: 2, id: 2, name: "b"},
+		testSpan{trace: 3, id: 3, name: "c"},
+	)}}
+	bad.ResourceSpans[0].ScopeSpans[0].Spans[1].TraceId = []byte{1, 2, 3}
+	require.ErrorContains(t, store.WriteTraces(bad), `span "c" has a trace ID of 3 bytes`)
+	assert.Len(t, store.traces, 1, "a refused write stores nothing")
+}
+
+func TestStore_GetTraces(t *testing.T) {
+	c := serve(t, fixture(t))
+	tests := []struct {
+		name     string
+		query    []*storage.GetTraceParams
+		expected []int
+	}{
+		{
+			name:     "in the order named",
+			query:    []*storage.GetTraceParams{{TraceId: testID(2, 16)}, {TraceId: testID(1, 16)}},
+			expected: []int{2, 1},
+		},
+		{
+			name:     "unknown and repeated IDs",
+			query:    []*storage.GetTraceParams{{TraceId: testID(1, 16)}, {TraceId: testID(7, 16)}, {TraceId: testID(1, 16)}},
+			expected: []int{1},
+		},
+		{
+			name: "time range holding a span",
+			query: []*storage.GetTraceParams{{
+				TraceId:   testID(1, 16),
+				StartTime: timestamppb.New(base.Add(5 * time.Millisecond)),
+				EndTime:   timestamppb.New(base.Add(10 * time.Millisecond)),
+			}},
+			expected: []int{1},
+		},
+		{
+			name: "time range holding no span",
+			query: []*storage.GetTraceParams{{
+				TraceId:   testID(1, 16),
+				StartTime: timestamppb.New(base.Add(time.Minute)),
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := c.traces.GetTraces(t.Context(), &storage.GetTracesRequest{Query: test.query})
+			require.NoError(t, err)
+			chunks, err := receive(t, stream)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, traceIDsOf(t, chunks))
+		})
+	}
+
+	t.Run("whole trace under its resources", func(t *testing.T) {
+		stream, err := c.traces.GetTraces(t.Context(), &storage.GetTracesRequest{Query: []*storage.GetTraceParams{{TraceId: testID(1, 16)}}})
+		require.NoError(t, err)
+		chunks, err := receive(t, stream)
+		require.NoError(t, err)
+		require.Len(t, chunks, 1)
+		var services []string
+		for _, rs := range chunks[0].GetResourceSpans() {
+			services = append(services, rs.GetResource().GetAttributes()[0].GetValue().GetStringValue())
+			assert.Len(t, rs.GetScopeSpans(), 1)
+		}
+		assert.Equal(t, []string{"frontend", "driver"}, services)
+	})
+
+	t.Run("malformed ID", func(t *testing.T) {
+		stream, err := c.traces.GetTraces(t.Context(), &storage.GetTracesRequest{Query: []*storage.GetTraceParams{{TraceId: []byte{1}}}})
+		require.NoError(t, err)
+		_, err = receive(t, stream)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestStore_GetServicesAndOperations(t *testing.T) {
+	c := serve(t, fixture(t))
+	services, err := c.traces.GetServices(t.Context(), &storage.GetServicesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"driver", "frontend", "redis"}, services.GetServices())
+
+	operations, err := c.traces.GetOperations(t.Context(), &storage.GetOperationsRequest{Service: "frontend"})
+	require.NoError(t, err)
+	assertMessages(t, []*storage.Operation{
+		{Name: "GET /config", SpanKind: "server"},
+		{Name: "GET /dispatch", SpanKind: "server"},
+	}, operations.GetOperations())
+
+	operations, err = c.traces.GetOperations(t.Context(), &storage.GetOperationsRequest{Service: "frontend", SpanKind: "client"})
+	require.NoError(t, err)
+	assert.Empty(t, operations.GetOperations())
+
+	_, err = c.traces.GetOperations(t.Context(), &storage.GetOperationsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStore_FindTraces(t *testing.T) {
+	c := serve(t, fixture(t))
+	status404 := &expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+		&expression.AttributeRef{Key: "http.response.status_code"}, &expression.AnyValue{Value: "404"},
+	}}
+	timeoutEvent := &expression.Call{Op: expression.OpSome, Args: []expression.Expression{
+		&expression.NestedRef{Level: expression.LevelEvent},
+		&expression.Call{Op: expression.OpAnd, Args: []expression.Expression{
+			&expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+				&expression.FieldRef{Level: expression.LevelEvent, Name: expression.EventFieldName}, &expression.AnyValue{Value: "exception"},
+			}},
+			&expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+				&expression.AttributeRef{Key: "exception.type", Level: expression.LevelEvent}, &expression.AnyValue{Value: "timeout"},
+			}},
+		}},
+	}}
+	tests := []struct {
+		name     string
+		query    *storage.TraceQueryParameters
+		expected []int
+		refused  string
+	}{
+		{
+			name:     "everything, latest first",
+			query:    &storage.TraceQueryParameters{SearchDepth: 10},
+			expected: []int{3, 2, 1},
+		},
+		{
+			name:     "search depth",
+			query:    &storage.TraceQueryParameters{SearchDepth: 2},
+			expected: []int{3, 2},
+		},
+		{
+			name:     "service name",
+			query:    &storage.TraceQueryParameters{ServiceName: "frontend", SearchDepth: 10},
+			expected: []int{2, 1},
+		},
+		{
+			name:     "service and operation in the same span",
+			query:    &storage.TraceQueryParameters{ServiceName: "frontend", OperationName: "FindDriverIDs", SearchDepth: 10},
+			expected: nil,
+		},
+		{
+			name: "attribute as text",
+			query: &storage.TraceQueryParameters{SearchDepth: 10, Attributes: []*storage.KeyValue{
+				{Key: "http.response.status_code", Value: &storage.AnyValue{Value: &storage.AnyValue_StringValue{StringValue: "200"}}},
+			}},
+			expected: []int{1},
+		},
+		{
+			name: "resource attribute",
+			query: &storage.TraceQueryParameters{SearchDepth: 10, ServiceName: "redis", Attributes: []*storage.KeyValue{
+				{Key: "deployment.environment", Value: &storage.AnyValue{Value: &storage.AnyValue_StringValue{StringValue: "prod"}}},
+			}},
+			expected: []int{3},
+		},
+		{
+			name:     "duration",
+			query:    &storage.TraceQueryParameters{SearchDepth: 10, DurationMin: durationpb.New(50 * time.Millisecond), DurationMax: durationpb.New(time.Second)},
+			expected: []int{1},
+		},
+		{
+			name: "start time window",
+			query: &storage.TraceQueryParameters{
+				SearchDepth: 10, StartTimeMin: timestamppb.New(base.Add(time.Second)), StartTimeMax: timestamppb.New(base.Add(2 * time.Second)),
+			},
+			expected: []int{2},
+		},
+		{
+			name:     "filter",
+			query:    &storage.TraceQueryParameters{SearchDepth: 10, Filter: filterOf(t, status404)},
+			expected: []int{2},
+		},
+		{
+			name:     "filter on one event",
+			query:    &storage.TraceQueryParameters{SearchDepth: 10, Filter: filterOf(t, timeoutEvent)},
+			expected: []int{1},
+		},
+		{
+			name:    "no search depth",
+			query:   &storage.TraceQueryParameters{},
+			refused: "search_depth has to be positive",
+		},
+		{
+			name: "backwards time window",
+			query: &storage.TraceQueryParameters{
+				SearchDepth: 10, StartTimeMin: timestamppb.New(base.Add(time.Second)), StartTimeMax: timestamppb.New(base),
+			},
+			refused: "start_time_max is before start_time_min",
+		},
+		{
+			name:    "filter with legacy predicates",
+			query:   &storage.TraceQueryParameters{SearchDepth: 10, ServiceName: "frontend", Filter: filterOf(t, status404)},
+			refused: "mutually exclusive",
+		},
+		{
+			name: "filter that does not finalize",
+			query: &storage.TraceQueryParameters{SearchDepth: 10, Filter: filterOf(t, &expression.Call{Op: expression.OpEq, Args: []expression.Expression{
+				&expression.FieldRef{Level: expression.LevelSpan, Name: expression.SpanFieldDuration}, &expression.AnyValue{Value: "soon"},
+			}})},
+			refused: "duration",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream, err := c.traces.FindTraces(t.Context(), &storage.FindTracesRequest{Query: test.query})
+			require.NoError(t, err)
+			chunks, err := receive(t, stream)
+			if test.refused != "" {
+				require.Equal(t, codes.InvalidArgument, status.Code(err), "%v", err)
+				assert.Contains(t, status.Convert(err).Message(), test.refused)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, traceIDsOf(t, chunks))
+
+			ids, err := c.traces.FindTraceIDs(t.Context(), &storage.FindTraceIDsRequest{Query: test.query})
+			require.NoError(t, err)
+			var found []int
+			for _, id := range ids.GetTraceIds() {
+				found = append(found, int(id.GetTraceId()[15]))
+			}
+			assert.Equal(t, test.expected, found, "FindTraceIDs")
+		})
+	}
+}
+
+func TestStore_FindTraceIDs_TimeRange(t *testing.T) {
+	c := serve(t, fixture(t))
+	resp, err := c.traces.FindTraceIDs(t.Context(), &storage.FindTraceIDsRequest{Query: &storage.TraceQueryParameters{ServiceName: "driver", SearchDepth: 1}})
+	require.NoError(t, err)
+	assertMessages(t, []*storage.FoundTraceID{{
+		TraceId: testID(1, 16),
+		Start:   timestamppb.New(base),
+		End:     timestamppb.New(base.Add(500 * time.Millisecond)),
+	}}, resp.GetTraceIds())
+}
+
+func TestStore_FindTraceSummaries(t *testing.T) {
+	c := serve(t, fixture(t))
+	stream, err := c.traces.FindTraceSummaries(t.Context(), &storage.FindTraceSummariesRequest{Query: &storage.TraceQueryParameters{SearchDepth: 10}})
+	require.NoError(t, err)
+	chunks, err := receive(t, stream)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	expected := []*storage.TraceSummary{
+		{
+			TraceId: testID(3, 16), RootServiceName: "redis", RootOperationName: "GET",
+			MinStartTimeUnixNano: uint64(base.Add(2 * time.Second).UnixNano()),
+			MaxEndTimeUnixNano:   uint64(base.Add(2*time.Second + time.Millisecond).UnixNano()),
+			SpanCount:            1, OrphanSpanCount: 1,
+			Services: []*storage.ServiceSummary{{Name: "redis", SpanCount: 1}},
+		},
+		{
+			TraceId: testID(2, 16), RootServiceName: "frontend", RootOperationName: "GET /config",
+			MinStartTimeUnixNano: uint64(base.Add(time.Second).UnixNano()),
+			MaxEndTimeUnixNano:   uint64(base.Add(time.Second + 10*time.Millisecond).UnixNano()),
+			SpanCount:            1,
+			Services:             []*storage.ServiceSummary{{Name: "frontend", SpanCount: 1}},
+		},
+		{
+			TraceId: testID(1, 16), RootServiceName: "frontend", RootOperationName: "GET /dispatch",
+			MinStartTimeUnixNano: uint64(base.UnixNano()),
+			MaxEndTimeUnixNano:   uint64(base.Add(500 * time.Millisecond).UnixNano()),
+			SpanCount:            2, ErrorSpanCount: 1,
+			Services: []*storage.ServiceSummary{{Name: "driver", SpanCount: 1, ErrorSpanCount: 1}, {Name: "frontend", SpanCount: 1}},
+		},
+	}
+	assertMessages(t, expected, chunks[0].GetSummaries())
+
+	stream, err = c.traces.FindTraceSummaries(t.Context(), &storage.FindTraceSummariesRequest{Query: &storage.TraceQueryParameters{ServiceName: "nobody", SearchDepth: 10}})
+	require.NoError(t, err)
+	chunks, err = receive(t, stream)
+	require.NoError(t, err)
+	assert.Empty(t, chunks, "nothing is sent when nothing matches")
+}
+
+func TestStore_GetDependencies(t *testing.T) {
+	store := NewStore()
+	store.WriteDependencies(base, &storage.Dependency{Parent: "frontend", Child: "driver", CallCount: 2})
+	store.WriteDependencies(base.Add(time.Hour),
+		&storage.Dependency{Parent: "frontend", Child: "driver", CallCount: 3},
+		&storage.Dependency{Parent: "driver", Child: "redis", CallCount: 7, Source: "jaeger"},
+	)
+	store.WriteDependencies(base.Add(2*time.Hour), &storage.Dependency{Parent: "frontend", Child: "customer", CallCount: 1})
+	c := serve(t, store)
+
+	resp, err := c.dependencies.GetDependencies(t.Context(), &storage.GetDependenciesRequest{
+		StartTime: timestamppb.New(base),
+		EndTime:   timestamppb.New(base.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	assertMessages(t, []*storage.Dependency{
+		{Parent: "driver", Child: "redis", CallCount: 7, Source: "jaeger"},
+		{Parent: "frontend", Child: "driver", CallCount: 5},
+	}, resp.GetDependencies())
+
+	resp, err = c.dependencies.GetDependencies(t.Context(), &storage.GetDependenciesRequest{StartTime: timestamppb.New(base.Add(90 * time.Minute))})
+	require.NoError(t, err)
+	assertMessages(t, []*storage.Dependency{{Parent: "frontend", Child: "customer", CallCount: 1}}, resp.GetDependencies())
+
+	_, err = c.dependencies.GetDependencies(t.Context(), &storage.GetDependenciesRequest{
+		StartTime: timestamppb.New(base.Add(time.Hour)),
+		EndTime:   timestamppb.New(base),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStore_GetCapabilities(t *testing.T) {
+	c := serve(t, NewStore())
+	resp, err := c.capabilities.GetCapabilities(t.Context(), &storage.GetCapabilitiesRequest{})
+	require.NoError(t, err)
+	search := resp.GetSearch()
+	assert.True(t, search.GetWithoutServiceName())
+	assert.True(t, search.GetSameSpanConjunction())
+	assert.Equal(t, []string{"span", "resource", "scope", "event", "link"}, search.GetFilter().GetLevels())
+	assert.Len(t, search.GetFilter().GetOperators(), 14)
+}
+
+// assertMessages asserts that two lists hold equal messages, in the same order.
+func assertMessages[M proto.Message](t *testing.T, expected, actual []M) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracesummary computes the TraceSummary that FindTraceSummaries returns (see the storage
+// v2 API) from the spans of a trace, as OTLP TracesData. A backend that stores whole traces rather
+// than summaries builds one from the chunks its GetTraces would stream, and a caller given a
+// backend that does not implement FindTraceSummaries builds one from what FindTraces streamed, so
+// that a summary says the same thing whichever computed it.
+//
+// A summary is of the spans given, which need not be the whole trace. The root is the earliest
+// span without a parent; a trace whose root was lost, or has not arrived yet, has none, and the
+// earliest of its orphans, spans whose parent is not among those given, stands in for it. A span
+// errs when its status is error.
+package tracesummary
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+// traceIDSize is the size of a trace ID, which a TraceSummary carries as bytes.
+const traceIDSize = 16
+
+// Builder builds the summary of one trace from the chunks it is streamed in. Which span is the
+// root and which are orphans is only known once every span has been seen, so the summary is built
+// when asked for, and reads every span added until then. The zero value is ready for use.
+type Builder struct {
+	traceID []byte
+	spans   []span
+}
+
+// span is what a summary reads of one span.
+type span struct {
+	id, parent    string
+	service, name string
+	start, end    uint64
+	errs          bool
+}
+
+// Summarize returns the summary of the trace the chunks hold.
+func Summarize(chunks ...*tracev1.TracesData) (*storage.TraceSummary, error) {
+	var b Builder
+	for _, chunk := range chunks {
+		if err := b.Add(chunk); err != nil {
+			return nil, err
+		}
+	}
+	if len(b.spans) == 0 {
+		return nil, errors.New("no spans to summarize")
+	}
+	return b.Summary(), nil
+}
+
+// Add adds the spans of a chunk. Every span has to carry the 16-byte trace ID of the first one
+// added, and a chunk holding one that does not is refused whole, so the builder is left as it was.
+func (b *Builder) Add(chunk *tracev1.TracesData) error {
+	traceID := b.traceID
+	var spans []span
+	for _, rs := range chunk.GetResourceSpans() {
+		service := ""
+		for _, kv := range rs.GetResource().GetAttributes() {
+			if kv.GetKey() == "service.name" {
+				service = kv.GetValue().GetStringValue()
+			}
+		}
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				switch {
+				case len(s.GetTraceId()) != traceIDSize:
+					return fmt.Errorf("span %q has a trace ID of %d bytes, and a trace ID has %d", s.GetName(), len(s.GetTraceId()), traceIDSize)
+				case traceID == nil:
+					traceID = s.GetTraceId()
+				case !bytes.Equal(traceID, s.GetTraceId()):
+					return fmt.Errorf("span %q belongs to trace %x, and the summary is of trace %x", s.GetName(), s.GetTraceId(), traceID)
+				}
+				spans = append(spans, span{
+					id:      spanID(s.GetSpanId()),
+					parent:  spanID(s.GetParentSpanId()),
+					service: service,
+					name:    s.GetName(),
+					start:   s.GetStartTimeUnixNano(),
+					end:     s.GetEndTimeUnixNano(),
+					errs:    s.GetStatus().GetCode() == tracev1.Status_STATUS_CODE_ERROR,
+				})
+			}
+		}
+	}
+	b.traceID = slices.Clone(traceID)
+	b.spans = append(b.spans, spans...)
+	return nil
+}
+
+// Summary returns the summary of the spans added so far, or nil when none has been. Services are
+// sorted by name, and a time no span gave is left at zero, which the summary reads as unknown.
+func (b *Builder) Summary() *storage.TraceSummary {
+	if len(b.spans) == 0 {
+		return nil
+	}
+	present := map[string]bool{}
+	for _, s := range b.spans {
+		if s.id != "" {
+			present[s.id] = true
+		}
+	}
+	summary := &storage.TraceSummary{TraceId: slices.Clone(b.traceID)}
+	var root, orphanRoot *span
+	services := map[string]*storage.ServiceSummary{}
+	for i := range b.spans {
+		s := &b.spans[i]
+		summary.SpanCount++
+		if s.errs {
+			summary.ErrorSpanCount++
+		}
+		switch {
+		case s.parent == "":
+			root = earlier(root, s)
+		case !present[s.parent]:
+			summary.OrphanSpanCount++
+			orphanRoot = earlier(orphanRoot, s)
+		}
+		service, ok := services[s.service]
+		if !ok {
+			service = &storage.ServiceSummary{Name: s.service}
+			services[s.service] = service
+			summary.Services = append(summary.Services, service)
+		}
+		service.SpanCount++
+		if s.errs {
+			service.ErrorSpanCount++
+		}
+		if s.start != 0 && (summary.MinStartTimeUnixNano == 0 || s.start < summary.MinStartTimeUnixNano) {
+			summary.MinStartTimeUnixNano = s.start
+		}
+		summary.MaxEndTimeUnixNano = max(summary.MaxEndTimeUnixNano, s.end)
+	}
+	if root == nil {
+		root = orphanRoot
+	}
+	if root != nil {
+		summary.RootServiceName = root.service
+		summary.RootOperationName = root.name
+	}
+	slices.SortFunc(summary.Services, func(a, b *storage.ServiceSummary) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return summary
+}
+
+// earlier returns whichever of two spans started first, keeping the one it already has on a tie,
+// which is the one added first. A span that does not say when it started comes after every span
+// that does.
+func earlier(current, candidate *span) *span {
+	if current == nil || (candidate.start != 0 && (current.start == 0 || candidate.start < current.start)) {
+		return candidate
+	}
+	return current
+}
+
+// spanID reads a span ID as a map key. OTLP writes a missing ID, such as the parent of a root, as
+// empty or as zeros, and either is read as no ID.
+func spanID(id []byte) string {
+	if bytes.Equal(id, make([]byte, len(id))) {
+		return ""
+	}
+	return string(id)
+}
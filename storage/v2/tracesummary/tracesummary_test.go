@@ -0,0 +1,214 @@
+// Copyright (c) 2026 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package tracesummary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	storage "github.com/jaegertracing/jaeger-idl/proto-gen/storage/v2"
+)
+
+var traceID = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+type testSpan struct {
+	id, parent byte
+	name       string
+	start, end uint64
+	failed     bool
+}
+
+// chunk builds a chunk of spans a service wrote. An ID of zero is written as empty.
+func chunk(service string, spans ...testSpan) *tracev1.TracesData {
+	ss := &tracev1.ScopeSpans{}
+	for _, s := range spans {
+		span := &tracev1.Span{TraceId: traceID, Name: s.name, StartTimeUnixNano: s.start, EndTimeUnixNano: s.end}
+		if s.id != 0 {
+			span.SpanId = []byte{0, 0, 0, 0, 0, 0, 0, s.id}
+		}
+		if s.parent != 0 {
+			span.ParentSpanId = []byte{0, 0, 0, 0, 0, 0, 0, s.parent}
+		}
+		if s.failed {
+			span.Status = &tracev1.Status{Code: tracev1.Status_STATUS_CODE_ERROR}
+		}
+		ss.Spans = append(ss.Spans, span)
+	}
+	return &tracev1.TracesData{ResourceSpans: []*tracev1.ResourceSpans{{
+		Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{
+			{Key: "service.name", Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: service}}},
+		}},
+		ScopeSpans: []*tracev1.ScopeSpans{ss},
+	}}}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []*tracev1.TracesData
+		expected *storage.TraceSummary
+	}{
+		{
+			name: "whole trace over two chunks",
+			chunks: []*tracev1.TracesData{
+				chunk("frontend", testSpan{id: 1, name: "GET /", start: 100, end: 500}),
+				chunk("backend",
+					testSpan{id: 2, parent: 1, name: "query", start: 150, end: 300, failed: true},
+					testSpan{id: 3, parent: 2, name: "fetch", start: 160, end: 200, failed: true}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "frontend", RootOperationName: "GET /",
+				MinStartTimeUnixNano: 100, MaxEndTimeUnixNano: 500,
+				SpanCount: 3, ErrorSpanCount: 2,
+				Services: []*storage.ServiceSummary{
+					{Name: "backend", SpanCount: 2, ErrorSpanCount: 2},
+					{Name: "frontend", SpanCount: 1},
+				},
+			},
+		},
+		{
+			name: "child before its parent",
+			chunks: []*tracev1.TracesData{
+				chunk("backend", testSpan{id: 2, parent: 1, name: "query", start: 150, end: 300}),
+				chunk("frontend", testSpan{id: 1, name: "GET /", start: 100, end: 500}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "frontend", RootOperationName: "GET /",
+				MinStartTimeUnixNano: 100, MaxEndTimeUnixNano: 500,
+				SpanCount: 2,
+				Services: []*storage.ServiceSummary{
+					{Name: "backend", SpanCount: 1},
+					{Name: "frontend", SpanCount: 1},
+				},
+			},
+		},
+		{
+			name: "missing root",
+			chunks: []*tracev1.TracesData{
+				chunk("backend",
+					testSpan{id: 3, parent: 1, name: "later", start: 200, end: 250},
+					testSpan{id: 2, parent: 1, name: "earlier", start: 150, end: 300},
+					testSpan{id: 4, parent: 2, name: "child", start: 160, end: 170}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "backend", RootOperationName: "earlier",
+				MinStartTimeUnixNano: 150, MaxEndTimeUnixNano: 300,
+				SpanCount: 3, OrphanSpanCount: 2,
+				Services: []*storage.ServiceSummary{{Name: "backend", SpanCount: 3}},
+			},
+		},
+		{
+			name: "earliest of two roots",
+			chunks: []*tracev1.TracesData{
+				chunk("a", testSpan{id: 1, name: "second", start: 200, end: 300}),
+				chunk("b", testSpan{id: 2, name: "first", start: 100, end: 150}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "b", RootOperationName: "first",
+				MinStartTimeUnixNano: 100, MaxEndTimeUnixNano: 300,
+				SpanCount: 2,
+				Services:  []*storage.ServiceSummary{{Name: "a", SpanCount: 1}, {Name: "b", SpanCount: 1}},
+			},
+		},
+		{
+			name: "root without a start time",
+			chunks: []*tracev1.TracesData{
+				chunk("a", testSpan{id: 1, name: "unknown"}, testSpan{id: 2, name: "known", start: 100}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "a", RootOperationName: "known",
+				MinStartTimeUnixNano: 100,
+				SpanCount:            2,
+				Services:             []*storage.ServiceSummary{{Name: "a", SpanCount: 2}},
+			},
+		},
+		{
+			name: "tie goes to the first added",
+			chunks: []*tracev1.TracesData{
+				chunk("a", testSpan{id: 1, name: "one", start: 100, end: 200}, testSpan{id: 2, name: "two", start: 100, end: 200}),
+			},
+			expected: &storage.TraceSummary{
+				RootServiceName: "a", RootOperationName: "one",
+				MinStartTimeUnixNano: 100, MaxEndTimeUnixNano: 200,
+				SpanCount: 2,
+				Services:  []*storage.ServiceSummary{{Name: "a", SpanCount: 2}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary, err := Summarize(test.chunks...)
+			require.NoError(t, err)
+			test.expected.TraceId = traceID
+			assert.True(t, proto.Equal(test.expected, summary), "expected %v, got %v", test.expected, summary)
+		})
+	}
+}
+
+func TestSummarize_ZeroParentIsNoParent(t *testing.T) {
+	data := chunk("a", testSpan{id: 1, name: "root", start: 100})
+	data.ResourceSpans[0].ScopeSpans[0].Spans[0].ParentSpanId = make([]byte, 8)
+	summary, err := Summarize(data)
+	require.NoError(t, err)
+	assert.Equal(t, "root", summary.GetRootOperationName())
+	assert.Zero(t, summary.GetOrphanSpanCount())
+}
+
+func TestSummarize_Errors(t *testing.T) {
+	otherTrace := chunk("a", testSpan{id: 2, name: "elsewhere"})
+	otherTrace.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceId = make([]byte, 16)
+	shortID := chunk("a", testSpan{id: 2, name: "short"})
+	shortID.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceId = []byte{1}
+	tests := []struct {
+		name   string
+		chunks []*tracev1.TracesData
+		err    string
+	}{
+		{name: "nothing", err: "no spans to summarize"},
+		{name: "empty chunk", chunks: []*tracev1.TracesData{{}}, err: "no spans to summarize"},
+		{
+			name:   "two traces",
+			chunks: []*tracev1.TracesData{chunk("a", testSpan{id: 1, name: "root"}), otherTrace},
+			err:    `span "elsewhere" belongs to trace 00000000000000000000000000000000, and the summary is of trace 0102030405060708090a0b0c0d0e0f10`,
+		},
+		{
+			name:   "short trace ID",
+			chunks: []*tracev1.TracesData{shortID},
+			err:    `span "short" has a trace ID of 1 bytes, and a trace ID has 16`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Summarize(test.chunks...)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	var b Builder
+	assert.Nil(t, b.Summary(), "nothing added")
+
+	require.NoError(t, b.Add(chunk("backend", testSpan{id: 2, parent: 1, name: "query", start: 150, end: 300})))
+	partial := b.Summary()
+	assert.Equal(t, "query", partial.GetRootOperationName(), "an orphan stands in for the root that has not arrived")
+	assert.Equal(t, int32(1), partial.GetOrphanSpanCount())
+
+	bad := chunk("frontend", testSpan{id: 1, name: "GET /", start: 100, end: 500}, testSpan{id: 3, name: "bad"})
+	bad.ResourceSpans[0].ScopeSpans[0].Spans[1].TraceId = nil
+	require.Error(t, b.Add(bad))
+	assert.True(t, proto.Equal(partial, b.Summary()), "a refused chunk adds nothing")
+
+	require.NoError(t, b.Add(chunk("frontend", testSpan{id: 1, name: "GET /", start: 100, end: 500})))
+	whole := b.Summary()
+	assert.Equal(t, "GET /", whole.GetRootOperationName())
+	assert.Zero(t, whole.GetOrphanSpanCount(), "the parent arrived")
+	assert.Equal(t, int32(2), whole.GetSpanCount())
+}